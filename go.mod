@@ -3,6 +3,7 @@ module github.com/cidertool/cider
 go 1.16
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/Masterminds/semver/v3 v3.1.1
 	github.com/alessio/shellescape v1.4.1
 	github.com/apex/log v1.9.0
@@ -11,7 +12,9 @@ require (
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/manifoldco/promptui v0.8.0
 	github.com/spf13/cobra v1.1.3
+	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,82 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"errors"
+
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/pipe/env"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/spf13/pflag"
+)
+
+// ErrNoAppsSelected happens when none of the apps in the configuration file were selected.
+var ErrNoAppsSelected = errors.New("no apps selected, use --app or --all-apps to select apps from the configuration file")
+
+// apiOpts are the options shared by commands that work with the apps in a project through the
+// App Store Connect API.
+type apiOpts struct {
+	config  string
+	profile string
+	apps    []string
+	allApps bool
+	// client is used instead of a client for the App Store Connect API, if set.
+	client client.Client
+}
+
+func (opts *apiOpts) addFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&opts.config, "config", "f", "", "Load configuration from file")
+	flags.StringVar(&opts.profile, "profile", "", "Profile overlay to patch over the configuration file")
+	flags.StringArrayVarP(&opts.apps, "app", "a", []string{}, `Process the given app, providing the app key name used in your configuration file.
+You can omit this flag if your configuration file has only one app defined.`)
+	flags.BoolVarP(&opts.allApps, "all-apps", "A", false, "Process all apps in the configuration file")
+}
+
+// newAPIContext loads the project configuration and the App Store Connect credentials from the environment,
+// and returns a context with the selected apps along with a client to use with it.
+func newAPIContext(opts apiOpts, logger log.Interface) (*context.Context, client.Client, error) {
+	cfg, source, err := loadConfig(opts.config, "", opts.profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := context.New(cfg)
+	ctx.ConfigSource = source
+	ctx.Profile = opts.profile
+	ctx.Log = logger
+	ctx.AppsToRelease = cfg.AppsMatching(opts.apps, opts.allApps)
+
+	if len(ctx.AppsToRelease) == 0 {
+		return nil, nil, ErrNoAppsSelected
+	}
+
+	if opts.client != nil {
+		return ctx, opts.client, nil
+	}
+
+	if err := (env.Pipe{}).Run(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, client.New(ctx), nil
+}
@@ -21,11 +21,17 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package clicommand
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/cidertool/cider/internal/log"
 	"github.com/cidertool/cider/internal/pipe/defaults"
+	"github.com/cidertool/cider/internal/pipe/template"
+	"github.com/cidertool/cider/pkg/config"
 	"github.com/cidertool/cider/pkg/context"
 	"github.com/fatih/color"
+	"github.com/hashicorp/go-multierror"
 	"github.com/spf13/cobra"
 )
 
@@ -33,15 +39,18 @@ type checkCmd struct {
 	cmd            *cobra.Command
 	debugFlagValue *bool
 	config         string
+	profile        string
+	assets         bool
 }
 
 func newCheckCmd(debugFlagValue *bool) *checkCmd {
 	var root = &checkCmd{debugFlagValue: debugFlagValue}
 
 	var cmd = &cobra.Command{
-		Use:           "check",
-		Short:         "Checks if the configuration is valid",
-		Long:          `Use to validate your configuration file.`,
+		Use:   "check",
+		Short: "Checks if the configuration is valid",
+		Long: `Use to validate your configuration file. The file is checked against the configuration
+schema, which is also published for editors at https://cidertool.github.io/cider/schema.json.`,
 		Example:       "cider check",
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -49,6 +58,8 @@ func newCheckCmd(debugFlagValue *bool) *checkCmd {
 	}
 
 	cmd.Flags().StringVarP(&root.config, "config", "f", "", "Configuration file to check")
+	cmd.Flags().StringVar(&root.profile, "profile", "", "Profile overlay to patch over the configuration file")
+	cmd.Flags().BoolVar(&root.assets, "assets", false, "Resolve the templates, globs and directories of screenshot and preview sets, and list the files they refer to")
 
 	root.cmd = cmd
 
@@ -58,17 +69,77 @@ func newCheckCmd(debugFlagValue *bool) *checkCmd {
 func (cmd *checkCmd) Run(c *cobra.Command, args []string) error {
 	logger := newLogger(cmd.debugFlagValue)
 
-	cfg, err := loadConfig(cmd.config, "")
+	path, err := findConfig(cmd.config, "")
 	if err != nil {
 		return err
 	}
 
+	source, err := config.LoadSource(path, cmd.profile)
+	if err != nil {
+		logValidationErrors(logger, err)
+
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := source.Validate(); err != nil {
+		logValidationErrors(logger, err)
+		logger.Error(color.New(color.Bold).Sprintf("config does not match schema"))
+
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	cfg, err := source.Decode()
+	if err != nil {
+		logValidationErrors(logger, err)
+
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := source.Check(cfg); err != nil {
+		logValidationErrors(logger, err)
+		logger.Error(color.New(color.Bold).Sprintf("config has conflicting values"))
+
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if deprecations := source.Deprecations(); len(deprecations) > 0 {
+		for _, d := range deprecations {
+			logger.Warn(validationErrorMessage(d))
+		}
+
+		logger.Warn(color.New(color.Bold).Sprintf("config uses deprecated keys, run `cider config migrate` to upgrade it"))
+	}
+
+	if missing := source.MissingScreenshots(cfg); len(missing) > 0 {
+		for _, m := range missing {
+			logger.Warn(validationErrorMessage(m))
+		}
+
+		logger.Warn(color.New(color.Bold).Sprintf("config is missing screenshots required for review, unless they are already in App Store Connect"))
+	}
+
 	var ctx = context.New(cfg)
+	ctx.ConfigSource = source
+	ctx.Profile = cmd.profile
 
 	if err := context.NewInterrupt().Run(ctx, func() error {
 		logger.Info(color.New(color.Bold).Sprint("checking config:"))
 
-		return defaults.Pipe{}.Run(ctx)
+		if err := (defaults.Pipe{}).Run(ctx); err != nil {
+			return err
+		}
+
+		if !cmd.assets {
+			return nil
+		}
+
+		if err := (template.Pipe{}).Run(ctx); err != nil {
+			return err
+		}
+
+		logAssets(logger, ctx.Config)
+
+		return nil
 	}); err != nil {
 		logger.WithError(err).Error(color.New(color.Bold).Sprintf("config is invalid"))
 
@@ -79,3 +150,91 @@ func (cmd *checkCmd) Run(c *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// logAssets logs the files of the preview and screenshot sets of each version to be released, after screenshot
+// fallbacks are applied.
+func logAssets(logger log.Interface, project config.Project) {
+	for _, name := range project.AppsMatching(nil, true) {
+		for _, version := range project[name].Versions.PlatformVersions() {
+			locales := make([]string, 0, len(version.Localizations))
+			for locale := range version.Localizations {
+				locales = append(locales, locale)
+			}
+
+			sort.Strings(locales)
+
+			for _, locale := range locales {
+				loc := version.Localizations[locale]
+				fields := log.Fields{
+					"app":      name,
+					"platform": version.Platform,
+					"locale":   locale,
+				}
+
+				previews := make(map[string][]string, len(loc.PreviewSets))
+				for previewType, set := range loc.PreviewSets {
+					for _, preview := range set {
+						previews[string(previewType)] = append(previews[string(previewType)], preview.Path)
+					}
+				}
+
+				screenshots := make(map[string][]string, len(loc.ScreenshotSets))
+				for screenshotType, set := range loc.ScreenshotSets.WithFallbacks(loc.ScreenshotFallbacks) {
+					for _, screenshot := range set {
+						screenshots[string(screenshotType)] = append(screenshots[string(screenshotType)], screenshot.Path)
+					}
+				}
+
+				for _, assets := range []struct {
+					kind  string
+					paths map[string][]string
+				}{{"previews", previews}, {"screenshots", screenshots}} {
+					types := make([]string, 0, len(assets.paths))
+					for t := range assets.paths {
+						types = append(types, t)
+					}
+
+					sort.Strings(types)
+
+					for _, t := range types {
+						for _, path := range assets.paths[t] {
+							logger.WithFields(fields).WithField(assets.kind, t).Info(path)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// logValidationErrors logs each error in err on its own, followed by an excerpt of the offending
+// line for errors that carry a position in the configuration file.
+func logValidationErrors(logger log.Interface, err error) {
+	var errs = []error{err}
+
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		errs = merr.Errors
+	}
+
+	for _, err := range errs {
+		var verr config.ValidationError
+		if !errors.As(err, &verr) {
+			logger.Error(err.Error())
+
+			continue
+		}
+
+		logger.Error(validationErrorMessage(verr))
+	}
+}
+
+// validationErrorMessage formats err for the log, followed by an excerpt of the offending line
+// if it has a position in the configuration file.
+func validationErrorMessage(err config.ValidationError) string {
+	if excerpt := err.Excerpt(); excerpt != "" {
+		return err.Error() + "\n\n" + excerpt + "\n"
+	}
+
+	return err.Error()
+}
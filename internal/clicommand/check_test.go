@@ -53,3 +53,160 @@ func TestCheckCmd(t *testing.T) {
 	err = cmd.cmd.Execute()
 	assert.NoError(t, err)
 }
+
+func TestCheckCmd_SchemaError(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newCheckCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte("My App:\n  id: com.app\n  platform: iOS\n"), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `foo.yaml:3:3: My App: unknown field "platform"`)
+}
+
+func TestCheckCmd_ConflictingValues(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newCheckCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(`My App:
+  id: com.app
+  localizations: {}
+  versions:
+    platform: iOS
+    localizations: {}
+  testflight:
+    enableAutoNotify: false
+    licenseAgreement: ''
+    localizations: {}
+  inAppPurchases:
+    com.app.premium:
+      referenceName: Premium
+      type: nonConsumable
+      localizations: {}
+  subscriptionGroups:
+    Premium:
+      localizations: {}
+      subscriptions:
+        com.app.premium:
+          referenceName: Premium
+          duration: oneMonth
+          localizations: {}
+`), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "foo.yaml:21:11: My App.subscriptionGroups.Premium.subscriptions.com.app.premium: product ID com.app.premium is already used by My App.inAppPurchases.com.app.premium")
+}
+
+func TestCheckCmd_MissingScreenshots(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newCheckCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(`My App:
+  id: com.app
+  localizations: {}
+  versions:
+    platform: macOS
+    localizations:
+      en-US:
+        description: My App
+        screenshotSets:
+          iphone65:
+            - path: iphone65.png
+  testflight:
+    enableAutoNotify: false
+    licenseAgreement: ''
+    localizations: {}
+`), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	// Missing screenshots are only warned about, as they may already be in App Store Connect
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestCheckCmd_Assets(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newCheckCmd(&noDebug)
+
+	var dir = t.TempDir()
+
+	var path = filepath.Join(dir, "foo.yaml")
+
+	err := os.MkdirAll(filepath.Join(dir, "en-US"), 0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "en-US", "1.png"), []byte{}, 0600)
+	assert.NoError(t, err)
+
+	err = os.WriteFile(path, []byte(`My App:
+  id: com.app
+  localizations: {}
+  versions:
+    platform: iOS
+    localizations:
+      en-US:
+        description: My App
+        screenshotSets:
+          iphone65:
+            - path: `+dir+`/{{ .locale }}/*.png
+        screenshotFallbacks:
+          ipadPro3Gen129: iphone65
+      ja:
+        description: 私のアプリ
+        screenshotSets:
+          iphone65:
+            - path: `+dir+`/{{ .locale }}/*.png
+  testflight:
+    enableAutoNotify: false
+    licenseAgreement: ''
+    localizations: {}
+`), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+
+	// Resolving the assets finds that no screenshots were written for ja
+	cmd.assets = true
+
+	err = cmd.cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no assets found matching "+filepath.Join(dir, "ja", "*.png"))
+
+	err = os.MkdirAll(filepath.Join(dir, "ja"), 0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "ja", "1.png"), []byte{}, 0600)
+	assert.NoError(t, err)
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+}
@@ -31,24 +31,44 @@ import (
 // ErrConfigNotFound happens if a config file could not be found at any of the default locations.
 var ErrConfigNotFound = errors.New("config file not found at any default path")
 
-func loadConfig(path string, wd string) (config.Project, error) {
+func loadConfig(path string, wd string, profile string) (config.Project, *config.Source, error) {
+	path, err := findConfig(path, wd)
+	if err != nil {
+		return config.Project{}, nil, err
+	}
+
+	source, err := config.LoadSource(path, profile)
+	if err != nil {
+		return config.Project{}, nil, err
+	}
+
+	proj, err := source.Decode()
+
+	return proj, source, err
+}
+
+func findConfig(path string, wd string) (string, error) {
 	if path != "" {
-		return config.Load(path)
+		return path, nil
 	}
 
-	for _, f := range [4]string{
+	for _, f := range [8]string{
 		".cider.yml",
 		".cider.yaml",
+		".cider.json",
+		".cider.toml",
 		"cider.yml",
 		"cider.yaml",
+		"cider.json",
+		"cider.toml",
 	} {
-		proj, err := config.Load(filepath.Join(wd, f))
-		if err != nil && os.IsNotExist(err) {
+		path = filepath.Join(wd, f)
+		if _, err := os.Stat(path); err != nil && os.IsNotExist(err) {
 			continue
 		}
 
-		return proj, err
+		return path, nil
 	}
 
-	return config.Project{}, ErrConfigNotFound
+	return "", ErrConfigNotFound
 }
@@ -40,7 +40,7 @@ func TestConfig_Happy_CustomPath(t *testing.T) {
 	assert.NoError(t, err)
 	err = os.WriteFile(path, []byte(s), 0600)
 	assert.NoError(t, err)
-	cfg, err := loadConfig(path, "")
+	cfg, _, err := loadConfig(path, "", "")
 	assert.NoError(t, err)
 	assert.Empty(t, cfg)
 }
@@ -58,7 +58,7 @@ func TestConfig_Happy_DefaultPath(t *testing.T) {
 	assert.NoError(t, err)
 	err = os.WriteFile(path, []byte(s), 0600)
 	assert.NoError(t, err)
-	cfg, err := loadConfig("", folder)
+	cfg, _, err := loadConfig("", folder, "")
 	assert.NoError(t, err)
 	assert.Empty(t, cfg)
 }
@@ -66,7 +66,46 @@ func TestConfig_Happy_DefaultPath(t *testing.T) {
 func TestConfig_Err_DoesntExist(t *testing.T) {
 	t.Parallel()
 
-	cfg, err := loadConfig("", t.TempDir())
+	cfg, _, err := loadConfig("", t.TempDir(), "")
 	assert.Error(t, err)
 	assert.Empty(t, cfg)
 }
+
+func TestConfig_Happy_Profile(t *testing.T) {
+	t.Parallel()
+
+	var folder = t.TempDir()
+
+	err := os.WriteFile(filepath.Join(folder, ".cider.yml"), []byte("My App:\n  id: com.app\n  primaryLocale: en-US\n"), 0600)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(folder, ".cider.staging.yml"), []byte("My App:\n  id: com.app.beta\n"), 0600)
+	assert.NoError(t, err)
+	cfg, _, err := loadConfig("", folder, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "com.app.beta", cfg["My App"].BundleID)
+	assert.Equal(t, "en-US", cfg["My App"].PrimaryLocale)
+}
+
+func TestConfig_Err_ProfileDoesntExist(t *testing.T) {
+	t.Parallel()
+
+	var folder = t.TempDir()
+
+	err := os.WriteFile(filepath.Join(folder, ".cider.yml"), []byte("My App:\n  id: com.app\n"), 0600)
+	assert.NoError(t, err)
+	cfg, _, err := loadConfig("", folder, "staging")
+	assert.ErrorAs(t, err, &config.ErrProfileNotFound{})
+	assert.Empty(t, cfg)
+}
+
+func TestConfig_Happy_DefaultPathJSON(t *testing.T) {
+	t.Parallel()
+
+	var folder = t.TempDir()
+
+	err := os.WriteFile(filepath.Join(folder, "cider.json"), []byte(`{"My App": {"id": "com.app"}}`), 0600)
+	assert.NoError(t, err)
+	cfg, _, err := loadConfig("", folder, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "com.app", cfg["My App"].BundleID)
+}
@@ -0,0 +1,286 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/closer"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	feedbackFormatCSV  = "csv"
+	feedbackFormatJSON = "json"
+)
+
+// feedbackColumns are the columns of the CSV format of feedback.
+// nolint: gochecknoglobals
+var feedbackColumns = []string{"app", "kind", "createdDate", "tester", "build", "device", "osVersion", "comment", "screenshots"}
+
+type errUnsupportedFeedbackFormat struct {
+	Format string
+}
+
+func (e errUnsupportedFeedbackFormat) Error() string {
+	return fmt.Sprintf("unsupported feedback format %s, expected csv or json", e.Format)
+}
+
+type errInvalidSince struct {
+	Value string
+}
+
+func (e errInvalidSince) Error() string {
+	return fmt.Sprintf("invalid value %s for --since, expected a number of days such as 7d or a duration such as 12h", e.Value)
+}
+
+// appFeedback is feedback along with the name of the app in the configuration file it was submitted for.
+type appFeedback struct {
+	App string `json:"app"`
+	client.Feedback
+}
+
+type testflightFeedbackCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+	since          string
+	format         string
+	output         string
+	screenshots    string
+}
+
+func newTestflightFeedbackCmd(debugFlagValue *bool) *testflightFeedbackCmd {
+	var root = &testflightFeedbackCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "feedback",
+		Short: "Exports the feedback beta testers submitted through TestFlight",
+		Long: `Use to export the screenshot and crash feedback that beta testers submitted through TestFlight for
+the selected apps, newest first. Each submission includes the tester, build, device, OS version and
+comment. Screenshots attached to feedback are downloaded into the directory given by --screenshots,
+and their paths are listed in place of their URLs.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testflight feedback --app MyApp --since 7d --format csv --screenshots feedback",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&root.since, "since", "7d", "Only export feedback submitted within this long, as a number of days such as 7d or a duration such as 12h")
+	cmd.Flags().StringVar(&root.format, "format", feedbackFormatCSV, "Format to export feedback in, one of csv or json")
+	cmd.Flags().StringVarP(&root.output, "output", "o", "", "Path of the file to write. Defaults to standard output")
+	cmd.Flags().StringVar(&root.screenshots, "screenshots", "", "Directory to download screenshots attached to feedback into")
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testflightFeedbackCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	if cmd.format != feedbackFormatCSV && cmd.format != feedbackFormatJSON {
+		return errUnsupportedFeedbackFormat{Format: cmd.format}
+	}
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseSince(cmd.since, ctx.Date)
+	if err != nil {
+		return err
+	}
+
+	var feedback = make([]appFeedback, 0)
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		items, err := client.ListBetaFeedback(ctx, ascApp.ID, since)
+		if err != nil {
+			return err
+		}
+
+		logger.WithField("app", name).Infof("found %d feedback submissions", len(items))
+
+		for _, item := range items {
+			if cmd.screenshots != "" {
+				if item.Screenshots, err = cmd.downloadScreenshots(ctx, client, item); err != nil {
+					return err
+				}
+			}
+
+			feedback = append(feedback, appFeedback{App: name, Feedback: item})
+		}
+	}
+
+	var w io.Writer = c.OutOrStdout()
+
+	if cmd.output != "" {
+		f, err := os.OpenFile(filepath.Clean(cmd.output), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+
+		defer closer.Close(f)
+
+		w = f
+	}
+
+	if cmd.format == feedbackFormatJSON {
+		err = writeFeedbackJSON(w, feedback)
+	} else {
+		err = writeFeedbackCSV(w, feedback)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.output != "" {
+		logger.WithField("file", cmd.output).Info(color.New(color.Bold).Sprintf("exported %d feedback submissions", len(feedback)))
+	}
+
+	return nil
+}
+
+// downloadScreenshots downloads the screenshots of the feedback into the screenshots directory, and returns
+// their paths.
+func (cmd *testflightFeedbackCmd) downloadScreenshots(ctx *context.Context, client client.Client, feedback client.Feedback) ([]string, error) {
+	if len(feedback.Screenshots) == 0 {
+		return feedback.Screenshots, nil
+	}
+
+	if err := os.MkdirAll(cmd.screenshots, 0750); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, len(feedback.Screenshots))
+
+	for i, screenshotURL := range feedback.Screenshots {
+		ext := ".png"
+		if u, err := url.Parse(screenshotURL); err == nil && path.Ext(u.Path) != "" {
+			ext = path.Ext(u.Path)
+		}
+
+		paths[i] = filepath.Join(cmd.screenshots, fmt.Sprintf("%s-%d%s", feedback.ID, i+1, ext))
+
+		if err := downloadScreenshot(ctx, client, screenshotURL, paths[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+func downloadScreenshot(ctx *context.Context, client client.Client, screenshotURL string, path string) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close(f)
+
+	return client.DownloadFeedbackScreenshot(ctx, screenshotURL, f)
+}
+
+// parseSince returns the time a given number of days, such as 7d, or a duration, such as 12h, before now.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, errInvalidSince{Value: value}
+		}
+
+		return now.AddDate(0, 0, -n), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errInvalidSince{Value: value}
+	}
+
+	return now.Add(-d), nil
+}
+
+func writeFeedbackJSON(w io.Writer, feedback []appFeedback) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(feedback)
+}
+
+func writeFeedbackCSV(w io.Writer, feedback []appFeedback) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(feedbackColumns); err != nil {
+		return err
+	}
+
+	for _, item := range feedback {
+		if err := writer.Write([]string{
+			item.App,
+			item.Kind,
+			item.CreatedDate.UTC().Format(time.RFC3339),
+			item.Tester,
+			item.Build,
+			item.Device,
+			item.OSVersion,
+			item.Comment,
+			strings.Join(item.Screenshots, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,145 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFeedbackCmd(t *testing.T) *testflightFeedbackCmd {
+	t.Helper()
+
+	var noDebug bool
+
+	var cmd = newTestflightFeedbackCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	var proj = config.Project{
+		"My App": {BundleID: "com.app"},
+	}
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+
+	return cmd
+}
+
+func TestTestflightFeedbackCmd_CSV(t *testing.T) {
+	t.Parallel()
+
+	var cmd = newTestFeedbackCmd(t)
+
+	var dir = filepath.Join(t.TempDir(), "screenshots")
+
+	var out bytes.Buffer
+
+	cmd.screenshots = dir
+	cmd.cmd.SetOut(&out)
+
+	err := cmd.cmd.Execute()
+	assert.NoError(t, err)
+
+	var screenshot = filepath.Join(dir, "TEST-1.png")
+
+	assert.Equal(t, `app,kind,createdDate,tester,build,device,osVersion,comment,screenshots
+My App,screenshot,2021-04-01T00:00:00Z,test@example.com,1,iPhone13_2,14.4,Button is cut off,`+screenshot+"\n", out.String())
+
+	data, err := os.ReadFile(screenshot)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST", string(data))
+}
+
+func TestTestflightFeedbackCmd_JSON(t *testing.T) {
+	t.Parallel()
+
+	var cmd = newTestFeedbackCmd(t)
+
+	var output = filepath.Join(t.TempDir(), "feedback.json")
+
+	cmd.format = feedbackFormatJSON
+	cmd.output = output
+
+	err := cmd.cmd.Execute()
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(output)
+	assert.NoError(t, err)
+
+	var feedback []appFeedback
+
+	err = json.Unmarshal(data, &feedback)
+	assert.NoError(t, err)
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, "My App", feedback[0].App)
+	assert.Equal(t, []string{"https://example.com/TEST.png"}, feedback[0].Screenshots)
+}
+
+func TestTestflightFeedbackCmd_Err(t *testing.T) {
+	t.Parallel()
+
+	var cmd = newTestFeedbackCmd(t)
+
+	cmd.format = "xml"
+
+	err := cmd.cmd.Execute()
+	assert.EqualError(t, err, errUnsupportedFeedbackFormat{Format: "xml"}.Error())
+
+	cmd.format = feedbackFormatCSV
+	cmd.since = "a week"
+
+	err = cmd.cmd.Execute()
+	assert.EqualError(t, err, errInvalidSince{Value: "a week"}.Error())
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 4, 8, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseSince("7d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC), since)
+
+	since, err = parseSince("12h", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 4, 8, 0, 0, 0, 0, time.UTC), since)
+
+	_, err = parseSince("-1d", now)
+	assert.Error(t, err)
+
+	_, err = parseSince("soon", now)
+	assert.Error(t, err)
+}
@@ -33,6 +33,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const configSchemaModeline = "# yaml-language-server: $schema=https://cidertool.github.io/cider/schema.json\n"
+
 const configDocString = `# This is a template .cider.yaml file with some sane defaults, initially-generated by cider init.
 # Check this file into your repository so you can version changes to your apps' configurations in App Store Connect.
 # For additional configuration options, see: https://cidertool.github.io/cider/configuration
@@ -47,9 +49,36 @@ type initCmd struct {
 
 type initOpts struct {
 	config     string
+	format     string
 	skipPrompt bool
 }
 
+// resolveFormat settles the format of the file to create. Without an explicit format, the format is
+// implied by the extension of the file. With one, the default file's extension is updated to match.
+func (opts *initOpts) resolveFormat(configChanged bool) error {
+	if opts.format == "" {
+		opts.format = string(config.FormatForPath(opts.config))
+		if opts.format == "" {
+			opts.format = string(config.FormatYAML)
+		}
+
+		return nil
+	}
+
+	format, err := config.ParseFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	opts.format = string(format)
+
+	if !configChanged && config.FormatForPath(opts.config) != format {
+		opts.config = ".cider." + string(format)
+	}
+
+	return nil
+}
+
 func newInitCmd(debugFlagValue *bool) *initCmd {
 	var root = &initCmd{}
 
@@ -64,11 +93,18 @@ func newInitCmd(debugFlagValue *bool) *initCmd {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := newLogger(debugFlagValue)
 
-			return initProject(root.opts, logger)
+			opts := root.opts
+			if err := opts.resolveFormat(cmd.Flags().Changed("config")); err != nil {
+				return err
+			}
+
+			return initProject(opts, logger)
 		},
 	}
 
 	cmd.Flags().StringVarP(&root.opts.config, "config", "f", ".cider.yml", "Path of configuration file to create")
+	cmd.Flags().StringVar(&root.opts.format, "format", "", `Format of the configuration file to create, one of yaml, json or toml. Defaults to the
+format implied by the extension of the file, and changes the extension of the default file to match`)
 	cmd.Flags().BoolVarP(&root.opts.skipPrompt, "skip-prompt", "y", false, `Skips onboarding prompts. This can result in an overwritten configuration file`)
 
 	root.cmd = cmd
@@ -91,7 +127,7 @@ func initProject(opts initOpts, logger log.Interface) (err error) {
 		return err
 	}
 
-	if err := writeProject(project, file); err != nil {
+	if err := writeProject(project, config.Format(opts.format), file); err != nil {
 		return err
 	}
 
@@ -332,13 +368,24 @@ func newProjectFromValues(values projectInitValues) config.Project {
 	return project
 }
 
-func writeProject(project *config.Project, f io.StringWriter) error {
-	contents, err := project.String()
+func writeProject(project *config.Project, format config.Format, f io.StringWriter) error {
+	contents, err := project.Marshal(format)
 	if err != nil {
 		return err
 	}
 
-	if _, err := f.WriteString(configDocString + contents); err != nil {
+	var header string
+
+	switch format {
+	case config.FormatYAML:
+		header = configSchemaModeline + configDocString
+	case config.FormatTOML:
+		header = configDocString
+	case config.FormatJSON:
+		// JSON has no comments.
+	}
+
+	if _, err := f.WriteString(header + string(contents)); err != nil {
 		return err
 	}
 
@@ -21,9 +21,11 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package clicommand
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/cidertool/cider/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,4 +43,62 @@ func TestInitCmd(t *testing.T) {
 	cmd.SetArgs([]string{"-f", path, "--skip-prompt"})
 	assert.NoError(t, cmd.Execute())
 	assert.FileExists(t, path)
+	assert.NoError(t, config.ValidateFile(path, ""))
+}
+
+func TestInitCmd_Formats(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []config.Format{config.FormatJSON, config.FormatTOML} {
+		var folder = t.TempDir()
+
+		var noDebug bool
+
+		var cmd = newInitCmd(&noDebug).cmd
+
+		var path = filepath.Join(folder, "foo.cfg")
+
+		cmd.SetArgs([]string{"-f", path, "--format", string(format), "--skip-prompt"})
+		assert.NoError(t, cmd.Execute())
+
+		contents, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, format, config.DetectFormat(contents))
+		assert.NoError(t, config.ValidateFile(path, ""))
+	}
+}
+
+func TestInitCmd_BadFormat(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newInitCmd(&noDebug).cmd
+
+	cmd.SetArgs([]string{"-f", filepath.Join(t.TempDir(), "foo.xml"), "--format", "xml", "--skip-prompt"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestInitOpts_ResolveFormat(t *testing.T) {
+	t.Parallel()
+
+	opts := initOpts{config: ".cider.yml"}
+	assert.NoError(t, opts.resolveFormat(false))
+	assert.Equal(t, initOpts{config: ".cider.yml", format: "yaml"}, opts)
+
+	opts = initOpts{config: ".cider.yml", format: "json"}
+	assert.NoError(t, opts.resolveFormat(false))
+	assert.Equal(t, initOpts{config: ".cider.json", format: "json"}, opts)
+
+	opts = initOpts{config: "cider.conf", format: "toml"}
+	assert.NoError(t, opts.resolveFormat(true))
+	assert.Equal(t, initOpts{config: "cider.conf", format: "toml"}, opts)
+
+	opts = initOpts{config: "cider.toml"}
+	assert.NoError(t, opts.resolveFormat(true))
+	assert.Equal(t, initOpts{config: "cider.toml", format: "toml"}, opts)
+
+	opts = initOpts{config: ".cider.yml", format: "yml"}
+	assert.NoError(t, opts.resolveFormat(false))
+	assert.Equal(t, initOpts{config: ".cider.yml", format: "yaml"}, opts)
 }
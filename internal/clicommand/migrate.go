@@ -0,0 +1,128 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+type configCmd struct {
+	cmd *cobra.Command
+}
+
+func newConfigCmd(debugFlagValue *bool) *configCmd {
+	var root = &configCmd{}
+
+	var cmd = &cobra.Command{
+		Use:           "config",
+		Short:         "Manage the configuration file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newMigrateCmd(debugFlagValue).cmd)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type migrateCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	config         string
+	dryRun         bool
+}
+
+func newMigrateCmd(debugFlagValue *bool) *migrateCmd {
+	var root = &migrateCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrades the configuration file to the current schema version",
+		Long: `Use to upgrade a configuration file written against an older version of the configuration
+schema. Deprecated keys are replaced with their successors, the schemaVersion key is set to the
+current version, and the file is rewritten in place. Comments in YAML files are preserved.`,
+		Example:       "cider config migrate",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	cmd.Flags().StringVarP(&root.config, "config", "f", "", "Configuration file to migrate")
+	cmd.Flags().BoolVar(&root.dryRun, "dry-run", false, "Print the migrated configuration instead of writing it to the file")
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *migrateCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	path, err := findConfig(cmd.config, "")
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	migrated, deprecations, err := config.Migrate(path, data)
+	if err != nil {
+		logValidationErrors(logger, err)
+
+		return fmt.Errorf("could not migrate config: %w", err)
+	}
+
+	for _, d := range deprecations {
+		logger.Info(validationErrorMessage(d))
+	}
+
+	if cmd.dryRun {
+		_, err = c.OutOrStdout().Write(migrated)
+
+		return err
+	}
+
+	if err := os.WriteFile(path, migrated, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	logger.WithField("file", path).Info(color.New(color.Bold).Sprintf("config migrated to schema version %d", config.SchemaVersion))
+
+	return nil
+}
@@ -0,0 +1,143 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyConfig = `My App:
+  id: com.app
+  availability:
+    # Free everywhere.
+    pricing:
+      - tier: '0'
+`
+
+func TestMigrateCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newMigrateCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(legacyConfig), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `schemaVersion: 2
+My App:
+  id: com.app
+  availability:
+    # Free everywhere.
+    priceTiers:
+      - tier: '0'
+`, string(data))
+}
+
+func TestMigrateCmd_DryRun(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newMigrateCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(legacyConfig), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.cmd.SetOut(&out)
+	cmd.config = path
+	cmd.dryRun = true
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "priceTiers:")
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, legacyConfig, string(data))
+}
+
+func TestMigrateCmd_Err(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newMigrateCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte("schemaVersion: 99\n"), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.Error(t, err)
+
+	cmd.config = filepath.Join(t.TempDir(), "doesnotexist.yaml")
+
+	err = cmd.cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCheckCmd_Deprecations(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newCheckCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(legacyConfig+`  localizations: {}
+  versions:
+    platform: iOS
+    localizations: {}
+  testflight:
+    enableAutoNotify: false
+    licenseAgreement: ''
+    localizations: {}
+`), 0600)
+	assert.NoError(t, err)
+
+	cmd.config = path
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,135 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/spf13/cobra"
+)
+
+// ErrNoBuildNumber happens when no build number is given to notify testers of.
+var ErrNoBuildNumber = errors.New("no build number provided, use --build to select the build to notify testers of")
+
+type errBetaReviewNotApproved struct {
+	App   string
+	Build string
+	State asc.BetaReviewState
+}
+
+func (e errBetaReviewNotApproved) Error() string {
+	return fmt.Sprintf("build %s of %s has a beta app review state of %s, testers can only be notified once it is approved", e.Build, e.App, e.State)
+}
+
+type testflightNotifyCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+	build          string
+}
+
+func newTestflightNotifyCmd(debugFlagValue *bool) *testflightNotifyCmd {
+	var root = &testflightNotifyCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "notify",
+		Short: "Notifies beta testers that a build is available",
+		Long: `Use to notify the beta testers of the selected apps that the build with the given build number
+is available for testing. This is useful when enableAutoNotify and notifyTesters are disabled in the
+Testflight configuration of an app, or when the build was still waiting for Beta App Review at the
+end of the release.
+
+Testers can only be notified once the build has passed Beta App Review. Builds that were never
+submitted for review, such as builds only distributed to internal beta groups, can be notified at
+any time.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testflight notify --app MyApp --build 42",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&root.build, "build", "b", "", "Build number (CFBundleVersion) of the build to notify testers of")
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testflightNotifyCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	if cmd.build == "" {
+		return ErrNoBuildNumber
+	}
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		build, err := client.GetBuildForNumber(ctx, ascApp, cmd.build)
+		if err != nil {
+			return err
+		}
+
+		state, err := client.GetBetaReviewState(ctx, build.ID)
+		if err != nil {
+			return err
+		}
+
+		if state != "" && state != asc.BetaReviewStateApproved {
+			return errBetaReviewNotApproved{App: name, Build: cmd.build, State: state}
+		}
+
+		logger.
+			WithFields(log.Fields{
+				"app":   name,
+				"build": cmd.build,
+			}).
+			Info("notifying testers")
+
+		if err := client.NotifyBetaTesters(ctx, build.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// pricingDateFormat is the format of the dates prices take effect and end in the pricing table.
+const pricingDateFormat = "2006-01-02"
+
+type pricingCmd struct {
+	cmd *cobra.Command
+}
+
+func newPricingCmd(debugFlagValue *bool) *pricingCmd {
+	var root = &pricingCmd{}
+
+	var cmd = &cobra.Command{
+		Use:   "pricing",
+		Short: "Inspect the prices of apps",
+		Long: `Use to work with the prices declared in the availability section of each app, either as price
+tiers or as prices per territory.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newPricingShowCmd(debugFlagValue).cmd,
+	)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type pricingShowCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+}
+
+func newPricingShowCmd(debugFlagValue *bool) *pricingShowCmd {
+	var root = &pricingShowCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "show",
+		Short: "Prints the effective price of apps in each territory over time",
+		Long: `Use to print a table of the prices configured for the selected apps. Each row is the price of an
+app in a territory from the date it takes effect until the date it ends, with "-" for prices that take
+effect immediately or don't end. The base territory is listed first. Territories without prices of their
+own are priced by App Store Connect from the price in the base territory. Apps that use price tiers are
+listed with the same tiers in all territories. The table is built from the configuration alone, and does
+not require App Store Connect credentials.`,
+		Example:       "cider pricing show --app MyApp",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *pricingShowCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	cfg, _, err := loadConfig(cmd.opts.config, "", cmd.opts.profile)
+	if err != nil {
+		return err
+	}
+
+	apps := cfg.AppsMatching(cmd.opts.apps, cmd.opts.allApps)
+	if len(apps) == 0 {
+		return ErrNoAppsSelected
+	}
+
+	sort.Strings(apps)
+
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "APP\tTERRITORY\tPRICE\tFROM\tUNTIL")
+
+	for _, name := range apps {
+		app, ok := cfg[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		if app.Availability == nil || (len(app.Availability.Prices) == 0 && len(app.Availability.Pricing) == 0) {
+			logger.WithField("app", name).Warn("no prices")
+
+			continue
+		}
+
+		writePrices(w, name, *app.Availability)
+	}
+
+	return w.Flush()
+}
+
+// writePrices writes a row for each price of an app to the pricing table.
+func writePrices(w io.Writer, name string, availability config.Availability) {
+	if len(availability.Prices) == 0 {
+		schedules := make([]config.PriceSchedule, len(availability.Pricing))
+		copy(schedules, availability.Pricing)
+
+		sort.SliceStable(schedules, func(i, j int) bool {
+			return schedules[j].StartDate != nil && (schedules[i].StartDate == nil || schedules[i].StartDate.Before(*schedules[j].StartDate))
+		})
+
+		for i, schedule := range schedules {
+			var end *time.Time
+			if i+1 < len(schedules) {
+				end = schedules[i+1].StartDate
+			}
+
+			fmt.Fprintf(w, "%s\tall\ttier %s\t%s\t%s\n", name, schedule.Tier, pricingDate(schedule.StartDate), pricingDate(end))
+		}
+
+		return
+	}
+
+	base := availability.PriceBaseTerritory()
+	prices := availability.PricesByTerritory()
+
+	territories := make([]string, 0, len(prices))
+	for territory := range prices {
+		if territory != base {
+			territories = append(territories, territory)
+		}
+	}
+
+	sort.Strings(territories)
+
+	if _, ok := prices[base]; ok {
+		territories = append([]string{base}, territories...)
+	}
+
+	for _, territory := range territories {
+		label := territory
+		if territory == base {
+			label += " (base)"
+		}
+
+		for _, price := range prices[territory] {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, label, price.Price, pricingDate(price.StartDate), pricingDate(price.EndDate))
+		}
+	}
+}
+
+func pricingDate(date *time.Time) string {
+	if date == nil {
+		return "-"
+	}
+
+	return date.UTC().Format(pricingDateFormat)
+}
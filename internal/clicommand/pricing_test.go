@@ -0,0 +1,105 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingShowCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newPricingShowCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(`My App:
+  id: com.app
+  availability:
+    baseTerritory: USA
+    prices:
+      - territory: JPN
+        price: '800'
+      - price: '2.99'
+        startDate: 2026-11-27T00:00:00Z
+        endDate: 2026-12-01T00:00:00Z
+      - price: '4.99'
+      - price: '4.99'
+        startDate: 2026-12-01T00:00:00Z
+Tiered App:
+  id: com.tiered
+  availability:
+    priceTiers:
+      - tier: '2'
+        startDate: 2026-12-01T00:00:00Z
+      - tier: '1'
+Free App:
+  id: com.free
+`), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.opts.config = path
+	cmd.opts.allApps = true
+	cmd.cmd.SetOut(&out)
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, `APP         TERRITORY   PRICE   FROM        UNTIL
+My App      USA (base)  4.99    -           2026-11-27
+My App      USA (base)  2.99    2026-11-27  2026-12-01
+My App      USA (base)  4.99    2026-12-01  -
+My App      JPN         800     -           -
+Tiered App  all         tier 1  -           2026-12-01
+Tiered App  all         tier 2  2026-12-01  -
+`, out.String())
+}
+
+func TestPricingShowCmd_ErrNoAppsSelected(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newPricingShowCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	var proj config.Project
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	cmd.opts.config = path
+
+	err = cmd.cmd.Execute()
+	assert.ErrorIs(t, err, ErrNoAppsSelected)
+}
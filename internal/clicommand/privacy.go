@@ -0,0 +1,360 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/keys"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+type errPrivacyDrift struct {
+	Count int
+}
+
+func (e errPrivacyDrift) Error() string {
+	return fmt.Sprintf("found %d differences between the privacy declarations and App Store Connect", e.Count)
+}
+
+type privacyCmd struct {
+	cmd *cobra.Command
+}
+
+func newPrivacyCmd(debugFlagValue *bool) *privacyCmd {
+	var root = &privacyCmd{}
+
+	var cmd = &cobra.Command{
+		Use:   "privacy",
+		Short: "Summarize and audit the App Privacy details of apps",
+		Long: `Use to work with the App Privacy details declared in the privacy section of each app. The App Store
+Connect API cannot publish App Privacy details, so they have to be entered in App Store Connect by hand.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newPrivacySummaryCmd(debugFlagValue).cmd,
+		newPrivacyDriftCmd(debugFlagValue).cmd,
+	)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type privacySummaryCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+}
+
+func newPrivacySummaryCmd(debugFlagValue *bool) *privacySummaryCmd {
+	var root = &privacySummaryCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "summary",
+		Short: "Prints the App Privacy details of apps for review",
+		Long: `Use to print the App Privacy details declared for the selected apps as Markdown. For each app,
+the summary previews the privacy labels of its product page, followed by the answers to give in
+App Store Connect for each data type, grouped by category. The summary is built from the
+configuration alone, and does not require App Store Connect credentials.`,
+		Example:       "cider privacy summary --app MyApp > privacy.md",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *privacySummaryCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	cfg, _, err := loadConfig(cmd.opts.config, "", cmd.opts.profile)
+	if err != nil {
+		return err
+	}
+
+	apps := cfg.AppsMatching(cmd.opts.apps, cmd.opts.allApps)
+	if len(apps) == 0 {
+		return ErrNoAppsSelected
+	}
+
+	sort.Strings(apps)
+
+	var written int
+
+	for _, name := range apps {
+		app, ok := cfg[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		if app.Privacy == nil {
+			logger.WithField("app", name).Warn("no privacy declaration")
+
+			continue
+		}
+
+		if written > 0 {
+			fmt.Fprintln(c.OutOrStdout())
+		}
+
+		writePrivacySummary(c.OutOrStdout(), name, *app.Privacy)
+		written++
+	}
+
+	return nil
+}
+
+// writePrivacySummary writes the App Privacy details of an app as Markdown.
+func writePrivacySummary(w io.Writer, name string, privacy config.Privacy) {
+	fmt.Fprintf(w, "# %s\n\n", name)
+
+	if len(privacy.DataTypes) == 0 {
+		fmt.Fprintln(w, "## Data Not Collected")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "The developer does not collect any data from this app.")
+
+		return
+	}
+
+	var tracking, linked, notLinked []config.PrivacyDataType
+
+	for _, dataType := range privacy.DataTypes {
+		if dataType.Tracking {
+			tracking = append(tracking, dataType)
+		}
+
+		if dataType.Linked {
+			linked = append(linked, dataType)
+		} else {
+			notLinked = append(notLinked, dataType)
+		}
+	}
+
+	for _, label := range []struct {
+		title     string
+		dataTypes []config.PrivacyDataType
+	}{
+		{"Data Used to Track You", tracking},
+		{"Data Linked to You", linked},
+		{"Data Not Linked to You", notLinked},
+	} {
+		categories := privacyCategories(label.dataTypes)
+		if len(categories) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "## %s\n\n", label.title)
+
+		for _, category := range categories {
+			fmt.Fprintf(w, "- %s\n", category)
+		}
+
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "## Data Types")
+
+	for _, category := range privacyCategories(privacy.DataTypes) {
+		fmt.Fprintf(w, "\n### %s\n\n", category)
+
+		for _, dataType := range privacy.DataTypes {
+			if dataType.Type.Category() != category {
+				continue
+			}
+
+			purposes := make([]string, len(dataType.Purposes))
+			for i, purpose := range dataType.Purposes {
+				purposes[i] = purpose.DisplayName()
+			}
+
+			fmt.Fprintf(w, "- **%s**\n", dataType.Type.DisplayName())
+			fmt.Fprintf(w, "  - Purposes: %s\n", strings.Join(purposes, ", "))
+			fmt.Fprintf(w, "  - Linked to the user's identity: %s\n", yesNo(dataType.Linked))
+			fmt.Fprintf(w, "  - Used for tracking: %s\n", yesNo(dataType.Tracking))
+		}
+	}
+}
+
+// privacyCategories returns the categories of dataTypes in the order App Store Connect shows them.
+func privacyCategories(dataTypes []config.PrivacyDataType) []string {
+	var categories []string
+
+	for _, category := range config.PrivacyCategories {
+		for _, dataType := range dataTypes {
+			if dataType.Type.Category() == category {
+				categories = append(categories, category)
+
+				break
+			}
+		}
+	}
+
+	return categories
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+
+	return "No"
+}
+
+type privacyDriftCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+}
+
+func newPrivacyDriftCmd(debugFlagValue *bool) *privacyDriftCmd {
+	var root = &privacyDriftCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "drift",
+		Short: "Reports differences between the App Privacy details and App Store Connect",
+		Long: `Use to compare the App Privacy details declared for the selected apps with the privacy details
+App Store Connect publishes through its API for the version of each app that is ready for sale:
+whether the version uses the advertising identifier, and the privacy policy URL of each locale.
+Each difference is listed on its own line with the app and a description separated by a tab, and
+the command fails if there are any.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider privacy drift --all-apps",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *privacyDriftCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	var count int
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		if app.Privacy == nil {
+			logger.WithField("app", name).Warn("no privacy declaration")
+
+			continue
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		details, err := client.GetPrivacyDetails(ctx, ascApp.ID, app.Versions.PlatformVersions()[0].Platform)
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range privacyDrift(app, details) {
+			fmt.Fprintf(c.OutOrStdout(), "%s\t%s\n", name, drift)
+			count++
+		}
+	}
+
+	if count > 0 {
+		return errPrivacyDrift{Count: count}
+	}
+
+	logger.Info("privacy declarations match App Store Connect")
+
+	return nil
+}
+
+// privacyDrift describes each difference between the privacy declaration of app and the privacy details
+// published in App Store Connect.
+func privacyDrift(app config.App, details *client.PrivacyDetails) []string {
+	var drift []string
+
+	if details.Version != "" {
+		if details.UsesIDFA && !app.Privacy.Collects(config.PrivacyDataTypeDeviceID) {
+			drift = append(drift, fmt.Sprintf("version %s uses the advertising identifier, but %s is not declared", details.Version, config.PrivacyDataTypeDeviceID))
+		} else if !details.UsesIDFA && tracksDeviceID(*app.Privacy) {
+			drift = append(drift, fmt.Sprintf("%s is declared for tracking, but version %s does not use the advertising identifier", config.PrivacyDataTypeDeviceID, details.Version))
+		}
+	}
+
+	if len(app.Privacy.DataTypes) == 0 {
+		return drift
+	}
+
+	for _, locale := range keys.Sorted(details.PrivacyPolicyURLs) {
+		published := details.PrivacyPolicyURLs[locale]
+
+		if published == "" {
+			drift = append(drift, fmt.Sprintf("locale %s has no privacy policy URL", locale))
+
+			continue
+		}
+
+		if loc, ok := app.Localizations[locale]; ok && loc.PrivacyPolicyURL != "" && loc.PrivacyPolicyURL != published {
+			drift = append(drift, fmt.Sprintf("privacy policy URL of locale %s is %s, but %s is configured", locale, published, loc.PrivacyPolicyURL))
+		}
+	}
+
+	return drift
+}
+
+func tracksDeviceID(privacy config.Privacy) bool {
+	for _, dataType := range privacy.DataTypes {
+		if dataType.Type == config.PrivacyDataTypeDeviceID && dataType.Tracking {
+			return true
+		}
+	}
+
+	return false
+}
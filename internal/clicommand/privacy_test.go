@@ -0,0 +1,184 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacySummaryCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newPrivacySummaryCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(`My App:
+  id: com.app
+  privacy:
+    dataTypes:
+      - type: crashData
+        purposes: [analytics]
+      - type: emailAddress
+        purposes: [appFunctionality, developerAdvertising]
+        linked: true
+      - type: deviceID
+        purposes: [thirdPartyAdvertising]
+        linked: true
+        tracking: true
+Other App:
+  id: com.other
+  privacy: {}
+No Privacy:
+  id: com.none
+`), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.opts.config = path
+	cmd.opts.allApps = true
+	cmd.cmd.SetOut(&out)
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, `# My App
+
+## Data Used to Track You
+
+- Identifiers
+
+## Data Linked to You
+
+- Contact Info
+- Identifiers
+
+## Data Not Linked to You
+
+- Diagnostics
+
+## Data Types
+
+### Contact Info
+
+- **Email Address**
+  - Purposes: App Functionality, Developer's Advertising or Marketing
+  - Linked to the user's identity: Yes
+  - Used for tracking: No
+
+### Identifiers
+
+- **Device ID**
+  - Purposes: Third-Party Advertising
+  - Linked to the user's identity: Yes
+  - Used for tracking: Yes
+
+### Diagnostics
+
+- **Crash Data**
+  - Purposes: Analytics
+  - Linked to the user's identity: No
+  - Used for tracking: No
+
+# Other App
+
+## Data Not Collected
+
+The developer does not collect any data from this app.
+`, out.String())
+}
+
+func TestPrivacyDriftCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newPrivacyDriftCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	err := os.WriteFile(path, []byte(`My App:
+  id: com.app
+  localizations:
+    en-US:
+      name: My App
+      privacyPolicyURL: https://example.com/privacy-policy
+  privacy:
+    dataTypes:
+      - type: crashData
+        purposes: [analytics]
+`), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+	cmd.cmd.SetOut(&out)
+
+	err = cmd.cmd.Execute()
+	assert.EqualError(t, err, errPrivacyDrift{Count: 1}.Error())
+	assert.Equal(t, "My App\tprivacy policy URL of locale en-US is https://example.com/privacy, but https://example.com/privacy-policy is configured\n", out.String())
+}
+
+func TestPrivacyDrift(t *testing.T) {
+	t.Parallel()
+
+	app := config.App{
+		Privacy: &config.Privacy{
+			DataTypes: []config.PrivacyDataType{
+				{Type: config.PrivacyDataTypeDeviceID, Tracking: true},
+			},
+		},
+	}
+
+	drift := privacyDrift(app, &client.PrivacyDetails{
+		Version:           "1.0",
+		PrivacyPolicyURLs: map[string]string{"en-US": "", "ja": "https://example.com/ja/privacy"},
+	})
+	assert.Equal(t, []string{
+		"deviceID is declared for tracking, but version 1.0 does not use the advertising identifier",
+		"locale en-US has no privacy policy URL",
+	}, drift)
+
+	app.Privacy = &config.Privacy{}
+
+	drift = privacyDrift(app, &client.PrivacyDetails{
+		Version:           "1.0",
+		UsesIDFA:          true,
+		PrivacyPolicyURLs: map[string]string{"en-US": ""},
+	})
+	assert.Equal(t, []string{
+		"version 1.0 uses the advertising identifier, but deviceID is not declared",
+	}, drift)
+
+	assert.Empty(t, privacyDrift(app, &client.PrivacyDetails{}))
+}
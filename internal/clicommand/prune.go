@@ -0,0 +1,107 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/spf13/cobra"
+)
+
+type testflightPruneCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+	dryRun         bool
+}
+
+func newTestflightPruneCmd(debugFlagValue *bool) *testflightPruneCmd {
+	var root = &testflightPruneCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Deletes the beta groups that are not listed in the configuration",
+		Long: `Use to prune the beta groups of the selected apps without running a release. Only apps with prune
+enabled in their Testflight configuration are pruned. Beta groups that are not listed in betaGroups and
+whose names match prunePattern are deleted, and listed one per line with the app and the beta group
+separated by a tab. Internal beta groups are never deleted. Use --dry-run to list the groups without
+deleting them.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testflight prune --app MyApp --dry-run",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&root.dryRun, "dry-run", false, "List the beta groups that would be deleted without deleting them")
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testflightPruneCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx.PruneDryRun = cmd.dryRun
+
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		if !app.Testflight.Prune {
+			logger.WithField("app", name).Warn("prune is not enabled")
+
+			continue
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		pruned, err := client.PruneBetaGroups(ctx, ascApp.ID, app.Testflight.BetaGroups, app.Testflight.PrunePattern)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range pruned {
+			fmt.Fprintf(w, "%s\t%s\n", name, group)
+		}
+	}
+
+	return w.Flush()
+}
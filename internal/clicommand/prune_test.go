@@ -0,0 +1,68 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestflightPruneCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newTestflightPruneCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	var proj = config.Project{
+		"My App": {
+			BundleID:   "com.app",
+			Testflight: config.Testflight{Prune: true, PrunePattern: "*"},
+		},
+		"Other App": {BundleID: "com.other"},
+	}
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.opts.config = path
+	cmd.opts.allApps = true
+	cmd.opts.client = &clienttest.Client{}
+	cmd.cmd.SetOut(&out)
+	cmd.cmd.SetArgs([]string{"--dry-run"})
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.True(t, cmd.dryRun)
+	assert.Equal(t, "My App  TEST\n", out.String())
+}
@@ -46,9 +46,12 @@ type releaseCmd struct {
 
 type releaseOpts struct {
 	config              string
+	profile             string
 	appsToRelease       []string
 	publishMode         context.PublishMode
 	maxProcesses        int
+	maxTesterRemovals   int
+	pruneDryRun         bool
 	releaseAllApps      bool
 	skipGit             bool
 	skipUpdatePricing   bool
@@ -108,12 +111,13 @@ More info: https://developer.apple.com/documentation/appstoreconnectapi/creating
 
 			logger.Info(color.New(color.Bold).Sprint("releasing..."))
 
-			_, err := releaseProject(root.opts, logger)
+			ctx, err := releaseProject(root.opts, logger)
 			if err != nil {
 				return wrapError(err, color.New(color.Bold).Sprintf("release failed after %0.2fs", time.Since(start).Seconds()))
 			}
 
 			logger.Info(color.New(color.Bold).Sprintf("release succeeded after %0.2fs", time.Since(start).Seconds()))
+			logPublicLinks(logger, ctx.PublicLinks)
 
 			return nil
 		},
@@ -128,6 +132,13 @@ More info: https://developer.apple.com/documentation/appstoreconnectapi/creating
 		"",
 		"Load configuration from file",
 	)
+	cmd.Flags().StringVar(
+		&root.opts.profile,
+		"profile",
+		"",
+		`Patch the overlay for the profile `+"`name`"+` over the configuration file. For example, the profile
+"staging" loads .cider.staging.yml and merges it over .cider.yml.`,
+	)
 	cmd.Flags().StringArrayVarP(
 		&root.opts.appsToRelease,
 		"app",
@@ -160,6 +171,20 @@ option is "appstore" for submitting to the App Store.`,
 		1,
 		`Run certain metadata syncing and asset uploading logic in parallel with
 the maximum allowable concurrency.`,
+	)
+	cmd.Flags().IntVar(
+		&root.opts.maxTesterRemovals,
+		"max-tester-removals",
+		context.DefaultMaxTesterRemovals,
+		`Maximum number of beta testers that can be removed from beta groups and apps configured
+with the exact sync mode. Cider aborts before removing more testers than this in a single run.`,
+	)
+	cmd.Flags().BoolVar(
+		&root.opts.pruneDryRun,
+		"prune-dry-run",
+		false,
+		`Lists the beta groups that would be deleted by apps configured to prune beta groups,
+without deleting them.`,
 	)
 	cmd.Flags().DurationVar(
 		&root.opts.timeout,
@@ -246,7 +271,7 @@ using the configuration file.`,
 func releaseProject(options releaseOpts, logger log.Interface) (*context.Context, error) {
 	var forceAllSkips bool
 
-	cfg, err := loadConfig(options.config, options.currentDirectory)
+	cfg, source, err := loadConfig(options.config, options.currentDirectory, options.profile)
 	if err != nil {
 		if errors.Is(err, ErrConfigNotFound) {
 			logger.Warn(err.Error())
@@ -260,6 +285,7 @@ func releaseProject(options releaseOpts, logger log.Interface) (*context.Context
 
 	ctx, cancel := context.NewWithTimeout(cfg, options.timeout)
 	defer cancel()
+	ctx.ConfigSource = source
 	setupReleaseContext(ctx, options, forceAllSkips, logger)
 
 	return ctx, context.NewInterrupt().Run(ctx, func() error {
@@ -285,8 +311,11 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts, forceAllSkip
 		ctx.PublishMode = options.publishMode
 	}
 
+	ctx.Profile = options.profile
 	ctx.Log = logger
 	ctx.MaxProcesses = options.maxProcesses
+	ctx.MaxTesterRemovals = options.maxTesterRemovals
+	ctx.PruneDryRun = options.pruneDryRun
 	ctx.SkipGit = options.skipGit || forceAllSkips
 	ctx.SkipUpdatePricing = options.skipUpdatePricing || forceAllSkips
 	ctx.SkipUpdateMetadata = options.skipUpdateMetadata || forceAllSkips
@@ -312,10 +341,14 @@ func setupReleaseContext(ctx *context.Context, options releaseOpts, forceAllSkip
 		for appName, app := range ctx.Config {
 			if len(options.betaGroupsOverride) > 0 {
 				app.Testflight.BetaGroups = betaGroups
+				// Overridden groups are never a complete list.
+				app.Testflight.Prune = false
 			}
 
 			if len(betaTesters) > 0 {
 				app.Testflight.BetaTesters = betaTesters
+				// Overridden testers are never a complete list.
+				app.Testflight.BetaTestersSync = config.SyncModeAdditive
 			}
 
 			ctx.Config[appName] = app
@@ -66,6 +66,11 @@ func NewRoot(version string, exit func(int)) *Root {
 		newInitCmd(&debug).cmd,
 		newCheckCmd(&debug).cmd,
 		newReleaseCmd(&debug).cmd,
+		newConfigCmd(&debug).cmd,
+		newTestersCmd(&debug).cmd,
+		newTestflightCmd(&debug).cmd,
+		newPrivacyCmd(&debug).cmd,
+		newPricingCmd(&debug).cmd,
 		newCompletionsCmd().cmd,
 	)
 
@@ -0,0 +1,267 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// testerMissingState is the state reported for configured testers that do not exist in App Store Connect.
+const testerMissingState = "MISSING"
+
+// testerReportPeriods are the periods App Store Connect aggregates beta tester usage over.
+// nolint: gochecknoglobals
+var testerReportPeriods = []string{"P7D", "P30D", "P90D", "P365D"}
+
+type errUnsupportedReportPeriod struct {
+	Period string
+}
+
+func (e errUnsupportedReportPeriod) Error() string {
+	return fmt.Sprintf("unsupported period %s, expected one of %s", e.Period, strings.Join(testerReportPeriods, ", "))
+}
+
+type testflightTestersCmd struct {
+	cmd *cobra.Command
+}
+
+func newTestflightTestersCmd(debugFlagValue *bool) *testflightTestersCmd {
+	var root = &testflightTestersCmd{}
+
+	var cmd = &cobra.Command{
+		Use:           "testers",
+		Short:         "Inspect the beta testers of apps",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newTestflightTestersReportCmd(debugFlagValue).cmd,
+	)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type testflightTestersReportCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+	period         string
+}
+
+func newTestflightTestersReportCmd(debugFlagValue *bool) *testflightTestersReportCmd {
+	var root = &testflightTestersReportCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "report",
+		Short: "Reports the invitation state and usage of each configured beta tester",
+		Long: `Use to report on every beta tester in the configuration of the selected apps, including the testers
+of each beta group. Each tester is listed with their invitation state in App Store Connect, how they
+were invited, and the number of sessions, crashes and feedback submissions App Store Connect recorded
+for them over --period, along with the end of the last period they were active in. App Store Connect
+does not report which build each tester installed, so the state column reads INSTALLED once a tester
+has installed any build.
+
+Configured testers that do not exist in App Store Connect are flagged with the state MISSING.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testflight testers report --app MyApp --period P30D",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&root.period, "period", "P30D", "Period to count sessions, crashes and feedback over, one of "+strings.Join(testerReportPeriods, ", "))
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testflightTestersReportCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	if !isTesterReportPeriod(cmd.period) {
+		return errUnsupportedReportPeriod{Period: cmd.period}
+	}
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "APP\tEMAIL\tNAME\tSTATE\tINVITE\tSESSIONS\tCRASHES\tFEEDBACK\tLAST ACTIVE")
+
+	var missing int
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		testers, err := configuredTesters(app, ctx.CurrentDirectory)
+		if err != nil {
+			return err
+		}
+
+		if len(testers) == 0 {
+			logger.WithField("app", name).Warn("no beta testers configured")
+
+			continue
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		statuses, err := client.ListBetaTesterStatuses(ctx, ascApp.ID, cmd.period)
+		if err != nil {
+			return err
+		}
+
+		for _, tester := range testers {
+			status, found := findTesterStatus(statuses, tester)
+			if !found {
+				missing++
+			}
+
+			writeTesterStatus(w, name, status)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if missing > 0 {
+		logger.Warn(color.New(color.Bold).Sprintf("%d configured testers are missing from App Store Connect", missing))
+	}
+
+	return nil
+}
+
+func isTesterReportPeriod(period string) bool {
+	for _, p := range testerReportPeriods {
+		if p == period {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configuredTesters returns the individual testers of the app followed by the testers of each of its beta
+// groups, without duplicate emails. Roster files are resolved against dir.
+func configuredTesters(app config.App, dir string) ([]config.BetaTester, error) {
+	var testers = make([]config.BetaTester, 0, len(app.Testflight.BetaTesters))
+
+	var seen = make(map[string]bool)
+
+	add := func(tester config.BetaTester) {
+		email := strings.ToLower(tester.Email)
+		if email == "" || seen[email] {
+			return
+		}
+
+		seen[email] = true
+
+		testers = append(testers, tester)
+	}
+
+	for _, tester := range app.Testflight.BetaTesters {
+		add(tester)
+	}
+
+	for _, group := range app.Testflight.BetaGroups {
+		groupTesters, err := group.ResolveTesters(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tester := range groupTesters {
+			add(tester)
+		}
+	}
+
+	return testers, nil
+}
+
+// findTesterStatus returns the status of the configured tester in App Store Connect, or a status flagging the
+// tester as missing if there is none.
+func findTesterStatus(statuses []client.BetaTesterStatus, tester config.BetaTester) (client.BetaTesterStatus, bool) {
+	if status, ok := client.FindBetaTesterStatus(statuses, tester.Email); ok {
+		return status, true
+	}
+
+	return client.BetaTesterStatus{
+		Email:     tester.Email,
+		FirstName: tester.FirstName,
+		LastName:  tester.LastName,
+		State:     testerMissingState,
+	}, false
+}
+
+func writeTesterStatus(w io.Writer, app string, status client.BetaTesterStatus) {
+	name := strings.TrimSpace(status.FirstName + " " + status.LastName)
+
+	if status.State == testerMissingState {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t-\t-\t-\t-\t-\n", app, status.Email, name, status.State)
+
+		return
+	}
+
+	lastActive := "-"
+	if !status.LastActive.IsZero() {
+		lastActive = status.LastActive.Format("2006-01-02")
+	}
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		app,
+		status.Email,
+		name,
+		status.State,
+		status.InviteType,
+		strconv.Itoa(status.Sessions),
+		strconv.Itoa(status.Crashes),
+		strconv.Itoa(status.Feedback),
+		lastActive,
+	)
+}
@@ -0,0 +1,102 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTestersReportCmd(t *testing.T, proj config.Project) *testflightTestersReportCmd {
+	t.Helper()
+
+	var noDebug bool
+
+	var cmd = newTestflightTestersReportCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+
+	return cmd
+}
+
+func TestTestflightTestersReportCmd(t *testing.T) {
+	t.Parallel()
+
+	var cmd = newTestTestersReportCmd(t, config.Project{
+		"My App": {
+			BundleID: "com.app",
+			Testflight: config.Testflight{
+				BetaTesters: []config.BetaTester{
+					{Email: "test@example.com"},
+				},
+				BetaGroups: []config.BetaGroup{
+					{
+						Name: "QA",
+						Testers: []config.BetaTester{
+							{Email: "TEST@example.com"},
+							{Email: "missing@example.com", FirstName: "Gone", LastName: "Person"},
+						},
+					},
+				},
+			},
+		},
+		"Other App": {BundleID: "com.other"},
+	})
+
+	var out bytes.Buffer
+
+	cmd.opts.allApps = true
+	cmd.cmd.SetOut(&out)
+
+	err := cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, `APP     EMAIL                NAME              STATE      INVITE  SESSIONS  CRASHES  FEEDBACK  LAST ACTIVE
+My App  test@example.com     Person Personson  INSTALLED  EMAIL   12        1        2         2021-04-01
+My App  missing@example.com  Gone Person       MISSING    -       -         -        -         -
+`, out.String())
+}
+
+func TestTestflightTestersReportCmd_ErrPeriod(t *testing.T) {
+	t.Parallel()
+
+	var cmd = newTestTestersReportCmd(t, config.Project{
+		"My App": {BundleID: "com.app"},
+	})
+
+	cmd.cmd.SetArgs([]string{"--period", "P1D"})
+
+	err := cmd.cmd.Execute()
+	assert.EqualError(t, err, errUnsupportedReportPeriod{Period: "P1D"}.Error())
+}
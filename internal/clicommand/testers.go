@@ -0,0 +1,163 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cidertool/cider/internal/closer"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+type testersCmd struct {
+	cmd *cobra.Command
+}
+
+func newTestersCmd(debugFlagValue *bool) *testersCmd {
+	var root = &testersCmd{}
+
+	var cmd = &cobra.Command{
+		Use:           "testers",
+		Short:         "Manage beta testers",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newTestersExportCmd(debugFlagValue).cmd)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type testersExportCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+	output         string
+}
+
+func newTestersExportCmd(debugFlagValue *bool) *testersExportCmd {
+	var root = &testersExportCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "export",
+		Short: "Exports the testers in each beta group to a CSV file",
+		Long: `Use to export the beta testers of each beta group in App Store Connect to a CSV file, with the
+columns email, firstName, lastName and groups. The file can be referenced by the testersFile field of
+a beta group in the configuration file.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testers export --app MyApp --output testers.csv",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&root.output, "output", "o", "", "Path of the CSV file to write. Defaults to standard output")
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testersExportCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	// Map of tester emails -> their record, merged across apps
+	var byEmail = make(map[string]*config.TesterRecord)
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		records, err := client.ListBetaGroupTesters(ctx, ascApp.ID)
+		if err != nil {
+			return err
+		}
+
+		logger.WithField("app", name).Infof("found %d beta testers in beta groups", len(records))
+
+		for i := range records {
+			record := records[i]
+			email := strings.ToLower(record.Email)
+
+			if existing, ok := byEmail[email]; ok {
+				existing.Groups = append(existing.Groups, record.Groups...)
+			} else {
+				byEmail[email] = &record
+			}
+		}
+	}
+
+	var records = make([]config.TesterRecord, 0, len(byEmail))
+
+	for _, record := range byEmail {
+		sort.Strings(record.Groups)
+		records = append(records, *record)
+	}
+
+	var w io.Writer = c.OutOrStdout()
+
+	if cmd.output != "" {
+		f, err := os.OpenFile(filepath.Clean(cmd.output), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+
+		defer closer.Close(f)
+
+		w = f
+	}
+
+	if err := config.WriteTesters(w, records); err != nil {
+		return err
+	}
+
+	if cmd.output != "" {
+		logger.WithField("file", cmd.output).Info(color.New(color.Bold).Sprintf("exported %d beta testers", len(records)))
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestersExportCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newTestersExportCmd(&noDebug)
+
+	var dir = t.TempDir()
+
+	var path = filepath.Join(dir, "foo.yaml")
+
+	var output = filepath.Join(dir, "testers.csv")
+
+	var proj = config.Project{
+		"My App": {BundleID: "com.app"},
+	}
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+	cmd.output = output
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+
+	records, err := config.LoadTesters(output)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.TesterRecord{
+		{
+			BetaTester: config.BetaTester{Email: "test@example.com", FirstName: "Person", LastName: "Personson"},
+			Groups:     []string{"TEST"},
+		},
+	}, records)
+}
+
+func TestTestersExportCmd_ErrNoAppsSelected(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newTestersExportCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	var proj config.Project
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+
+	err = cmd.cmd.Execute()
+	assert.ErrorIs(t, err, ErrNoAppsSelected)
+}
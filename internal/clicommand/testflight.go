@@ -0,0 +1,147 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/cidertool/cider/internal/keys"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/spf13/cobra"
+)
+
+type testflightCmd struct {
+	cmd *cobra.Command
+}
+
+func newTestflightCmd(debugFlagValue *bool) *testflightCmd {
+	var root = &testflightCmd{}
+
+	var cmd = &cobra.Command{
+		Use:           "testflight",
+		Short:         "Inspect and manage the TestFlight distribution of apps",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newTestflightLinksCmd(debugFlagValue).cmd,
+		newTestflightFeedbackCmd(debugFlagValue).cmd,
+		newTestflightTestersCmd(debugFlagValue).cmd,
+		newTestflightNotifyCmd(debugFlagValue).cmd,
+		newTestflightPruneCmd(debugFlagValue).cmd,
+	)
+
+	root.cmd = cmd
+
+	return root
+}
+
+type testflightLinksCmd struct {
+	cmd            *cobra.Command
+	debugFlagValue *bool
+	opts           apiOpts
+}
+
+func newTestflightLinksCmd(debugFlagValue *bool) *testflightLinksCmd {
+	var root = &testflightLinksCmd{debugFlagValue: debugFlagValue}
+
+	var cmd = &cobra.Command{
+		Use:   "links",
+		Short: "Lists the public TestFlight links of each beta group",
+		Long: `Use to list the public TestFlight links of the beta groups of the selected apps, one per line
+with the app, the beta group and the link separated by tabs. Groups without an enabled public link
+are omitted.
+
+Cider requires the ASC_KEY_ID, ASC_ISSUER_ID, and ASC_PRIVATE_KEY or ASC_PRIVATE_KEY_PATH environment
+variables to be set, as described in the documentation for the release command.`,
+		Example:       "cider testflight links --app MyApp",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          cobra.NoArgs,
+		RunE:          root.Run,
+	}
+
+	root.opts.addFlags(cmd.Flags())
+
+	root.cmd = cmd
+
+	return root
+}
+
+func (cmd *testflightLinksCmd) Run(c *cobra.Command, args []string) error {
+	logger := newLogger(cmd.debugFlagValue)
+
+	ctx, client, err := newAPIContext(cmd.opts, logger)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		ascApp, err := client.GetAppForBundleID(ctx, app.BundleID)
+		if err != nil {
+			return err
+		}
+
+		links, err := client.ListBetaGroupPublicLinks(ctx, ascApp.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range keys.Sorted(links) {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", name, group, links[group])
+		}
+	}
+
+	return w.Flush()
+}
+
+// logPublicLinks logs the public TestFlight links of each app's beta groups, keyed by app name and group name.
+func logPublicLinks(logger log.Interface, links map[string]map[string]string) {
+	apps := make([]string, 0, len(links))
+	for app := range links {
+		apps = append(apps, app)
+	}
+
+	sort.Strings(apps)
+
+	for _, app := range apps {
+		for _, group := range keys.Sorted(links[app]) {
+			logger.
+				WithFields(log.Fields{
+					"app":   app,
+					"group": group,
+				}).
+				Info(links[app][group])
+		}
+	}
+}
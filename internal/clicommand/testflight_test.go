@@ -0,0 +1,71 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clicommand
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestflightLinksCmd(t *testing.T) {
+	t.Parallel()
+
+	var noDebug bool
+
+	var cmd = newTestflightLinksCmd(&noDebug)
+
+	var path = filepath.Join(t.TempDir(), "foo.yaml")
+
+	var proj = config.Project{
+		"My App": {BundleID: "com.app"},
+	}
+
+	s, err := proj.String()
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(s), 0600)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+
+	cmd.opts.config = path
+	cmd.opts.client = &clienttest.Client{}
+	cmd.cmd.SetOut(&out)
+
+	err = cmd.cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "My App  TEST  https://testflight.apple.com/join/TEST\n", out.String())
+}
+
+func TestLogPublicLinks(t *testing.T) {
+	t.Parallel()
+
+	logPublicLinks(log.New(), map[string]map[string]string{
+		"My App": {"Public": "https://testflight.apple.com/join/TEST"},
+		"Other":  {},
+	})
+}
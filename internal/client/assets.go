@@ -25,6 +25,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -49,7 +50,16 @@ func (c *ascClient) UpdatePreviewsAndScreenshotsIfNeeded(ctx *context.Context, g
 			return err
 		}
 
-		if err := c.UpdatePreviewSets(ctx, g, previewSets.Data, loc.ID, config.PreviewSets); err != nil {
+		createSet := func(t asc.PreviewType) (*asc.AppPreviewSet, error) {
+			resp, _, err := c.client.Apps.CreateAppPreviewSet(ctx, t, loc.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &resp.Data, nil
+		}
+
+		if err := c.UpdatePreviewSets(ctx, g, previewSets.Data, createSet, config.PreviewSets); err != nil {
 			return err
 		}
 	}
@@ -62,7 +72,87 @@ func (c *ascClient) UpdatePreviewsAndScreenshotsIfNeeded(ctx *context.Context, g
 			return err
 		}
 
-		if err := c.UpdateScreenshotSets(ctx, g, screenshotSets.Data, loc.ID, config.ScreenshotSets); err != nil {
+		createSet := func(t asc.ScreenshotDisplayType) (*asc.AppScreenshotSet, error) {
+			resp, _, err := c.client.Apps.CreateAppScreenshotSet(ctx, t, loc.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &resp.Data, nil
+		}
+
+		if err := c.UpdateScreenshotSets(ctx, g, screenshotSets.Data, createSet, config.ScreenshotSets.WithFallbacks(config.ScreenshotFallbacks)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateLocalizationAssets uploads the previews and screenshots of a localization the App Store Connect API client
+// does not model, such as the localization of a custom product page. The localization is a resource of type typ,
+// which new preview and screenshot sets refer to through relationship.
+func (c *ascClient) updateLocalizationAssets(ctx *context.Context, g parallel.Group, typ string, relationship string, locID string, previewSets config.PreviewSets, screenshotSets config.ScreenshotSets) error {
+	if len(previewSets) > 0 {
+		var previewSetsResp asc.AppPreviewSetsResponse
+
+		if err := c.send(ctx, http.MethodGet, fmt.Sprintf("v1/%s/%s/appPreviewSets", typ, locID), nil, &previewSetsResp); err != nil {
+			return err
+		}
+
+		createSet := func(t asc.PreviewType) (*asc.AppPreviewSet, error) {
+			body := apiDocument{Data: apiResource{
+				Type: "appPreviewSets",
+				Attributes: map[string]interface{}{
+					"previewType": t,
+				},
+				Relationships: map[string]apiRelationship{
+					relationship: toOne(typ, locID),
+				},
+			}}
+
+			var resp asc.AppPreviewSetResponse
+
+			if err := c.send(ctx, http.MethodPost, "v1/appPreviewSets", body, &resp); err != nil {
+				return nil, err
+			}
+
+			return &resp.Data, nil
+		}
+
+		if err := c.UpdatePreviewSets(ctx, g, previewSetsResp.Data, createSet, previewSets); err != nil {
+			return err
+		}
+	}
+
+	if len(screenshotSets) > 0 {
+		var screenshotSetsResp asc.AppScreenshotSetsResponse
+
+		if err := c.send(ctx, http.MethodGet, fmt.Sprintf("v1/%s/%s/appScreenshotSets", typ, locID), nil, &screenshotSetsResp); err != nil {
+			return err
+		}
+
+		createSet := func(t asc.ScreenshotDisplayType) (*asc.AppScreenshotSet, error) {
+			body := apiDocument{Data: apiResource{
+				Type: "appScreenshotSets",
+				Attributes: map[string]interface{}{
+					"screenshotDisplayType": t,
+				},
+				Relationships: map[string]apiRelationship{
+					relationship: toOne(typ, locID),
+				},
+			}}
+
+			var resp asc.AppScreenshotSetResponse
+
+			if err := c.send(ctx, http.MethodPost, "v1/appScreenshotSets", body, &resp); err != nil {
+				return nil, err
+			}
+
+			return &resp.Data, nil
+		}
+
+		if err := c.UpdateScreenshotSets(ctx, g, screenshotSetsResp.Data, createSet, screenshotSets); err != nil {
 			return err
 		}
 	}
@@ -106,8 +196,11 @@ func (c *ascClient) UploadRoutingCoverage(ctx *context.Context, versionID string
 	return c.uploadFile(ctx, config.Path, prepare, create, commit)
 }
 
+// UpdatePreviewSets uploads the previews of each preview set, using createSet to create the preview sets
+// that do not exist yet.
+//
 //nolint:dupl // This is a false positive identified by dupl against UpdateScreenshotSets
-func (c *ascClient) UpdatePreviewSets(ctx *context.Context, g parallel.Group, previewSets []asc.AppPreviewSet, appStoreVersionLocalizationID string, config config.PreviewSets) error {
+func (c *ascClient) UpdatePreviewSets(ctx *context.Context, g parallel.Group, previewSets []asc.AppPreviewSet, createSet func(asc.PreviewType) (*asc.AppPreviewSet, error), config config.PreviewSets) error {
 	found := make(map[asc.PreviewType]bool)
 
 	for i := range previewSets {
@@ -121,18 +214,20 @@ func (c *ascClient) UpdatePreviewSets(ctx *context.Context, g parallel.Group, pr
 		}
 	}
 
-	for previewType, previews := range config {
+	for previewType := range config {
 		t := *previewType.APIValue()
 		if found[t] {
 			continue
 		}
 
-		previewSetResp, _, err := c.client.Apps.CreateAppPreviewSet(ctx, t, appStoreVersionLocalizationID)
+		found[t] = true
+
+		previewSet, err := createSet(t)
 		if err != nil {
 			return err
 		}
 
-		if err := c.UploadPreviews(ctx, g, &previewSetResp.Data, previews); err != nil {
+		if err := c.UploadPreviews(ctx, g, previewSet, config.GetPreviews(t)); err != nil {
 			return err
 		}
 	}
@@ -218,8 +313,11 @@ func (c *ascClient) UploadPreviews(ctx *context.Context, g parallel.Group, previ
 	return nil
 }
 
+// UpdateScreenshotSets uploads the screenshots of each screenshot set, using createSet to create the screenshot
+// sets that do not exist yet.
+//
 //nolint:dupl // This is a false positive identified by dupl against UpdatePreviewSets
-func (c *ascClient) UpdateScreenshotSets(ctx *context.Context, g parallel.Group, screenshotSets []asc.AppScreenshotSet, appStoreVersionLocalizationID string, config config.ScreenshotSets) error {
+func (c *ascClient) UpdateScreenshotSets(ctx *context.Context, g parallel.Group, screenshotSets []asc.AppScreenshotSet, createSet func(asc.ScreenshotDisplayType) (*asc.AppScreenshotSet, error), config config.ScreenshotSets) error {
 	found := make(map[asc.ScreenshotDisplayType]bool)
 
 	for i := range screenshotSets {
@@ -233,18 +331,20 @@ func (c *ascClient) UpdateScreenshotSets(ctx *context.Context, g parallel.Group,
 		}
 	}
 
-	for screenshotType, screenshots := range config {
+	for screenshotType := range config {
 		t := *screenshotType.APIValue()
 		if found[t] {
 			continue
 		}
 
-		screenshotSetResp, _, err := c.client.Apps.CreateAppScreenshotSet(ctx, t, appStoreVersionLocalizationID)
+		found[t] = true
+
+		screenshotSet, err := createSet(t)
 		if err != nil {
 			return err
 		}
 
-		if err := c.UploadScreenshots(ctx, g, &screenshotSetResp.Data, screenshots); err != nil {
+		if err := c.UploadScreenshots(ctx, g, screenshotSet, config.GetScreenshots(t)); err != nil {
 			return err
 		}
 	}
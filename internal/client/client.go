@@ -24,7 +24,11 @@ package client
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cidertool/asc-go/asc"
 	"github.com/cidertool/cider/pkg/config"
@@ -37,6 +41,8 @@ const (
 
 var errNoVersionProvided = errors.New("no version provided to lookup build with")
 
+var errMissingPrunePattern = errors.New("prunePattern must be set to prune beta groups")
+
 type errNoAppFound struct {
 	BundleID string
 }
@@ -113,6 +119,14 @@ func (e errBuildNoAttributes) Error() string {
 	return fmt.Sprintf("build %s has no attributes", e.id)
 }
 
+type errBuildNoPlatform struct {
+	id string
+}
+
+func (e errBuildNoPlatform) Error() string {
+	return fmt.Sprintf("build %s has no platform", e.id)
+}
+
 type errBuildNoProcessingState struct {
 	id string
 }
@@ -130,6 +144,29 @@ func (e errBuildInvalidProcessingState) Error() string {
 	return fmt.Sprintf("latest build %s has a processing state of %s. it would be dangerous to proceed", e.id, *e.processingState)
 }
 
+type errTooManyTesterRemovals struct {
+	Removals int
+	Removed  int
+	Limit    int
+}
+
+func (e errTooManyTesterRemovals) Error() string {
+	return fmt.Sprintf("refusing to remove %d beta testers after %d were already removed, which would exceed the limit of %d removals per run", e.Removals, e.Removed, e.Limit)
+}
+
+type errBetaGroupTypeMismatch struct {
+	Name       string
+	IsInternal bool
+}
+
+func (e errBetaGroupTypeMismatch) Error() string {
+	if e.IsInternal {
+		return fmt.Sprintf("beta group %s is configured as external, but is an internal group in App Store Connect", e.Name)
+	}
+
+	return fmt.Sprintf("beta group %s is configured as internal, but is an external group in App Store Connect", e.Name)
+}
+
 // Client is an abstraction of an App Store Connect API client's functionality.
 type Client interface {
 	// GetAppForBundleID returns the App resource matching the given bundle ID
@@ -138,6 +175,11 @@ type Client interface {
 	// GetBuild returns the Build resource for the given app, depending on the value set for
 	// ctx.Build. Returns an error if the selected build is still processing.
 	GetBuild(ctx *context.Context, app *asc.App) (*asc.Build, error)
+	// GetBuildForPlatform returns the Build resource for the given app on the given platform, like GetBuild.
+	GetBuildForPlatform(ctx *context.Context, app *asc.App, platform config.Platform) (*asc.Build, error)
+	// GetBuildForNumber returns the most recently uploaded Build resource of the given app with the given build
+	// number, regardless of its version.
+	GetBuildForNumber(ctx *context.Context, app *asc.App, number string) (*asc.Build, error)
 	// ReleaseForAppIsInitial returns true if the App resource has never released before,
 	// i.e. has one or less associated App Store Version relationships.
 	ReleaseForAppIsInitial(ctx *context.Context, appID string) (bool, error)
@@ -154,12 +196,49 @@ type Client interface {
 	UpdateBetaBuildLocalizations(ctx *context.Context, buildID string, config config.TestflightLocalizations) error
 	// UpdateBetaLicenseAgreement updates an App's beta license agreement, or creates a new one if one does not yet exist.
 	UpdateBetaLicenseAgreement(ctx *context.Context, appID string, config config.Testflight) error
+	// GetPreviousBuildWhatsNew returns the "What to Test" notes of the build of an App uploaded for the same platform
+	// before the given build, in the first of locales that has notes, or an empty string if there is no such build.
+	GetPreviousBuildWhatsNew(ctx *context.Context, appID string, buildID string, locales []string) (string, error)
+	// AssignBetaGroups updates the beta groups of an App, creating any that do not exist, and adds the build to them.
+	// Testers are removed from groups configured with the exact sync mode if they are not listed. Builds are not
+	// added to internal groups that have access to all builds.
 	AssignBetaGroups(ctx *context.Context, appID string, buildID string, groups []config.BetaGroup) error
+	// ListBetaTesterStatuses returns the invitation state of each beta tester of an App, along with their sessions,
+	// crashes and feedback over the given ISO 8601 period, such as P30D.
+	ListBetaTesterStatuses(ctx *context.Context, appID string, period string) ([]BetaTesterStatus, error)
+	// ListBetaFeedback returns the screenshot and crash feedback that beta testers submitted for an App since the
+	// given time, newest first.
+	ListBetaFeedback(ctx *context.Context, appID string, since time.Time) ([]Feedback, error)
+	// DownloadFeedbackScreenshot writes the screenshot of a feedback submission at the given URL to w.
+	DownloadFeedbackScreenshot(ctx *context.Context, url string, w io.Writer) error
+	// ListBetaGroupPublicLinks returns the public TestFlight links of an App's beta groups, keyed by group name.
+	// Groups without an enabled public link are omitted.
+	ListBetaGroupPublicLinks(ctx *context.Context, appID string) (map[string]string, error)
+	// RemovePreviousBuildsFromBetaGroups removes builds for the given build's platform, other than the given build,
+	// from the App's beta groups that are listed in groups.
+	RemovePreviousBuildsFromBetaGroups(ctx *context.Context, appID string, buildID string, groups []config.BetaGroup) error
+	// ExpireBuilds expires the App's builds for the given build's platform that match the given configuration,
+	// other than builds of the given build's version, and returns the number of builds expired.
+	ExpireBuilds(ctx *context.Context, appID string, buildID string, config config.ExpireBuilds) (int, error)
+	// PruneBetaGroups deletes the beta groups of an App that are not listed in groups and whose names match the glob
+	// pattern, and returns their names. Internal beta groups are never deleted. If ctx.PruneDryRun is set, the groups
+	// are only listed.
+	PruneBetaGroups(ctx *context.Context, appID string, groups []config.BetaGroup, pattern string) ([]string, error)
 	AssignBetaTesters(ctx *context.Context, appID string, buildID string, testers []config.BetaTester) error
+	// RemoveUnlistedBetaTesters removes access to an App from individual testers that are not listed, and that do not
+	// belong to any of the App's beta groups.
+	RemoveUnlistedBetaTesters(ctx *context.Context, appID string, testers []config.BetaTester) error
+	// ListBetaGroupTesters returns the testers in an App's beta groups, along with the names of the groups each belongs to.
+	ListBetaGroupTesters(ctx *context.Context, appID string) ([]config.TesterRecord, error)
 	// UpdateBetaReviewDetails updates an App's beta review details, or creates new ones if they do not yet exist.
 	UpdateBetaReviewDetails(ctx *context.Context, appID string, config config.ReviewDetails) error
 	// SubmitBetaApp submits the given beta build for review
 	SubmitBetaApp(ctx *context.Context, buildID string) error
+	// GetBetaReviewState returns the state of the Beta App Review of the given build, or an empty state if the
+	// build was never submitted for review.
+	GetBetaReviewState(ctx *context.Context, buildID string) (asc.BetaReviewState, error)
+	// NotifyBetaTesters notifies the beta testers of the given build that it is available for testing.
+	NotifyBetaTesters(ctx *context.Context, buildID string) error
 
 	// App Store
 
@@ -175,18 +254,76 @@ type Client interface {
 	// SubmitApp submits the given app store version for review
 	SubmitApp(ctx *context.Context, versionID string) error
 
+	// In-App Purchases
+
+	// UpdateInAppPurchases creates or updates the in-app purchases of an App, including their localizations,
+	// price and review screenshot.
+	UpdateInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) error
+	// SubmitInAppPurchases submits the configured in-app purchases of an App that are ready to submit for review
+	// with the next App Store version, and returns the number of in-app purchases submitted.
+	SubmitInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) (int, error)
+
+	// Subscriptions
+
+	// UpdateSubscriptionGroups creates or updates the subscription groups of an App and their auto-renewable
+	// subscriptions, including their localizations, prices, introductory offers and review screenshots.
+	UpdateSubscriptionGroups(ctx *context.Context, appID string, config config.SubscriptionGroups) error
+
+	// Product Pages
+
+	// UpdateCustomProductPages creates or updates the custom product pages of an App, including the localizations,
+	// screenshots and previews of their editable version.
+	UpdateCustomProductPages(ctx *context.Context, appID string, config config.CustomProductPages) error
+	// UpdateExperiments creates or updates the product page optimization experiments of an App on a platform,
+	// including the localizations, screenshots and previews of their treatments.
+	UpdateExperiments(ctx *context.Context, appID string, platform config.Platform, config config.Experiments) error
+
+	// In-App Events
+
+	// UpdateInAppEvents creates or updates the in-app events of an App, including their schedule, localizations
+	// and media.
+	UpdateInAppEvents(ctx *context.Context, appID string, config config.InAppEvents) error
+
+	// Privacy
+
+	// GetPrivacyDetails returns the privacy details of an App that the App Store Connect API can read, as published
+	// with its version that is ready for sale on the platform.
+	GetPrivacyDetails(ctx *context.Context, appID string, platform config.Platform) (*PrivacyDetails, error)
+
 	Project() (*config.Project, error)
 }
 
 // New returns a new Client.
 func New(ctx *context.Context) Client {
-	client := asc.NewClient(ctx.Credentials.Client())
+	httpClient := ctx.Credentials.Client()
+	client := asc.NewClient(httpClient)
 
-	return &ascClient{client: client}
+	return &ascClient{client: client, httpClient: httpClient}
 }
 
 type ascClient struct {
 	client *asc.Client
+	// httpClient is the authenticated client used to send requests the App Store Connect API client does not model.
+	httpClient *http.Client
+
+	// testerRemovals counts the beta testers removed during this run, which is capped by ctx.MaxTesterRemovals.
+	testerRemovals int
+	mu             sync.Mutex
+}
+
+// reserveTesterRemovals records the intent to remove n beta testers, or returns an error if doing so would
+// exceed the limit of removals for this run.
+func (c *ascClient) reserveTesterRemovals(ctx *context.Context, n int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.testerRemovals+n > ctx.MaxTesterRemovals {
+		return errTooManyTesterRemovals{Removals: n, Removed: c.testerRemovals, Limit: ctx.MaxTesterRemovals}
+	}
+
+	c.testerRemovals += n
+
+	return nil
 }
 
 func (c *ascClient) GetAppForBundleID(ctx *context.Context, bundleID string) (*asc.App, error) {
@@ -225,6 +362,19 @@ func (c *ascClient) GetAppInfo(ctx *context.Context, appID string) (*asc.AppInfo
 }
 
 func (c *ascClient) GetBuild(ctx *context.Context, app *asc.App) (*asc.Build, error) {
+	return c.getBuild(ctx, app, nil)
+}
+
+func (c *ascClient) GetBuildForPlatform(ctx *context.Context, app *asc.App, platform config.Platform) (*asc.Build, error) {
+	platformValue := platform.APIValue()
+	if platformValue == nil {
+		return nil, errPlatformNotFound{Platform: platform}
+	}
+
+	return c.getBuild(ctx, app, platformValue)
+}
+
+func (c *ascClient) getBuild(ctx *context.Context, app *asc.App, platform *asc.Platform) (*asc.Build, error) {
 	if ctx.Version == "" {
 		return nil, errNoVersionProvided
 	}
@@ -234,6 +384,10 @@ func (c *ascClient) GetBuild(ctx *context.Context, app *asc.App) (*asc.Build, er
 		FilterPreReleaseVersionVersion: []string{ctx.Version},
 	}
 
+	if platform != nil {
+		query.FilterPreReleaseVersionPlatform = []string{string(*platform)}
+	}
+
 	if ctx.Build != "" {
 		query.FilterVersion = []string{ctx.Build}
 	}
@@ -265,6 +419,24 @@ func (c *ascClient) GetBuild(ctx *context.Context, app *asc.App) (*asc.Build, er
 	return &build, nil
 }
 
+func (c *ascClient) GetBuildForNumber(ctx *context.Context, app *asc.App, number string) (*asc.Build, error) {
+	resp, _, err := c.client.Builds.ListBuilds(ctx, &asc.ListBuildsQuery{
+		FilterApp:     []string{app.ID},
+		FilterVersion: []string{number},
+		Sort:          []string{"-uploadedDate"},
+		Limit:         1,
+	})
+	if err != nil || len(resp.Data) == 0 {
+		return nil, errBuildNotFound{
+			AppID:        *app.Attributes.BundleID,
+			BuildVersion: number,
+			InnerErr:     err,
+		}
+	}
+
+	return &resp.Data[0], nil
+}
+
 func (c *ascClient) ReleaseForAppIsInitial(ctx *context.Context, appID string) (bool, error) {
 	resp, _, err := c.client.Apps.ListAppStoreVersionsForApp(ctx, appID, nil)
 	if err != nil {
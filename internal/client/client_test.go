@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -211,6 +212,48 @@ func TestGetBuild_HappyOverrideBuild(t *testing.T) {
 	assert.Equal(t, expectedProcessingState, *build.Attributes.ProcessingState)
 }
 
+func TestGetBuildForPlatform_Happy(t *testing.T) {
+	t.Parallel()
+
+	expectedProcessingState := validProcessingState
+	app := asc.App{
+		Attributes: &asc.AppAttributes{
+			BundleID: asc.String("com.app.bundleid"),
+		},
+	}
+
+	ctx, client := newTestContext(response{
+		Response: asc.BuildsResponse{
+			Data: []asc.Build{
+				{
+					Attributes: &asc.BuildAttributes{
+						ProcessingState: &expectedProcessingState,
+					},
+				},
+			},
+		},
+	})
+	defer ctx.Close()
+
+	ctx.Context.Version = testGetBuildVersion
+	build, err := client.GetBuildForPlatform(ctx.Context, &app, config.PlatformMacOS)
+	assert.NoError(t, err)
+	assert.NotNil(t, build)
+	assert.Equal(t, expectedProcessingState, *build.Attributes.ProcessingState)
+}
+
+func TestGetBuildForPlatform_ErrPlatform(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	ctx.Context.Version = testGetBuildVersion
+	build, err := client.GetBuildForPlatform(ctx.Context, &asc.App{}, "watchOS")
+	assert.Error(t, err)
+	assert.Nil(t, build)
+}
+
 func TestGetBuild_ErrNoVersion(t *testing.T) {
 	t.Parallel()
 
@@ -337,6 +380,47 @@ func TestGetBuild_ErrInvalidProcessingState(t *testing.T) {
 	assert.Nil(t, build)
 }
 
+// Test GetBuildForNumber
+
+func TestGetBuildForNumber_Happy(t *testing.T) {
+	t.Parallel()
+
+	app := asc.App{
+		Attributes: &asc.AppAttributes{
+			BundleID: asc.String("com.app.bundleid"),
+		},
+	}
+
+	ctx, client := newTestContext(response{
+		RawResponse: `{"data":[{"id":"TEST","attributes":{"version":"42"}}]}`,
+	})
+	defer ctx.Close()
+
+	build, err := client.GetBuildForNumber(ctx.Context, &app, "42")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST", build.ID)
+}
+
+func TestGetBuildForNumber_ErrNoBuilds(t *testing.T) {
+	t.Parallel()
+
+	app := asc.App{
+		Attributes: &asc.AppAttributes{
+			BundleID: asc.String("com.app.bundleid"),
+		},
+	}
+
+	ctx, client := newTestContext(response{
+		RawResponse: `{"data":[]}`,
+	})
+	defer ctx.Close()
+
+	build, err := client.GetBuildForNumber(ctx.Context, &app, "42")
+	assert.Error(t, err)
+	assert.Equal(t, "build not found matching app=com.app.bundleid, build=42", err.Error())
+	assert.Nil(t, build)
+}
+
 // Test ReleaseForAppIsInitial
 
 func TestReleaseForAppIsInitial_HappyInitial(t *testing.T) {
@@ -22,10 +22,12 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package clienttest
 
 import (
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/client"
 	"github.com/cidertool/cider/pkg/config"
 	"github.com/cidertool/cider/pkg/context"
 )
@@ -95,6 +97,22 @@ func (c *Client) GetBuild(ctx *context.Context, app *asc.App) (*asc.Build, error
 	}, nil
 }
 
+// GetBuildForPlatform mocks returning the latest valid build corresponding to an app on a platform.
+func (c *Client) GetBuildForPlatform(ctx *context.Context, app *asc.App, platform config.Platform) (*asc.Build, error) {
+	return c.GetBuild(ctx, app)
+}
+
+// GetBuildForNumber mocks returning the build of an app with the given build number.
+func (c *Client) GetBuildForNumber(ctx *context.Context, app *asc.App, number string) (*asc.Build, error) {
+	return &asc.Build{
+		Attributes: &asc.BuildAttributes{
+			ProcessingState: asc.String("VALID"),
+			Version:         &number,
+		},
+		ID: "TEST",
+	}, nil
+}
+
 // ReleaseForAppIsInitial mocks returning whether or not an app has released on the App Store before.
 func (c *Client) ReleaseForAppIsInitial(ctx *context.Context, appID string) (bool, error) {
 	return false, nil
@@ -130,6 +148,93 @@ func (c *Client) AssignBetaTesters(ctx *context.Context, appID string, buildID s
 	return nil
 }
 
+// RemoveUnlistedBetaTesters mocks removing unlisted individual beta testers from an app.
+func (c *Client) RemoveUnlistedBetaTesters(ctx *context.Context, appID string, testers []config.BetaTester) error {
+	return nil
+}
+
+// ListBetaGroupTesters mocks listing the testers in an app's beta groups.
+func (c *Client) ListBetaGroupTesters(ctx *context.Context, appID string) ([]config.TesterRecord, error) {
+	return []config.TesterRecord{
+		{
+			BetaTester: config.BetaTester{
+				Email:     "test@example.com",
+				FirstName: "Person",
+				LastName:  "Personson",
+			},
+			Groups: []string{"TEST"},
+		},
+	}, nil
+}
+
+// GetPreviousBuildWhatsNew mocks getting the notes of the previous build.
+func (c *Client) GetPreviousBuildWhatsNew(ctx *context.Context, appID string, buildID string, locales []string) (string, error) {
+	return "Fixed bugs\n\nCommit: abcdef1234567890abcdef1234567890abcdef12", nil
+}
+
+// ListBetaTesterStatuses mocks listing the status of beta testers.
+func (c *Client) ListBetaTesterStatuses(ctx *context.Context, appID string, period string) ([]client.BetaTesterStatus, error) {
+	return []client.BetaTesterStatus{
+		{
+			Email:      "test@example.com",
+			FirstName:  "Person",
+			LastName:   "Personson",
+			InviteType: "EMAIL",
+			State:      "INSTALLED",
+			Sessions:   12,
+			Crashes:    1,
+			Feedback:   2,
+			LastActive: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, nil
+}
+
+// ListBetaFeedback mocks listing beta feedback.
+func (c *Client) ListBetaFeedback(ctx *context.Context, appID string, since time.Time) ([]client.Feedback, error) {
+	return []client.Feedback{
+		{
+			ID:          "TEST",
+			Kind:        client.FeedbackKindScreenshot,
+			CreatedDate: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Tester:      "test@example.com",
+			Build:       "1",
+			Device:      "iPhone13_2",
+			OSVersion:   "14.4",
+			Comment:     "Button is cut off",
+			Screenshots: []string{"https://example.com/TEST.png"},
+		},
+	}, nil
+}
+
+// DownloadFeedbackScreenshot mocks downloading a feedback screenshot.
+func (c *Client) DownloadFeedbackScreenshot(ctx *context.Context, url string, w io.Writer) error {
+	_, err := w.Write([]byte("TEST"))
+
+	return err
+}
+
+// ListBetaGroupPublicLinks mocks listing the public links of beta groups.
+func (c *Client) ListBetaGroupPublicLinks(ctx *context.Context, appID string) (map[string]string, error) {
+	return map[string]string{
+		"TEST": "https://testflight.apple.com/join/TEST",
+	}, nil
+}
+
+// RemovePreviousBuildsFromBetaGroups mocks removing previous builds from beta groups.
+func (c *Client) RemovePreviousBuildsFromBetaGroups(ctx *context.Context, appID string, buildID string, groups []config.BetaGroup) error {
+	return nil
+}
+
+// ExpireBuilds mocks expiring old builds.
+func (c *Client) ExpireBuilds(ctx *context.Context, appID string, buildID string, config config.ExpireBuilds) (int, error) {
+	return 0, nil
+}
+
+// PruneBetaGroups mocks deleting unlisted beta groups.
+func (c *Client) PruneBetaGroups(ctx *context.Context, appID string, groups []config.BetaGroup, pattern string) ([]string, error) {
+	return []string{"TEST"}, nil
+}
+
 // UpdateBetaReviewDetails mocks updating review details for a beta app.
 func (c *Client) UpdateBetaReviewDetails(ctx *context.Context, appID string, config config.ReviewDetails) error {
 	return nil
@@ -140,6 +245,16 @@ func (c *Client) SubmitBetaApp(ctx *context.Context, buildID string) error {
 	return nil
 }
 
+// GetBetaReviewState mocks returning the state of the beta app review of a build.
+func (c *Client) GetBetaReviewState(ctx *context.Context, buildID string) (asc.BetaReviewState, error) {
+	return asc.BetaReviewStateApproved, nil
+}
+
+// NotifyBetaTesters mocks notifying beta testers that a build is available.
+func (c *Client) NotifyBetaTesters(ctx *context.Context, buildID string) error {
+	return nil
+}
+
 // UpdateApp mocks updating properties for an app.
 func (c *Client) UpdateApp(ctx *context.Context, appID string, appInfoID string, versionID string, config config.App) error {
 	return nil
@@ -201,6 +316,44 @@ func (c *Client) SubmitApp(ctx *context.Context, versionID string) error {
 	return nil
 }
 
+// UpdateInAppPurchases mocks updating in-app purchases.
+func (c *Client) UpdateInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) error {
+	return nil
+}
+
+// SubmitInAppPurchases mocks submitting in-app purchases for review.
+func (c *Client) SubmitInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) (int, error) {
+	return len(config), nil
+}
+
+// UpdateSubscriptionGroups mocks updating subscription groups.
+func (c *Client) UpdateSubscriptionGroups(ctx *context.Context, appID string, config config.SubscriptionGroups) error {
+	return nil
+}
+
+// UpdateCustomProductPages mocks updating custom product pages.
+func (c *Client) UpdateCustomProductPages(ctx *context.Context, appID string, config config.CustomProductPages) error {
+	return nil
+}
+
+// UpdateExperiments mocks updating product page optimization experiments.
+func (c *Client) UpdateExperiments(ctx *context.Context, appID string, platform config.Platform, config config.Experiments) error {
+	return nil
+}
+
+// UpdateInAppEvents mocks updating in-app events.
+func (c *Client) UpdateInAppEvents(ctx *context.Context, appID string, config config.InAppEvents) error {
+	return nil
+}
+
+// GetPrivacyDetails mocks getting the published privacy details of an app.
+func (c *Client) GetPrivacyDetails(ctx *context.Context, appID string, platform config.Platform) (*client.PrivacyDetails, error) {
+	return &client.PrivacyDetails{
+		Version:           "1.0",
+		PrivacyPolicyURLs: map[string]string{"en-US": "https://example.com/privacy"},
+	}, nil
+}
+
 // Project mocks returning a project built from API values.
 func (c *Client) Project() (*config.Project, error) {
 	return &config.Project{}, nil
@@ -21,7 +21,9 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package clienttest_test
 
 import (
+	"io"
 	"testing"
+	"time"
 
 	"github.com/cidertool/cider/internal/client/clienttest"
 	"github.com/cidertool/cider/pkg/config"
@@ -48,6 +50,14 @@ func TestClient(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, build)
 
+	build, err = c.GetBuildForPlatform(ctx, nil, config.PlatformiOS)
+	assert.NoError(t, err)
+	assert.NotNil(t, build)
+
+	build, err = c.GetBuildForNumber(ctx, nil, "99")
+	assert.NoError(t, err)
+	assert.NotNil(t, build)
+
 	initial, err := c.ReleaseForAppIsInitial(ctx, "TEST")
 	assert.NoError(t, err)
 	assert.False(t, initial)
@@ -70,12 +80,48 @@ func TestClient(t *testing.T) {
 	err = c.AssignBetaTesters(ctx, "TEST", "TEST", []config.BetaTester{})
 	assert.NoError(t, err)
 
+	err = c.RemoveUnlistedBetaTesters(ctx, "TEST", []config.BetaTester{})
+	assert.NoError(t, err)
+
+	_, err = c.ListBetaGroupTesters(ctx, "TEST")
+	assert.NoError(t, err)
+
+	_, err = c.GetPreviousBuildWhatsNew(ctx, "TEST", "TEST", []string{"en-US"})
+	assert.NoError(t, err)
+
+	_, err = c.ListBetaTesterStatuses(ctx, "TEST", "P30D")
+	assert.NoError(t, err)
+
+	_, err = c.ListBetaFeedback(ctx, "TEST", time.Time{})
+	assert.NoError(t, err)
+
+	err = c.DownloadFeedbackScreenshot(ctx, "TEST", io.Discard)
+	assert.NoError(t, err)
+
+	_, err = c.ListBetaGroupPublicLinks(ctx, "TEST")
+	assert.NoError(t, err)
+
+	err = c.RemovePreviousBuildsFromBetaGroups(ctx, "TEST", "TEST", []config.BetaGroup{})
+	assert.NoError(t, err)
+
+	_, err = c.ExpireBuilds(ctx, "TEST", "TEST", config.ExpireBuilds{})
+	assert.NoError(t, err)
+
+	_, err = c.PruneBetaGroups(ctx, "TEST", []config.BetaGroup{}, "*")
+	assert.NoError(t, err)
+
 	err = c.UpdateBetaReviewDetails(ctx, "TEST", config.ReviewDetails{})
 	assert.NoError(t, err)
 
 	err = c.SubmitBetaApp(ctx, "TEST")
 	assert.NoError(t, err)
 
+	_, err = c.GetBetaReviewState(ctx, "TEST")
+	assert.NoError(t, err)
+
+	err = c.NotifyBetaTesters(ctx, "TEST")
+	assert.NoError(t, err)
+
 	err = c.UpdateApp(ctx, "TEST", "TEST", "TEST", config.App{})
 	assert.NoError(t, err)
 
@@ -104,6 +150,28 @@ func TestClient(t *testing.T) {
 	err = c.SubmitApp(ctx, "TEST")
 	assert.NoError(t, err)
 
+	err = c.UpdateInAppPurchases(ctx, "TEST", config.InAppPurchases{})
+	assert.NoError(t, err)
+
+	_, err = c.SubmitInAppPurchases(ctx, "TEST", config.InAppPurchases{})
+	assert.NoError(t, err)
+
+	err = c.UpdateSubscriptionGroups(ctx, "TEST", config.SubscriptionGroups{})
+	assert.NoError(t, err)
+
+	err = c.UpdateCustomProductPages(ctx, "TEST", config.CustomProductPages{})
+	assert.NoError(t, err)
+
+	err = c.UpdateExperiments(ctx, "TEST", config.PlatformiOS, config.Experiments{})
+	assert.NoError(t, err)
+
+	err = c.UpdateInAppEvents(ctx, "TEST", config.InAppEvents{})
+	assert.NoError(t, err)
+
+	details, err := c.GetPrivacyDetails(ctx, "TEST", config.PlatformiOS)
+	assert.NoError(t, err)
+	assert.NotNil(t, details)
+
 	proj, err := c.Project()
 	assert.NoError(t, err)
 	assert.NotNil(t, proj)
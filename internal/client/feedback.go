@@ -0,0 +1,216 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/closer"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// feedbackLimit is the maximum number of feedback submissions App Store Connect returns in a single page.
+const feedbackLimit = 200
+
+const (
+	// FeedbackKindScreenshot is feedback submitted by a tester with a screenshot from the TestFlight app.
+	FeedbackKindScreenshot = "screenshot"
+	// FeedbackKindCrash is feedback submitted by a tester after the app crashed.
+	FeedbackKindCrash = "crash"
+)
+
+type errDownloadScreenshot struct {
+	URL        string
+	StatusCode int
+}
+
+func (e errDownloadScreenshot) Error() string {
+	return fmt.Sprintf("downloading screenshot %s failed with status %d", e.URL, e.StatusCode)
+}
+
+// Feedback is a screenshot or crash submission sent by a beta tester through TestFlight.
+type Feedback struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	CreatedDate time.Time `json:"createdDate"`
+	Tester      string    `json:"tester,omitempty"`
+	Build       string    `json:"build,omitempty"`
+	Device      string    `json:"device,omitempty"`
+	OSVersion   string    `json:"osVersion,omitempty"`
+	Comment     string    `json:"comment,omitempty"`
+	Screenshots []string  `json:"screenshots,omitempty"`
+}
+
+// betaFeedbackResponse is a page of betaFeedbackScreenshotSubmissions or betaFeedbackCrashSubmissions, which
+// the App Store Connect API client does not model.
+type betaFeedbackResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes *struct {
+			CreatedDate *asc.DateTime `json:"createdDate,omitempty"`
+			Comment     *string       `json:"comment,omitempty"`
+			Email       *string       `json:"email,omitempty"`
+			DeviceModel *string       `json:"deviceModel,omitempty"`
+			OSVersion   *string       `json:"osVersion,omitempty"`
+			Screenshots []struct {
+				URL *string `json:"url,omitempty"`
+			} `json:"screenshots,omitempty"`
+		} `json:"attributes,omitempty"`
+		Relationships *struct {
+			Build *asc.Relationship `json:"build,omitempty"`
+		} `json:"relationships,omitempty"`
+	} `json:"data"`
+	Included []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes *struct {
+			Version *string `json:"version,omitempty"`
+		} `json:"attributes,omitempty"`
+	} `json:"included,omitempty"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+func (c *ascClient) ListBetaFeedback(ctx *context.Context, appID string, since time.Time) ([]Feedback, error) {
+	feedback := make([]Feedback, 0)
+
+	for _, source := range []struct{ kind, resource string }{
+		{FeedbackKindScreenshot, "betaFeedbackScreenshotSubmissions"},
+		{FeedbackKindCrash, "betaFeedbackCrashSubmissions"},
+	} {
+		query := url.Values{}
+		query.Set("include", "build")
+		query.Set("sort", "-createdDate")
+		query.Set("limit", strconv.Itoa(feedbackLimit))
+
+		ref := &asc.Reference{URL: url.URL{
+			Path:     fmt.Sprintf("apps/%s/%s", appID, source.resource),
+			RawQuery: query.Encode(),
+		}}
+
+		items, err := c.listBetaFeedback(ctx, source.kind, ref, since)
+		if err != nil {
+			return nil, err
+		}
+
+		feedback = append(feedback, items...)
+	}
+
+	sort.SliceStable(feedback, func(i, j int) bool {
+		return feedback[i].CreatedDate.After(feedback[j].CreatedDate)
+	})
+
+	return feedback, nil
+}
+
+// listBetaFeedback follows the pages of a feedback resource, sorted newest first, until it reaches
+// feedback submitted before since.
+func (c *ascClient) listBetaFeedback(ctx *context.Context, kind string, ref *asc.Reference, since time.Time) ([]Feedback, error) {
+	feedback := make([]Feedback, 0)
+
+	for ref != nil {
+		var resp betaFeedbackResponse
+
+		if _, err := c.client.FollowReference(ctx, ref, &resp); err != nil {
+			return nil, err
+		}
+
+		// Map of build IDs -> build versions
+		var versions = make(map[string]string)
+
+		for _, included := range resp.Included {
+			if included.Type == "builds" && included.Attributes != nil && included.Attributes.Version != nil {
+				versions[included.ID] = *included.Attributes.Version
+			}
+		}
+
+		for _, data := range resp.Data {
+			if data.Attributes == nil || data.Attributes.CreatedDate == nil {
+				continue
+			} else if data.Attributes.CreatedDate.Before(since) {
+				return feedback, nil
+			}
+
+			item := Feedback{
+				ID:          data.ID,
+				Kind:        kind,
+				CreatedDate: data.Attributes.CreatedDate.Time,
+				Tester:      stringValue(data.Attributes.Email),
+				Device:      stringValue(data.Attributes.DeviceModel),
+				OSVersion:   stringValue(data.Attributes.OSVersion),
+				Comment:     stringValue(data.Attributes.Comment),
+			}
+
+			if data.Relationships != nil && data.Relationships.Build != nil && data.Relationships.Build.Data != nil {
+				item.Build = versions[data.Relationships.Build.Data.ID]
+			}
+
+			for _, screenshot := range data.Attributes.Screenshots {
+				if screenshot.URL != nil {
+					item.Screenshots = append(item.Screenshots, *screenshot.URL)
+				}
+			}
+
+			feedback = append(feedback, item)
+		}
+
+		ref = resp.Links.Next
+	}
+
+	return feedback, nil
+}
+
+func (c *ascClient) DownloadFeedbackScreenshot(ctx *context.Context, screenshotURL string, w io.Writer) error {
+	// Screenshot URLs are signed, and are fetched without App Store Connect credentials.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, screenshotURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return errDownloadScreenshot{URL: screenshotURL, StatusCode: resp.StatusCode}
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,170 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ListBetaFeedback
+
+func TestListBetaFeedback_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v1/apps/TEST/betaFeedbackScreenshotSubmissions?cursor=2")
+	assert.NoError(t, err)
+
+	ctx.SetResponses(
+		response{
+			RawResponse: `{
+				"data": [
+					{
+						"id": "1",
+						"attributes": {
+							"createdDate": "2021-04-03T00:00:00Z",
+							"comment": "Button is cut off",
+							"email": "test@example.com",
+							"deviceModel": "iPhone13_2",
+							"osVersion": "14.4",
+							"screenshots": [{"url": "https://example.com/1.png"}]
+						},
+						"relationships": {"build": {"data": {"type": "builds", "id": "build1"}}}
+					},
+					{"id": "noattributes"}
+				],
+				"included": [{"type": "builds", "id": "build1", "attributes": {"version": "42"}}],
+				"links": {"self": "", "next": "` + next.String() + `"}
+			}`,
+		},
+		response{
+			RawResponse: `{
+				"data": [
+					{"id": "2", "attributes": {"createdDate": "2021-04-01T00:00:00Z"}},
+					{"id": "old", "attributes": {"createdDate": "2021-03-01T00:00:00Z"}}
+				],
+				"links": {"self": "", "next": "` + next.String() + `"}
+			}`,
+		},
+		response{
+			RawResponse: `{
+				"data": [
+					{"id": "3", "attributes": {"createdDate": "2021-04-02T00:00:00Z", "comment": "Crashed on launch"}}
+				],
+				"links": {"self": ""}
+			}`,
+		},
+	)
+
+	feedback, err := client.ListBetaFeedback(ctx.Context, testID, time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []Feedback{
+		{
+			ID:          "1",
+			Kind:        FeedbackKindScreenshot,
+			CreatedDate: time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC),
+			Tester:      "test@example.com",
+			Build:       "42",
+			Device:      "iPhone13_2",
+			OSVersion:   "14.4",
+			Comment:     "Button is cut off",
+			Screenshots: []string{"https://example.com/1.png"},
+		},
+		{
+			ID:          "3",
+			Kind:        FeedbackKindCrash,
+			CreatedDate: time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC),
+			Comment:     "Crashed on launch",
+		},
+		{
+			ID:          "2",
+			Kind:        FeedbackKindScreenshot,
+			CreatedDate: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, feedback)
+}
+
+func TestListBetaFeedback_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.ListBetaFeedback(ctx.Context, testID, time.Time{})
+	assert.Error(t, err)
+}
+
+// Test DownloadFeedbackScreenshot
+
+func TestDownloadFeedbackScreenshot_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `TEST`,
+		},
+	)
+	defer ctx.Close()
+
+	u, err := ctx.URL("1.png")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	err = client.DownloadFeedbackScreenshot(ctx.Context, u.String(), &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST\n", buf.String())
+}
+
+func TestDownloadFeedbackScreenshot_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusForbidden,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	u, err := ctx.URL("1.png")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	err = client.DownloadFeedbackScreenshot(ctx.Context, u.String(), &buf)
+	assert.EqualError(t, err, errDownloadScreenshot{URL: u.String(), StatusCode: http.StatusForbidden}.Error())
+
+	err = client.DownloadFeedbackScreenshot(ctx.Context, "://bad", &buf)
+	assert.Error(t, err)
+}
@@ -0,0 +1,367 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/parallel"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// appEventsLimit is the maximum number of in-app events App Store Connect returns in a single page.
+const appEventsLimit = 200
+
+// Kinds of in-app event media, by where App Store Connect shows them.
+const (
+	appEventAssetTypeEventCard   = "EVENT_CARD"
+	appEventAssetTypeDetailsPage = "EVENT_DETAILS_PAGE"
+)
+
+type errInvalidInAppEventSchedule struct {
+	Name string
+	Err  error
+}
+
+func (e errInvalidInAppEventSchedule) Error() string {
+	return fmt.Sprintf("in-app event %s has an invalid schedule: %s", e.Name, e.Err)
+}
+
+// appEvent is an in-app event resource, which the App Store Connect API client does not model.
+type appEvent struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		ReferenceName string `json:"referenceName"`
+		EventState    string `json:"eventState"`
+	} `json:"attributes"`
+}
+
+type appEventsResponse struct {
+	Data  []appEvent             `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+type appEventResponse struct {
+	Data appEvent `json:"data"`
+}
+
+// appEventAsset is an in-app event screenshot or video clip resource, which the App Store Connect API client
+// does not model.
+type appEventAsset struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		FileName          string                `json:"fileName"`
+		AppEventAssetType string                `json:"appEventAssetType"`
+		UploadOperations  []asc.UploadOperation `json:"uploadOperations"`
+	} `json:"attributes"`
+}
+
+type appEventAssetsResponse struct {
+	Data []appEventAsset `json:"data"`
+}
+
+type appEventAssetResponse struct {
+	Data appEventAsset `json:"data"`
+}
+
+func (c *ascClient) UpdateInAppEvents(ctx *context.Context, appID string, config config.InAppEvents) error {
+	existing, err := c.listInAppEvents(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	var g = parallel.New(ctx.MaxProcesses)
+
+	for name, eventConfig := range config {
+		name := name
+		eventConfig := eventConfig
+
+		g.Go(func() error {
+			event, ok := existing[name]
+			if ok && !isEditableInAppEvent(event) {
+				ctx.Log.WithField("event", name).Warn("skipping in-app event that has already been submitted")
+
+				return nil
+			}
+
+			attrs, err := inAppEventAttributes(name, eventConfig)
+			if err != nil {
+				return err
+			}
+
+			eventID := event.ID
+			if ok {
+				body := apiDocument{Data: apiResource{Type: "appEvents", ID: eventID, Attributes: attrs}}
+
+				if err := c.send(ctx, http.MethodPatch, "v1/appEvents/"+eventID, body, nil); err != nil {
+					return err
+				}
+			} else {
+				ctx.Log.WithField("event", name).Debug("create in-app event")
+
+				body := apiDocument{Data: apiResource{
+					Type:       "appEvents",
+					Attributes: attrs,
+					Relationships: map[string]apiRelationship{
+						"app": toOne("apps", appID),
+					},
+				}}
+
+				var resp appEventResponse
+
+				if err := c.send(ctx, http.MethodPost, "v1/appEvents", body, &resp); err != nil {
+					return err
+				}
+
+				eventID = resp.Data.ID
+			}
+
+			return c.updateInAppEventLocalizations(ctx, eventID, eventConfig.Localizations)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ascClient) listInAppEvents(ctx *context.Context, appID string) (map[string]appEvent, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(appEventsLimit))
+
+	events := make(map[string]appEvent)
+	path := fmt.Sprintf("v1/apps/%s/appEvents?%s", appID, query.Encode())
+
+	for path != "" {
+		var resp appEventsResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, event := range resp.Data {
+			events[event.Attributes.ReferenceName] = event
+		}
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return events, nil
+}
+
+// isEditableInAppEvent reports whether the event can be updated without withdrawing it from review.
+func isEditableInAppEvent(event appEvent) bool {
+	switch event.Attributes.EventState {
+	case "DRAFT", "READY_FOR_REVIEW", "REJECTED":
+		return true
+	default:
+		return false
+	}
+}
+
+func inAppEventAttributes(name string, config config.InAppEvent) (map[string]interface{}, error) {
+	schedule, err := config.Schedule()
+	if err != nil {
+		return nil, errInvalidInAppEventSchedule{Name: name, Err: err}
+	}
+
+	attrs := map[string]interface{}{
+		"referenceName": name,
+		"badge":         config.Badge.APIValue(),
+		"territorySchedules": []map[string]interface{}{
+			{
+				"territories":  config.Territories,
+				"publishStart": schedule.PublishStart.UTC().Format(time.RFC3339),
+				"eventStart":   schedule.EventStart.UTC().Format(time.RFC3339),
+				"eventEnd":     schedule.EventEnd.UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	if config.DeepLink != "" {
+		attrs["deepLink"] = config.DeepLink
+	}
+
+	if purpose := config.Purpose.APIValue(); purpose != nil {
+		attrs["purpose"] = purpose
+	}
+
+	if priority := config.Priority.APIValue(); priority != nil {
+		attrs["priority"] = priority
+	}
+
+	return attrs, nil
+}
+
+func (c *ascClient) updateInAppEventLocalizations(ctx *context.Context, eventID string, config config.InAppEventLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+
+	for locale, locConfig := range config {
+		attrs[locale] = map[string]interface{}{
+			"name":             locConfig.Name,
+			"shortDescription": locConfig.ShortDescription,
+			"longDescription":  locConfig.LongDescription,
+		}
+	}
+
+	ids, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v1/appEvents/%s/localizations", eventID),
+		Type:         "appEventLocalizations",
+		Relationship: "appEvent",
+		Product:      toOne("appEvents", eventID),
+	}, attrs)
+	if err != nil {
+		return err
+	}
+
+	for locale, locConfig := range config {
+		if locConfig.EventCard != nil {
+			if err := c.updateInAppEventMedia(ctx, ids[locale], appEventAssetTypeEventCard, *locConfig.EventCard); err != nil {
+				return err
+			}
+		}
+
+		if locConfig.EventDetailsPage != nil {
+			if err := c.updateInAppEventMedia(ctx, ids[locale], appEventAssetTypeDetailsPage, *locConfig.EventDetailsPage); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *ascClient) updateInAppEventMedia(ctx *context.Context, locID string, assetType string, config config.InAppEventMedia) error {
+	if config.Image != nil {
+		if err := c.uploadInAppEventAsset(ctx, "appEventScreenshots", locID, assetType, config.Image.Path, nil); err != nil {
+			return err
+		}
+	}
+
+	if config.Video != nil {
+		attrs := map[string]interface{}{}
+		if config.Video.PreviewFrameTimeCode != "" {
+			attrs["previewFrameTimeCode"] = config.Video.PreviewFrameTimeCode
+		}
+
+		if err := c.uploadInAppEventAsset(ctx, "appEventVideoClips", locID, assetType, config.Video.Path, attrs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadInAppEventAsset replaces the screenshot or video clip of the given asset type of an in-app event
+// localization, unless its file name is unchanged. App Store Connect does not keep the checksums of in-app
+// event assets to compare files by.
+func (c *ascClient) uploadInAppEventAsset(ctx *context.Context, typ string, locID string, assetType string, path string, attrs map[string]interface{}) error {
+	prepare := func(name string, checksum string) (shouldContinue bool, err error) {
+		var resp appEventAssetsResponse
+
+		if err := c.send(ctx, http.MethodGet, fmt.Sprintf("v1/appEventLocalizations/%s/%s", locID, typ), nil, &resp); err != nil {
+			return false, err
+		}
+
+		for _, asset := range resp.Data {
+			if asset.Attributes.AppEventAssetType != assetType {
+				continue
+			}
+
+			if asset.Attributes.FileName == name {
+				ctx.Log.WithFields(log.Fields{
+					"id":   asset.ID,
+					"name": name,
+				}).Debug("skip existing in-app event asset")
+
+				return false, nil
+			}
+
+			ctx.Log.WithFields(log.Fields{
+				"name": name,
+				"id":   asset.ID,
+			}).Debug("delete in-app event asset")
+
+			if err := c.send(ctx, http.MethodDelete, "v1/"+typ+"/"+asset.ID, nil, nil); err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	create := func(name string, size int64) (id string, ops []asc.UploadOperation, err error) {
+		ctx.Log.WithFields(log.Fields{
+			"name": name,
+		}).Debug("create in-app event asset")
+
+		created := map[string]interface{}{
+			"fileName":          name,
+			"fileSize":          size,
+			"appEventAssetType": assetType,
+		}
+		for key, value := range attrs {
+			created[key] = value
+		}
+
+		body := apiDocument{Data: apiResource{
+			Type:       typ,
+			Attributes: created,
+			Relationships: map[string]apiRelationship{
+				"appEventLocalization": toOne("appEventLocalizations", locID),
+			},
+		}}
+
+		var resp appEventAssetResponse
+
+		if err := c.send(ctx, http.MethodPost, "v1/"+typ, body, &resp); err != nil {
+			return "", nil, err
+		}
+
+		return resp.Data.ID, resp.Data.Attributes.UploadOperations, nil
+	}
+
+	commit := func(id string, checksum string) error {
+		ctx.Log.WithFields(log.Fields{
+			"id": id,
+		}).Debug("commit in-app event asset")
+
+		body := apiDocument{Data: apiResource{
+			Type: typ,
+			ID:   id,
+			Attributes: map[string]interface{}{
+				"uploaded": true,
+			},
+		}}
+
+		return c.send(ctx, http.MethodPatch, "v1/"+typ+"/"+id, body, nil)
+	}
+
+	return c.uploadFile(ctx, path, prepare, create, commit)
+}
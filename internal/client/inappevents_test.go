@@ -0,0 +1,205 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test UpdateInAppEvents
+
+func TestUpdateInAppEvents_HappyCreate(t *testing.T) {
+	t.Parallel()
+
+	card := newTestAsset(t, "card.png")
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"event1","attributes":{"referenceName":"Summer","eventState":"DRAFT"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"loc1"}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"shot1","attributes":{"uploadOperations":[]}}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppEvents(ctx.Context, testID, config.InAppEvents{
+		"Summer": {
+			Badge:       config.InAppEventBadgeChallenge,
+			Purpose:     config.InAppEventPurposeKeepActiveUsersInformed,
+			Priority:    config.InAppEventPriorityHigh,
+			DeepLink:    "app://events/summer",
+			Territories: []string{"USA"},
+			EventStart:  "2021-06-07T00:00:00Z",
+			EventEnd:    "2021-06-21T00:00:00Z",
+			Localizations: config.InAppEventLocalizations{
+				"en-US": {
+					Name:             "Summer Challenge",
+					ShortDescription: "Race all summer",
+					LongDescription:  "Race your friends all summer long",
+					EventCard: &config.InAppEventMedia{
+						Image: &config.File{Path: card.Name},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateInAppEvents_HappyUpdate(t *testing.T) {
+	t.Parallel()
+
+	card := newTestAsset(t, "card.png")
+	details := newTestAsset(t, "details.mp4")
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"event1","attributes":{"referenceName":"Summer","eventState":"REJECTED"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[
+				{"id":"shot1","attributes":{"fileName":"old.png","appEventAssetType":"EVENT_CARD"}},
+				{"id":"shot2","attributes":{"fileName":"card.png","appEventAssetType":"EVENT_DETAILS_PAGE"}}
+			]}`,
+		},
+		response{
+			StatusCode: http.StatusNoContent,
+		},
+		response{
+			RawResponse: `{"data":{"id":"shot3","attributes":{"uploadOperations":[]}}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"clip1","attributes":{"fileName":"details.mp4","appEventAssetType":"EVENT_DETAILS_PAGE"}}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppEvents(ctx.Context, testID, config.InAppEvents{
+		"Summer": {
+			Badge:        config.InAppEventBadgeChallenge,
+			Territories:  []string{"USA"},
+			PublishStart: "2021-06-01T00:00:00Z",
+			EventStart:   "2021-06-07T00:00:00Z",
+			EventEnd:     "2021-06-21T00:00:00Z",
+			Localizations: config.InAppEventLocalizations{
+				"en-US": {
+					Name: "Summer Challenge",
+					EventCard: &config.InAppEventMedia{
+						Image: &config.File{Path: card.Name},
+					},
+					EventDetailsPage: &config.InAppEventMedia{
+						Video: &config.Preview{
+							File:                 config.File{Path: details.Name},
+							PreviewFrameTimeCode: "00:00:05:00",
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateInAppEvents_HappySkipSubmitted(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"event1","attributes":{"referenceName":"Summer","eventState":"PUBLISHED"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppEvents(ctx.Context, testID, config.InAppEvents{
+		"Summer": {Badge: config.InAppEventBadgeChallenge},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateInAppEvents_ErrSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppEvents(ctx.Context, testID, config.InAppEvents{
+		"Summer": {
+			Badge:      config.InAppEventBadgeChallenge,
+			EventStart: "next week",
+			EventEnd:   "2021-06-21T00:00:00Z",
+		},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "in-app event Summer has an invalid schedule")
+}
+
+func TestUpdateInAppEvents_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppEvents(ctx.Context, testID, config.InAppEvents{
+		"Summer": {},
+	})
+	assert.Error(t, err)
+}
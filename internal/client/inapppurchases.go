@@ -0,0 +1,319 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/parallel"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// inAppPurchasesLimit is the maximum number of in-app purchases App Store Connect returns in a single page.
+const inAppPurchasesLimit = 200
+
+// inAppPurchaseStateReadyToSubmit is the state of in-app purchases whose metadata is complete.
+const inAppPurchaseStateReadyToSubmit = "READY_TO_SUBMIT"
+
+type errInvalidInAppPurchaseType struct {
+	ProductID string
+	Type      string
+}
+
+func (e errInvalidInAppPurchaseType) Error() string {
+	return fmt.Sprintf("in-app purchase %s has an invalid type %q", e.ProductID, e.Type)
+}
+
+type errInAppPurchasePriceNotFound struct {
+	ProductID string
+	Territory string
+	Price     string
+}
+
+func (e errInAppPurchasePriceNotFound) Error() string {
+	return fmt.Sprintf("no price point found for price %s in territory %s of in-app purchase %s", e.Price, e.Territory, e.ProductID)
+}
+
+// inAppPurchase is an in-app purchase resource, which the App Store Connect API client does not model.
+type inAppPurchase struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name              string `json:"name,omitempty"`
+		ProductID         string `json:"productId,omitempty"`
+		InAppPurchaseType string `json:"inAppPurchaseType,omitempty"`
+		State             string `json:"state,omitempty"`
+		ReviewNote        string `json:"reviewNote,omitempty"`
+	} `json:"attributes"`
+}
+
+type inAppPurchasesResponse struct {
+	Data  []inAppPurchase        `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+type inAppPurchaseResponse struct {
+	Data inAppPurchase `json:"data"`
+}
+
+func (c *ascClient) UpdateInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) error {
+	existing, err := c.listInAppPurchases(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	var g = parallel.New(ctx.MaxProcesses)
+
+	for productID, iapConfig := range config {
+		productID := productID
+		iapConfig := iapConfig
+
+		g.Go(func() error {
+			iap, ok := existing[productID]
+			if !ok {
+				created, err := c.createInAppPurchase(ctx, appID, productID, iapConfig)
+				if err != nil {
+					return err
+				}
+
+				iap = *created
+			} else if err := c.updateInAppPurchase(ctx, iap.ID, iapConfig); err != nil {
+				return err
+			}
+
+			return c.updateInAppPurchaseDetails(ctx, iap.ID, productID, iapConfig)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ascClient) SubmitInAppPurchases(ctx *context.Context, appID string, config config.InAppPurchases) (int, error) {
+	existing, err := c.listInAppPurchases(ctx, appID)
+	if err != nil {
+		return 0, err
+	}
+
+	var submitted int
+
+	for productID := range config {
+		iap, ok := existing[productID]
+		if !ok || iap.Attributes.State != inAppPurchaseStateReadyToSubmit {
+			continue
+		}
+
+		ctx.Log.WithField("product", productID).Debug("submit in-app purchase")
+
+		body := apiDocument{Data: apiResource{
+			Type: "inAppPurchaseSubmissions",
+			Relationships: map[string]apiRelationship{
+				"inAppPurchaseV2": toOne("inAppPurchases", iap.ID),
+			},
+		}}
+
+		if err := c.send(ctx, http.MethodPost, "v1/inAppPurchaseSubmissions", body, nil); err != nil {
+			return submitted, err
+		}
+
+		submitted++
+	}
+
+	return submitted, nil
+}
+
+// listInAppPurchases returns the in-app purchases of the app keyed by product ID.
+func (c *ascClient) listInAppPurchases(ctx *context.Context, appID string) (map[string]inAppPurchase, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(inAppPurchasesLimit))
+
+	path := fmt.Sprintf("v1/apps/%s/inAppPurchasesV2?%s", appID, query.Encode())
+	iaps := make(map[string]inAppPurchase)
+
+	for path != "" {
+		var resp inAppPurchasesResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, iap := range resp.Data {
+			iaps[iap.Attributes.ProductID] = iap
+		}
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return iaps, nil
+}
+
+func (c *ascClient) createInAppPurchase(ctx *context.Context, appID string, productID string, config config.InAppPurchase) (*inAppPurchase, error) {
+	typ := config.Type.APIValue()
+	if typ == nil {
+		return nil, errInvalidInAppPurchaseType{ProductID: productID, Type: string(config.Type)}
+	}
+
+	ctx.Log.WithField("product", productID).Debug("create in-app purchase")
+
+	body := apiDocument{Data: apiResource{
+		Type: "inAppPurchases",
+		Attributes: map[string]interface{}{
+			"name":              config.ReferenceName,
+			"productId":         productID,
+			"inAppPurchaseType": *typ,
+			"reviewNote":        config.ReviewNotes,
+		},
+		Relationships: map[string]apiRelationship{
+			"app": toOne("apps", appID),
+		},
+	}}
+
+	var resp inAppPurchaseResponse
+
+	if err := c.send(ctx, http.MethodPost, "v2/inAppPurchases", body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Data, nil
+}
+
+func (c *ascClient) updateInAppPurchase(ctx *context.Context, iapID string, config config.InAppPurchase) error {
+	body := apiDocument{Data: apiResource{
+		Type: "inAppPurchases",
+		ID:   iapID,
+		Attributes: map[string]interface{}{
+			"name":       config.ReferenceName,
+			"reviewNote": config.ReviewNotes,
+		},
+	}}
+
+	return c.send(ctx, http.MethodPatch, "v2/inAppPurchases/"+iapID, body, nil)
+}
+
+// updateInAppPurchaseDetails updates the localizations, price and review screenshot of an in-app purchase.
+func (c *ascClient) updateInAppPurchaseDetails(ctx *context.Context, iapID string, productID string, config config.InAppPurchase) error {
+	if err := c.updateInAppPurchaseLocalizations(ctx, iapID, config.Localizations); err != nil {
+		return err
+	}
+
+	if config.Price != "" {
+		if err := c.updateInAppPurchasePrice(ctx, iapID, productID, config); err != nil {
+			return err
+		}
+	}
+
+	if config.ReviewScreenshot != nil {
+		if err := c.uploadInAppPurchaseReviewScreenshot(ctx, iapID, *config.ReviewScreenshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ascClient) updateInAppPurchaseLocalizations(ctx *context.Context, iapID string, config config.InAppPurchaseLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+	for locale, locConfig := range config {
+		attrs[locale] = map[string]interface{}{
+			"name":        locConfig.Name,
+			"description": locConfig.Description,
+		}
+	}
+
+	_, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v2/inAppPurchases/%s/inAppPurchaseLocalizations", iapID),
+		Type:         "inAppPurchaseLocalizations",
+		Relationship: "inAppPurchaseV2",
+		Product:      toOne("inAppPurchases", iapID),
+	}, attrs)
+
+	return err
+}
+
+// updateInAppPurchasePrice replaces the price schedule of an in-app purchase with the price point of the customer
+// price in the base territory, from which App Store Connect derives prices in other territories.
+func (c *ascClient) updateInAppPurchasePrice(ctx *context.Context, iapID string, productID string, config config.InAppPurchase) error {
+	territory := config.PriceBaseTerritory()
+
+	query := url.Values{}
+	query.Set("filter[territory]", territory)
+	query.Set("limit", strconv.Itoa(inAppPurchasesLimit))
+
+	path := fmt.Sprintf("v2/inAppPurchases/%s/pricePoints?%s", iapID, query.Encode())
+
+	pricePointID, err := c.findPricePointByCustomerPrice(ctx, path, config.Price)
+	if err != nil {
+		return err
+	}
+
+	if pricePointID == "" {
+		return errInAppPurchasePriceNotFound{ProductID: productID, Territory: territory, Price: config.Price}
+	}
+
+	ctx.Log.WithFields(log.Fields{
+		"product":       productID,
+		"baseTerritory": territory,
+		"price":         config.Price,
+	}).Debug("update in-app purchase price")
+
+	// The manual price is created inline, and is referred to by a temporary ID
+	body := apiDocument{
+		Data: apiResource{
+			Type: "inAppPurchasePriceSchedules",
+			Relationships: map[string]apiRelationship{
+				"inAppPurchase": toOne("inAppPurchases", iapID),
+				"baseTerritory": toOne("territories", territory),
+				"manualPrices":  toMany("inAppPurchasePrices", "${price}"),
+			},
+		},
+		Included: []apiResource{
+			{
+				Type: "inAppPurchasePrices",
+				ID:   "${price}",
+				Attributes: map[string]interface{}{
+					"startDate": nil,
+				},
+				Relationships: map[string]apiRelationship{
+					"inAppPurchaseV2":         toOne("inAppPurchases", iapID),
+					"inAppPurchasePricePoint": toOne("inAppPurchasePricePoints", pricePointID),
+				},
+			},
+		},
+	}
+
+	return c.send(ctx, http.MethodPost, "v1/inAppPurchasePriceSchedules", body, nil)
+}
+
+func (c *ascClient) uploadInAppPurchaseReviewScreenshot(ctx *context.Context, iapID string, config config.File) error {
+	return c.uploadProductReviewScreenshot(ctx, productResource{
+		Path:         fmt.Sprintf("v2/inAppPurchases/%s/appStoreReviewScreenshot", iapID),
+		Type:         "inAppPurchaseAppStoreReviewScreenshots",
+		Relationship: "inAppPurchaseV2",
+		Product:      toOne("inAppPurchases", iapID),
+	}, config)
+}
@@ -0,0 +1,290 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test UpdateInAppPurchases
+
+func TestUpdateInAppPurchases_HappyCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"iap1","attributes":{"productId":"com.app.coins"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {
+			ReferenceName: "Coins",
+			Type:          config.InAppPurchaseTypeConsumable,
+			Localizations: config.InAppPurchaseLocalizations{
+				"en-US": {Name: "Coins"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateInAppPurchases_HappyUpdate(t *testing.T) {
+	t.Parallel()
+
+	asset := newTestAsset(t, "coins.png")
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v2/inAppPurchases/iap1/pricePoints?cursor=2")
+	assert.NoError(t, err)
+
+	ctx.SetResponses(
+		response{
+			RawResponse: `{"data":[{"id":"iap1","attributes":{"productId":"com.app.coins","state":"MISSING_METADATA"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp0","attributes":{"customerPrice":"0.0"}}],"links":{"self":"","next":"` + next.String() + `"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp1","attributes":{"customerPrice":"1.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"shot1","attributes":{"fileName":"old.png","sourceFileChecksum":"OLD"}}}`,
+		},
+		response{
+			StatusCode: http.StatusNoContent,
+		},
+		response{
+			RawResponse: `{"data":{"id":"shot2","attributes":{"uploadOperations":[]}}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+
+	err = client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {
+			ReferenceName: "Coins",
+			Type:          config.InAppPurchaseTypeConsumable,
+			Price:         "1.99",
+			BaseTerritory: "GBR",
+			Localizations: config.InAppPurchaseLocalizations{
+				"en-US": {Name: "Coins", Description: "A pile of coins"},
+				"ja":    {Name: "コイン"},
+			},
+			ReviewScreenshot: &config.File{Path: asset.Name},
+			ReviewNotes:      "TEST",
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateInAppPurchases_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{})
+	assert.Error(t, err)
+}
+
+func TestUpdateInAppPurchases_ErrInvalidType(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {ReferenceName: "Coins"},
+	})
+	assert.EqualError(t, err, errInvalidInAppPurchaseType{ProductID: "com.app.coins"}.Error())
+}
+
+func TestUpdateInAppPurchases_ErrPricePointNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"iap1","attributes":{"productId":"com.app.coins"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp0","attributes":{"customerPrice":"0.0"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {ReferenceName: "Coins", Price: "87.99"},
+	})
+	assert.EqualError(t, err, errInAppPurchasePriceNotFound{ProductID: "com.app.coins", Territory: "USA", Price: "87.99"}.Error())
+}
+
+func TestUpdateInAppPurchases_ErrUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"iap1","attributes":{"productId":"com.app.coins"}}],"links":{"self":""}}`,
+		},
+		response{
+			StatusCode:  http.StatusConflict,
+			RawResponse: `{"errors":[{"code":"ENTITY_ERROR","status":"409","title":"TEST","detail":"TEST"}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {ReferenceName: "Coins"},
+	})
+	assert.Error(t, err)
+}
+
+// Test SubmitInAppPurchases
+
+func TestSubmitInAppPurchases_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{
+				"data": [
+					{"id": "iap1", "attributes": {"productId": "com.app.coins", "state": "READY_TO_SUBMIT"}},
+					{"id": "iap2", "attributes": {"productId": "com.app.gems", "state": "MISSING_METADATA"}},
+					{"id": "iap3", "attributes": {"productId": "com.app.unlisted", "state": "READY_TO_SUBMIT"}}
+				],
+				"links": {"self": ""}
+			}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	submitted, err := client.SubmitInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins":   {},
+		"com.app.gems":    {},
+		"com.app.missing": {},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+}
+
+func TestSubmitInAppPurchases_HappyPaged(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v1/apps/TEST/inAppPurchasesV2?cursor=2")
+	assert.NoError(t, err)
+
+	ctx.SetResponses(
+		response{
+			RawResponse: `{"data":[{"id":"iap1","attributes":{"productId":"com.app.coins","state":"READY_TO_SUBMIT"}}],"links":{"self":"","next":"` + next.String() + `"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"iap2","attributes":{"productId":"com.app.gems","state":"READY_TO_SUBMIT"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+
+	submitted, err := client.SubmitInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {},
+		"com.app.gems":  {},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, submitted)
+	assert.Equal(t, 4, ctx.CurrentResponseIndex)
+}
+
+func TestSubmitInAppPurchases_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"iap1","attributes":{"productId":"com.app.coins","state":"READY_TO_SUBMIT"}}],"links":{"self":""}}`,
+		},
+		response{
+			StatusCode:  http.StatusConflict,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	submitted, err := client.SubmitInAppPurchases(ctx.Context, testID, config.InAppPurchases{
+		"com.app.coins": {},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, submitted)
+}
@@ -0,0 +1,138 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// appPricePointsLimit is the maximum number of price points App Store Connect returns in a single page.
+const appPricePointsLimit = 200
+
+// appPriceDateFormat is the format of the start and end dates of app prices.
+const appPriceDateFormat = "2006-01-02"
+
+type errAppPricePointNotFound struct {
+	Territory string
+	Price     string
+}
+
+func (e errAppPricePointNotFound) Error() string {
+	return fmt.Sprintf("no price point found for price %s in territory %s", e.Price, e.Territory)
+}
+
+// updateAppPrices replaces the price schedule of the app with the prices in the configuration. App Store Connect
+// sets the price in territories without prices of their own from the prices in the base territory.
+func (c *ascClient) updateAppPrices(ctx *context.Context, appID string, availability config.Availability) error {
+	prices := availability.PricesByTerritory()
+
+	territories := make([]string, 0, len(prices))
+	for territory := range prices {
+		territories = append(territories, territory)
+	}
+
+	sort.Strings(territories)
+
+	var ids []string
+
+	var included []apiResource
+
+	for _, territory := range territories {
+		for _, price := range prices[territory] {
+			pricePointID, err := c.findAppPricePoint(ctx, appID, territory, price.Price)
+			if err != nil {
+				return err
+			}
+
+			attrs := map[string]interface{}{
+				"startDate": nil,
+				"endDate":   nil,
+			}
+
+			if price.StartDate != nil {
+				attrs["startDate"] = price.StartDate.UTC().Format(appPriceDateFormat)
+			}
+
+			if price.EndDate != nil {
+				attrs["endDate"] = price.EndDate.UTC().Format(appPriceDateFormat)
+			}
+
+			// Manual prices are created inline, and are referred to by temporary IDs
+			id := fmt.Sprintf("${price%d}", len(ids))
+			ids = append(ids, id)
+			included = append(included, apiResource{
+				Type:       "appPrices",
+				ID:         id,
+				Attributes: attrs,
+				Relationships: map[string]apiRelationship{
+					"appPricePoint": toOne("appPricePoints", pricePointID),
+				},
+			})
+		}
+	}
+
+	ctx.Log.WithFields(log.Fields{
+		"baseTerritory": availability.PriceBaseTerritory(),
+		"prices":        len(ids),
+	}).Debug("update app prices")
+
+	body := apiDocument{
+		Data: apiResource{
+			Type: "appPriceSchedules",
+			Relationships: map[string]apiRelationship{
+				"app":           toOne("apps", appID),
+				"baseTerritory": toOne("territories", availability.PriceBaseTerritory()),
+				"manualPrices":  toMany("appPrices", ids...),
+			},
+		},
+		Included: included,
+	}
+
+	return c.send(ctx, http.MethodPost, "v1/appPriceSchedules", body, nil)
+}
+
+// findAppPricePoint returns the ID of the price point of the app with the customer price in the territory.
+func (c *ascClient) findAppPricePoint(ctx *context.Context, appID string, territory string, price string) (string, error) {
+	query := url.Values{}
+	query.Set("filter[territory]", territory)
+	query.Set("limit", strconv.Itoa(appPricePointsLimit))
+
+	path := fmt.Sprintf("v1/apps/%s/appPricePoints?%s", appID, query.Encode())
+
+	pricePointID, err := c.findPricePointByCustomerPrice(ctx, path, price)
+	if err != nil {
+		return "", err
+	}
+
+	if pricePointID == "" {
+		return "", errAppPricePointNotFound{Territory: territory, Price: price}
+	}
+
+	return pricePointID, nil
+}
@@ -0,0 +1,145 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test updateAppPrices
+
+func TestUpdateApp_Prices(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	ctx, client := newTestContext(
+		response{
+			Response: asc.TerritoriesResponse{
+				Data: []asc.Territory{
+					{ID: "USA"},
+					{ID: "GBR"},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"gbr1","attributes":{"customerPrice":"0.99"}},{"id":"gbr2","attributes":{"customerPrice":"1.49"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"usa1","attributes":{"customerPrice":"0.99"}},{"id":"usa2","attributes":{"customerPrice":"1.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"usa1","attributes":{"customerPrice":"0.99"}},{"id":"usa2","attributes":{"customerPrice":"1.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			StatusCode:  http.StatusCreated,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateApp(ctx.Context, testID, testID, testID, config.App{
+		Availability: &config.Availability{
+			Territories: []string{"USA", "GBR"},
+			Prices: []config.AppPrice{
+				{Price: "0.99"},
+				{Price: "1.99", StartDate: &start},
+				{Territory: "GBR", Price: "1.49"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateApp_PricesSkipped(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	ctx.Context.SkipUpdatePricing = true
+
+	err := client.UpdateApp(ctx.Context, testID, testID, testID, config.App{
+		Availability: &config.Availability{
+			Prices: []config.AppPrice{{Price: "0.99"}},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateApp_ErrAppPricePointNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"usa1","attributes":{"customerPrice":"0.99"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateApp(ctx.Context, testID, testID, testID, config.App{
+		Availability: &config.Availability{
+			Prices: []config.AppPrice{{Price: "2.99"}},
+		},
+	})
+	assert.EqualError(t, err, errAppPricePointNotFound{Territory: "USA", Price: "2.99"}.Error())
+}
+
+func TestUpdateApp_ErrAppPriceSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"usa1","attributes":{"customerPrice":"0.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			StatusCode:  http.StatusConflict,
+			RawResponse: `{"errors":[{"code":"ENTITY_ERROR","status":"409","title":"TEST","detail":"TEST"}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateApp(ctx.Context, testID, testID, testID, config.App{
+		Availability: &config.Availability{
+			Prices: []config.AppPrice{{Price: "0.99"}},
+		},
+	})
+	assert.Error(t, err)
+}
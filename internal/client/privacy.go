@@ -0,0 +1,109 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// PrivacyDetails are the privacy details of an app that the App Store Connect API can read, which are published
+// alongside the App Privacy details of the app.
+type PrivacyDetails struct {
+	// Version string of the App Store version that is ready for sale, or an empty string if there is none.
+	Version string
+	// Whether the version that is ready for sale declares that it uses the advertising identifier.
+	UsesIDFA bool
+	// Privacy policy URLs of the app keyed by locale.
+	PrivacyPolicyURLs map[string]string
+}
+
+func (c *ascClient) GetPrivacyDetails(ctx *context.Context, appID string, platform config.Platform) (*PrivacyDetails, error) {
+	var details = PrivacyDetails{PrivacyPolicyURLs: make(map[string]string)}
+
+	query := asc.ListAppStoreVersionsQuery{
+		FilterAppStoreState: []string{string(asc.AppStoreVersionStateReadyForSale)},
+		Limit:               1,
+	}
+
+	if p := platform.APIValue(); p != nil {
+		query.FilterPlatform = []string{string(*p)}
+	}
+
+	versionsResp, _, err := c.client.Apps.ListAppStoreVersionsForApp(ctx, appID, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versionsResp.Data) > 0 && versionsResp.Data[0].Attributes != nil {
+		attrs := versionsResp.Data[0].Attributes
+		if attrs.VersionString != nil {
+			details.Version = *attrs.VersionString
+		}
+
+		details.UsesIDFA = attrs.UsesIDFA != nil && *attrs.UsesIDFA
+	}
+
+	infosResp, _, err := c.client.Apps.ListAppInfosForApp(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the app info of the version that is ready for sale over the one being edited
+	var infoID string
+
+	for _, info := range infosResp.Data {
+		if infoID == "" {
+			infoID = info.ID
+		}
+
+		if info.Attributes != nil && info.Attributes.AppStoreState != nil && *info.Attributes.AppStoreState == asc.AppStoreVersionStateReadyForSale {
+			infoID = info.ID
+
+			break
+		}
+	}
+
+	if infoID == "" {
+		return &details, nil
+	}
+
+	locsResp, _, err := c.client.Apps.ListAppInfoLocalizationsForAppInfo(ctx, infoID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, loc := range locsResp.Data {
+		if loc.Attributes == nil || loc.Attributes.Locale == nil {
+			continue
+		}
+
+		var url string
+		if loc.Attributes.PrivacyPolicyURL != nil {
+			url = *loc.Attributes.PrivacyPolicyURL
+		}
+
+		details.PrivacyPolicyURLs[*loc.Attributes.Locale] = url
+	}
+
+	return &details, nil
+}
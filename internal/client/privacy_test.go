@@ -0,0 +1,131 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test GetPrivacyDetails
+
+func TestGetPrivacyDetails_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"version1","attributes":{"versionString":"1.0","usesIdfa":true}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"info1","attributes":{"appStoreState":"PREPARE_FOR_SUBMISSION"}},{"id":"info2","attributes":{"appStoreState":"READY_FOR_SALE"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US","privacyPolicyUrl":"https://example.com/privacy"}},{"id":"loc2","attributes":{"locale":"ja"}}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	details, err := client.GetPrivacyDetails(ctx.Context, testID, config.PlatformiOS)
+	assert.NoError(t, err)
+	assert.Equal(t, &PrivacyDetails{
+		Version:  "1.0",
+		UsesIDFA: true,
+		PrivacyPolicyURLs: map[string]string{
+			"en-US": "https://example.com/privacy",
+			"ja":    "",
+		},
+	}, details)
+}
+
+func TestGetPrivacyDetails_HappyNoAppInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+	)
+	defer ctx.Close()
+
+	details, err := client.GetPrivacyDetails(ctx.Context, testID, config.PlatformiOS)
+	assert.NoError(t, err)
+	assert.Equal(t, &PrivacyDetails{PrivacyPolicyURLs: map[string]string{}}, details)
+}
+
+func TestGetPrivacyDetails_ErrVersions(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.GetPrivacyDetails(ctx.Context, testID, config.PlatformiOS)
+	assert.Error(t, err)
+}
+
+func TestGetPrivacyDetails_ErrAppInfos(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.GetPrivacyDetails(ctx.Context, testID, config.PlatformiOS)
+	assert.Error(t, err)
+}
+
+func TestGetPrivacyDetails_ErrLocalizations(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"info1"}]}`,
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.GetPrivacyDetails(ctx.Context, testID, config.PlatformiOS)
+	assert.Error(t, err)
+}
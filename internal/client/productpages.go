@@ -0,0 +1,391 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/parallel"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// productPageStatePrepareForSubmission is the state of custom product page versions and experiments that can
+// still be edited.
+const productPageStatePrepareForSubmission = "PREPARE_FOR_SUBMISSION"
+
+// productPageResource is a custom product page, product page optimization experiment or experiment treatment
+// resource, or one of their versions, which the App Store Connect API client does not model.
+type productPageResource struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name     string `json:"name,omitempty"`
+		Platform string `json:"platform,omitempty"`
+		State    string `json:"state,omitempty"`
+	} `json:"attributes"`
+}
+
+type productPageResourcesResponse struct {
+	Data  []productPageResource  `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+type productPageResourceResponse struct {
+	Data productPageResource `json:"data"`
+}
+
+func (c *ascClient) UpdateCustomProductPages(ctx *context.Context, appID string, config config.CustomProductPages) error {
+	existing, err := c.listProductPageResources(ctx, fmt.Sprintf("v1/apps/%s/appCustomProductPages", appID))
+	if err != nil {
+		return err
+	}
+
+	pages := make(map[string]string, len(existing))
+	for _, page := range existing {
+		pages[page.Attributes.Name] = page.ID
+	}
+
+	var g = parallel.New(ctx.MaxProcesses)
+
+	for name, pageConfig := range config {
+		name := name
+		pageConfig := pageConfig
+
+		g.Go(func() error {
+			pageID, ok := pages[name]
+			if !ok {
+				var err error
+				if pageID, err = c.createCustomProductPage(ctx, appID, name, pageConfig); err != nil {
+					return err
+				}
+			}
+
+			versionID, err := c.editableCustomProductPageVersion(ctx, pageID)
+			if err != nil {
+				return err
+			} else if versionID == "" {
+				ctx.Log.WithField("page", name).Warn("skipping custom product page with a version in review")
+
+				return nil
+			}
+
+			return c.updateCustomProductPageLocalizations(ctx, g, versionID, pageConfig.Localizations)
+		})
+	}
+
+	return g.Wait()
+}
+
+// listProductPageResources returns the resources at path, following all pages of results.
+func (c *ascClient) listProductPageResources(ctx *context.Context, path string) ([]productPageResource, error) {
+	var resources []productPageResource
+
+	for path != "" {
+		var resp productPageResourcesResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resp.Data...)
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return resources, nil
+}
+
+// createCustomProductPage creates a custom product page along with its first version, as App Store Connect
+// does not accept pages without a version and localizations.
+func (c *ascClient) createCustomProductPage(ctx *context.Context, appID string, name string, config config.CustomProductPage) (string, error) {
+	ctx.Log.WithField("page", name).Debug("create custom product page")
+
+	locales := make([]string, 0, len(config.Localizations))
+	for locale := range config.Localizations {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	localizations := make([]string, len(locales))
+	included := make([]apiResource, 0, len(locales)+1)
+
+	for i, locale := range locales {
+		localizations[i] = "${localization-" + locale + "}"
+		included = append(included, apiResource{
+			Type: "appCustomProductPageLocalizations",
+			ID:   localizations[i],
+			Attributes: map[string]interface{}{
+				"locale": locale,
+			},
+		})
+	}
+
+	included = append(included, apiResource{
+		Type: "appCustomProductPageVersions",
+		ID:   "${version}",
+		Relationships: map[string]apiRelationship{
+			"appCustomProductPageLocalizations": toMany("appCustomProductPageLocalizations", localizations...),
+		},
+	})
+
+	body := apiDocument{
+		Data: apiResource{
+			Type: "appCustomProductPages",
+			Attributes: map[string]interface{}{
+				"name": name,
+			},
+			Relationships: map[string]apiRelationship{
+				"app":                          toOne("apps", appID),
+				"appCustomProductPageVersions": toMany("appCustomProductPageVersions", "${version}"),
+			},
+		},
+		Included: included,
+	}
+
+	var resp productPageResourceResponse
+
+	if err := c.send(ctx, http.MethodPost, "v1/appCustomProductPages", body, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Data.ID, nil
+}
+
+// editableCustomProductPageVersion returns the ID of the version of a custom product page that can be edited,
+// creating one if the page only has approved versions, or an empty string if a version is being reviewed.
+func (c *ascClient) editableCustomProductPageVersion(ctx *context.Context, pageID string) (string, error) {
+	versions, err := c.listProductPageResources(ctx, fmt.Sprintf("v1/appCustomProductPages/%s/appCustomProductPageVersions", pageID))
+	if err != nil {
+		return "", err
+	}
+
+	for _, version := range versions {
+		switch version.Attributes.State {
+		case productPageStatePrepareForSubmission, "REJECTED":
+			return version.ID, nil
+		case "READY_FOR_REVIEW", "WAITING_FOR_REVIEW", "IN_REVIEW":
+			return "", nil
+		}
+	}
+
+	body := apiDocument{Data: apiResource{
+		Type: "appCustomProductPageVersions",
+		Relationships: map[string]apiRelationship{
+			"appCustomProductPage": toOne("appCustomProductPages", pageID),
+		},
+	}}
+
+	var resp productPageResourceResponse
+
+	if err := c.send(ctx, http.MethodPost, "v1/appCustomProductPageVersions", body, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Data.ID, nil
+}
+
+func (c *ascClient) updateCustomProductPageLocalizations(ctx *context.Context, g parallel.Group, versionID string, config config.CustomProductPageLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+
+	for locale, locConfig := range config {
+		attrs[locale] = map[string]interface{}{}
+		if locConfig.PromotionalText != "" {
+			attrs[locale]["promotionalText"] = locConfig.PromotionalText
+		}
+	}
+
+	ids, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v1/appCustomProductPageVersions/%s/appCustomProductPageLocalizations", versionID),
+		Type:         "appCustomProductPageLocalizations",
+		Relationship: "appCustomProductPageVersion",
+		Product:      toOne("appCustomProductPageVersions", versionID),
+	}, attrs)
+	if err != nil {
+		return err
+	}
+
+	for locale, locConfig := range config {
+		locale := locale
+		locConfig := locConfig
+
+		g.Go(func() error {
+			return c.updateLocalizationAssets(ctx, g, "appCustomProductPageLocalizations", "appCustomProductPageLocalization", ids[locale], locConfig.PreviewSets, locConfig.ScreenshotSets)
+		})
+	}
+
+	return nil
+}
+
+func (c *ascClient) UpdateExperiments(ctx *context.Context, appID string, platform config.Platform, config config.Experiments) error {
+	existing, err := c.listProductPageResources(ctx, fmt.Sprintf("v1/apps/%s/appStoreVersionExperimentsV2", appID))
+	if err != nil {
+		return err
+	}
+
+	var platformValue string
+	if p := platform.APIValue(); p != nil {
+		platformValue = string(*p)
+	}
+
+	experiments := make(map[string]productPageResource, len(existing))
+
+	for _, experiment := range existing {
+		if experiment.Attributes.Platform == platformValue {
+			experiments[experiment.Attributes.Name] = experiment
+		}
+	}
+
+	var g = parallel.New(ctx.MaxProcesses)
+
+	for name, experimentConfig := range config {
+		name := name
+		experimentConfig := experimentConfig
+
+		g.Go(func() error {
+			attrs := map[string]interface{}{
+				"trafficProportion": experimentConfig.TrafficProportion,
+			}
+
+			experiment, ok := experiments[name]
+			if !ok {
+				attrs["name"] = name
+				attrs["platform"] = platformValue
+
+				body := apiDocument{Data: apiResource{
+					Type:       "appStoreVersionExperiments",
+					Attributes: attrs,
+					Relationships: map[string]apiRelationship{
+						"app": toOne("apps", appID),
+					},
+				}}
+
+				var resp productPageResourceResponse
+
+				if err := c.send(ctx, http.MethodPost, "v2/appStoreVersionExperiments", body, &resp); err != nil {
+					return err
+				}
+
+				experiment = resp.Data
+			} else if experiment.Attributes.State != productPageStatePrepareForSubmission {
+				ctx.Log.WithField("experiment", name).Warn("skipping experiment that has already been submitted")
+
+				return nil
+			} else {
+				body := apiDocument{Data: apiResource{Type: "appStoreVersionExperiments", ID: experiment.ID, Attributes: attrs}}
+
+				if err := c.send(ctx, http.MethodPatch, "v2/appStoreVersionExperiments/"+experiment.ID, body, nil); err != nil {
+					return err
+				}
+			}
+
+			return c.updateExperimentTreatments(ctx, g, experiment.ID, experimentConfig.Treatments)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ascClient) updateExperimentTreatments(ctx *context.Context, g parallel.Group, experimentID string, config config.ExperimentTreatments) error {
+	existing, err := c.listProductPageResources(ctx, fmt.Sprintf("v2/appStoreVersionExperiments/%s/appStoreVersionExperimentTreatments", experimentID))
+	if err != nil {
+		return err
+	}
+
+	treatments := make(map[string]string, len(existing))
+	for _, treatment := range existing {
+		treatments[treatment.Attributes.Name] = treatment.ID
+	}
+
+	for name, treatmentConfig := range config {
+		attrs := map[string]interface{}{
+			"name": name,
+		}
+		if treatmentConfig.AppIconName != "" {
+			attrs["appIconName"] = treatmentConfig.AppIconName
+		}
+
+		treatmentID, ok := treatments[name]
+		if ok {
+			body := apiDocument{Data: apiResource{Type: "appStoreVersionExperimentTreatments", ID: treatmentID, Attributes: attrs}}
+
+			if err := c.send(ctx, http.MethodPatch, "v1/appStoreVersionExperimentTreatments/"+treatmentID, body, nil); err != nil {
+				return err
+			}
+		} else {
+			body := apiDocument{Data: apiResource{
+				Type:       "appStoreVersionExperimentTreatments",
+				Attributes: attrs,
+				Relationships: map[string]apiRelationship{
+					"appStoreVersionExperimentV2": toOne("appStoreVersionExperiments", experimentID),
+				},
+			}}
+
+			var resp productPageResourceResponse
+
+			if err := c.send(ctx, http.MethodPost, "v1/appStoreVersionExperimentTreatments", body, &resp); err != nil {
+				return err
+			}
+
+			treatmentID = resp.Data.ID
+		}
+
+		if err := c.updateExperimentTreatmentLocalizations(ctx, g, treatmentID, treatmentConfig.Localizations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ascClient) updateExperimentTreatmentLocalizations(ctx *context.Context, g parallel.Group, treatmentID string, config config.ExperimentTreatmentLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+	for locale := range config {
+		attrs[locale] = map[string]interface{}{}
+	}
+
+	ids, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v1/appStoreVersionExperimentTreatments/%s/appStoreVersionExperimentTreatmentLocalizations", treatmentID),
+		Type:         "appStoreVersionExperimentTreatmentLocalizations",
+		Relationship: "appStoreVersionExperimentTreatment",
+		Product:      toOne("appStoreVersionExperimentTreatments", treatmentID),
+	}, attrs)
+	if err != nil {
+		return err
+	}
+
+	for locale, locConfig := range config {
+		locale := locale
+		locConfig := locConfig
+
+		g.Go(func() error {
+			return c.updateLocalizationAssets(ctx, g, "appStoreVersionExperimentTreatmentLocalizations", "appStoreVersionExperimentTreatmentLocalization", ids[locale], locConfig.PreviewSets, locConfig.ScreenshotSets)
+		})
+	}
+
+	return nil
+}
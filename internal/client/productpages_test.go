@@ -0,0 +1,298 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test UpdateCustomProductPages
+
+func TestUpdateCustomProductPages_HappyCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"page1","attributes":{"name":"Summer"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"version1","attributes":{"state":"PREPARE_FOR_SUBMISSION"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"set1","attributes":{"screenshotDisplayType":"APP_IPHONE_65"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateCustomProductPages(ctx.Context, testID, config.CustomProductPages{
+		"Summer": {
+			Localizations: config.CustomProductPageLocalizations{
+				"en-US": {
+					PromotionalText: "Get ready for summer",
+					ScreenshotSets: config.ScreenshotSets{
+						config.ScreenshotTypeiPhone65: {},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateCustomProductPages_HappyNewVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"page1","attributes":{"name":"Summer"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"version1","attributes":{"state":"APPROVED"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"version2","attributes":{"state":"PREPARE_FOR_SUBMISSION"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"loc1"}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateCustomProductPages(ctx.Context, testID, config.CustomProductPages{
+		"Summer": {
+			Localizations: config.CustomProductPageLocalizations{
+				"en-US": {PromotionalText: "Get ready for summer"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateCustomProductPages_HappySkipInReview(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"page1","attributes":{"name":"Summer"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"version1","attributes":{"state":"WAITING_FOR_REVIEW"}}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateCustomProductPages(ctx.Context, testID, config.CustomProductPages{
+		"Summer": {
+			Localizations: config.CustomProductPageLocalizations{
+				"en-US": {PromotionalText: "Get ready for summer"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateCustomProductPages_HappyPaged(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v1/apps/TEST/appCustomProductPages?cursor=2")
+	assert.NoError(t, err)
+
+	// The page is only found on the second page of results, so it is not created again
+	ctx.SetResponses(
+		response{
+			RawResponse: `{"data":[{"id":"page0","attributes":{"name":"Winter"}}],"links":{"self":"","next":"` + next.String() + `"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"page1","attributes":{"name":"Summer"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"version1","attributes":{"state":"WAITING_FOR_REVIEW"}}]}`,
+		},
+	)
+
+	err = client.UpdateCustomProductPages(ctx.Context, testID, config.CustomProductPages{
+		"Summer": {
+			Localizations: config.CustomProductPageLocalizations{
+				"en-US": {PromotionalText: "Get ready for summer"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ctx.CurrentResponseIndex)
+}
+
+func TestUpdateCustomProductPages_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateCustomProductPages(ctx.Context, testID, config.CustomProductPages{
+		"Summer": {},
+	})
+	assert.Error(t, err)
+}
+
+// Test UpdateExperiments
+
+func TestUpdateExperiments_HappyCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"exp0","attributes":{"name":"Icon Test","platform":"MAC_OS","state":"PREPARE_FOR_SUBMISSION"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"exp1","attributes":{"name":"Icon Test","platform":"IOS","state":"PREPARE_FOR_SUBMISSION"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"treatment1","attributes":{"name":"Dark Icon"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"loc1"}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateExperiments(ctx.Context, testID, config.PlatformiOS, config.Experiments{
+		"Icon Test": {
+			TrafficProportion: 30,
+			Treatments: config.ExperimentTreatments{
+				"Dark Icon": {
+					AppIconName: "AppIconDark",
+					Localizations: config.ExperimentTreatmentLocalizations{
+						"en-US": {},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateExperiments_HappyUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"exp1","attributes":{"name":"Icon Test","platform":"IOS","state":"PREPARE_FOR_SUBMISSION"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"treatment1","attributes":{"name":"Dark Icon"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US"}}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateExperiments(ctx.Context, testID, config.PlatformiOS, config.Experiments{
+		"Icon Test": {
+			TrafficProportion: 50,
+			Treatments: config.ExperimentTreatments{
+				"Dark Icon": {
+					Localizations: config.ExperimentTreatmentLocalizations{
+						"en-US": {},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateExperiments_HappySkipSubmitted(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"exp1","attributes":{"name":"Icon Test","platform":"IOS","state":"IN_REVIEW"}}]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateExperiments(ctx.Context, testID, config.PlatformiOS, config.Experiments{
+		"Icon Test": {TrafficProportion: 50},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateExperiments_ErrCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			StatusCode:  http.StatusConflict,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateExperiments(ctx.Context, testID, config.PlatformiOS, config.Experiments{
+		"Icon Test": {TrafficProportion: 50},
+	})
+	assert.Error(t, err)
+}
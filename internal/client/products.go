@@ -0,0 +1,265 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// productResource refers to the resources of an in-app purchase, subscription or product page, such as its
+// localizations or review screenshot, which belong to the product through a relationship.
+type productResource struct {
+	// Path of the product's existing resources
+	Path string
+	// Type of the resources, which is also their path in version 1 of the API
+	Type string
+	// Name of the relationship from the resources to the product
+	Relationship string
+	// Product the resources belong to
+	Product apiRelationship
+}
+
+type apiCreatedResponse struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+type localizationsResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Locale string `json:"locale"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+type pricePointsResponse struct {
+	Data  []pricePoint           `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+type pricePoint struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		CustomerPrice string `json:"customerPrice"`
+	} `json:"attributes"`
+}
+
+type reviewScreenshotResponse struct {
+	Data *struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			FileName           string                `json:"fileName"`
+			SourceFileChecksum string                `json:"sourceFileChecksum"`
+			UploadOperations   []asc.UploadOperation `json:"uploadOperations"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// updateProductLocalizations updates the localizations of a product with the given attributes for each locale,
+// creating the ones that do not exist yet, and returns the IDs of the configured localizations keyed by locale.
+// Localizations without attributes to update are left as they are.
+func (c *ascClient) updateProductLocalizations(ctx *context.Context, res productResource, config map[string]map[string]interface{}) (map[string]string, error) {
+	var resp localizationsResponse
+
+	if err := c.send(ctx, http.MethodGet, res.Path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]string)
+	for _, loc := range resp.Data {
+		found[loc.Attributes.Locale] = loc.ID
+	}
+
+	ids := make(map[string]string, len(config))
+
+	for locale, attrs := range config {
+		if id, ok := found[locale]; ok {
+			ids[locale] = id
+
+			if len(attrs) == 0 {
+				continue
+			}
+
+			body := apiDocument{Data: apiResource{Type: res.Type, ID: id, Attributes: attrs}}
+
+			if err := c.send(ctx, http.MethodPatch, "v1/"+res.Type+"/"+id, body, nil); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		created := map[string]interface{}{"locale": locale}
+		for key, value := range attrs {
+			created[key] = value
+		}
+
+		body := apiDocument{Data: apiResource{
+			Type:       res.Type,
+			Attributes: created,
+			Relationships: map[string]apiRelationship{
+				res.Relationship: res.Product,
+			},
+		}}
+
+		var createdResp apiCreatedResponse
+
+		if err := c.send(ctx, http.MethodPost, "v1/"+res.Type, body, &createdResp); err != nil {
+			return nil, err
+		}
+
+		ids[locale] = createdResp.Data.ID
+	}
+
+	return ids, nil
+}
+
+// findPricePoint pages through the price points at path and returns the ID of the first one that matches,
+// or an empty string if none does.
+func (c *ascClient) findPricePoint(ctx *context.Context, path string, match func(pricePoint) bool) (string, error) {
+	for path != "" {
+		var resp pricePointsResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return "", err
+		}
+
+		for _, point := range resp.Data {
+			if match(point) {
+				return point.ID, nil
+			}
+		}
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return "", nil
+}
+
+// findPricePointByCustomerPrice pages through the price points at path and returns the ID of the one whose
+// customer price equals price, or an empty string if none does or price is not a number.
+func (c *ascClient) findPricePointByCustomerPrice(ctx *context.Context, path string, price string) (string, error) {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return "", nil
+	}
+
+	return c.findPricePoint(ctx, path, func(point pricePoint) bool {
+		customerPrice, err := strconv.ParseFloat(point.Attributes.CustomerPrice, 64)
+
+		return err == nil && customerPrice == value
+	})
+}
+
+// uploadProductReviewScreenshot replaces the review screenshot of a product unless it is already up to date.
+func (c *ascClient) uploadProductReviewScreenshot(ctx *context.Context, res productResource, config config.File) error {
+	prepare := func(name string, checksum string) (shouldContinue bool, err error) {
+		var resp reviewScreenshotResponse
+
+		if err := c.send(ctx, http.MethodGet, res.Path, nil, &resp); err != nil {
+			return false, err
+		}
+
+		shot := resp.Data
+		if shot == nil {
+			return true, nil
+		}
+
+		if shot.Attributes.FileName == name && shot.Attributes.SourceFileChecksum == checksum {
+			ctx.Log.WithFields(log.Fields{
+				"id":       shot.ID,
+				"checksum": checksum,
+			}).Debug("skip existing review screenshot")
+
+			return false, nil
+		}
+
+		ctx.Log.WithFields(log.Fields{
+			"name": name,
+			"id":   shot.ID,
+		}).Debug("delete review screenshot")
+
+		if err := c.send(ctx, http.MethodDelete, "v1/"+res.Type+"/"+shot.ID, nil, nil); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	create := func(name string, size int64) (id string, ops []asc.UploadOperation, err error) {
+		ctx.Log.WithFields(log.Fields{
+			"name": name,
+		}).Debug("create review screenshot")
+
+		body := apiDocument{Data: apiResource{
+			Type: res.Type,
+			Attributes: map[string]interface{}{
+				"fileName": name,
+				"fileSize": size,
+			},
+			Relationships: map[string]apiRelationship{
+				res.Relationship: res.Product,
+			},
+		}}
+
+		var resp reviewScreenshotResponse
+
+		if err := c.send(ctx, http.MethodPost, "v1/"+res.Type, body, &resp); err != nil {
+			return "", nil, err
+		}
+
+		if resp.Data == nil {
+			return "", nil, nil
+		}
+
+		return resp.Data.ID, resp.Data.Attributes.UploadOperations, nil
+	}
+
+	commit := func(id string, checksum string) error {
+		ctx.Log.WithFields(log.Fields{
+			"id": id,
+		}).Debug("commit review screenshot")
+
+		body := apiDocument{Data: apiResource{
+			Type: res.Type,
+			ID:   id,
+			Attributes: map[string]interface{}{
+				"uploaded":           true,
+				"sourceFileChecksum": checksum,
+			},
+		}}
+
+		return c.send(ctx, http.MethodPatch, "v1/"+res.Type+"/"+id, body, nil)
+	}
+
+	return c.uploadFile(ctx, config.Path, prepare, create, commit)
+}
@@ -0,0 +1,119 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/closer"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// apiBaseURL is the base URL of the App Store Connect API. Paths sent to it include the API version.
+const apiBaseURL = "https://api.appstoreconnect.apple.com/"
+
+// apiDocument is the body of a request or response for resources the App Store Connect API client does not model.
+type apiDocument struct {
+	Data     interface{}   `json:"data"`
+	Included []apiResource `json:"included,omitempty"`
+}
+
+// apiResource is a resource the App Store Connect API client does not model.
+type apiResource struct {
+	Type          string                     `json:"type"`
+	ID            string                     `json:"id,omitempty"`
+	Attributes    map[string]interface{}     `json:"attributes,omitempty"`
+	Relationships map[string]apiRelationship `json:"relationships,omitempty"`
+}
+
+// apiRelationship is a to-one or to-many relationship of an apiResource.
+type apiRelationship struct {
+	Data interface{} `json:"data"`
+}
+
+func toOne(typ, id string) apiRelationship {
+	return apiRelationship{Data: asc.RelationshipData{Type: typ, ID: id}}
+}
+
+func toMany(typ string, ids ...string) apiRelationship {
+	data := make([]asc.RelationshipData, len(ids))
+	for i, id := range ids {
+		data[i] = asc.RelationshipData{Type: typ, ID: id}
+	}
+
+	return apiRelationship{Data: data}
+}
+
+// send sends a request for resources the App Store Connect API client does not model, such as those only
+// available in version 2 of the API, and decodes the response into v if it is not nil. Errors returned by
+// the API are returned as an *asc.ErrorResponse, just like the App Store Connect API client does.
+func (c *ascClient) send(ctx *context.Context, method string, path string, body interface{}, v interface{}) error {
+	var buf bytes.Buffer
+
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	// Paging links are absolute
+	if !u.IsAbs() {
+		path = apiBaseURL + path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, &buf)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close(resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		errResp := &asc.ErrorResponse{Response: resp}
+		_ = json.NewDecoder(resp.Body).Decode(errResp)
+
+		return errResp
+	}
+
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
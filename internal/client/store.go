@@ -34,7 +34,7 @@ type errPlatformNotFound struct {
 }
 
 func (e errPlatformNotFound) Error() string {
-	return fmt.Sprintf(`platform %s could not be matched up with a supported App Store platform. supported values are "iOS", "macOS", or "tvOS"`, e.Platform)
+	return fmt.Sprintf(`platform %s could not be matched up with a supported App Store platform. supported values are "iOS", "macOS", "tvOS", or "visionOS"`, e.Platform)
 }
 
 func (c *ascClient) UpdateApp(ctx *context.Context, appID string, appInfoID string, versionID string, config config.App) error {
@@ -55,7 +55,11 @@ func (c *ascClient) UpdateApp(ctx *context.Context, appID string, appInfoID stri
 				return err
 			}
 
-			prices = priceSchedules(config.Availability.Pricing)
+			// Prices by territory are set through a price schedule of their own, once the app is available in them
+			if len(config.Availability.Prices) == 0 {
+				prices = priceSchedules(config.Availability.Pricing)
+			}
+
 			attrs.AvailableInNewTerritories = config.Availability.AvailableInNewTerritories
 		}
 
@@ -63,9 +67,15 @@ func (c *ascClient) UpdateApp(ctx *context.Context, appID string, appInfoID stri
 			attrs.PrimaryLocale = &config.PrimaryLocale
 		}
 
-		_, _, err := c.client.Apps.UpdateApp(ctx, appID, &attrs, availableTerritoryIDs, prices)
+		if _, _, err := c.client.Apps.UpdateApp(ctx, appID, &attrs, availableTerritoryIDs, prices); err != nil {
+			return err
+		}
+
+		if ctx.SkipUpdatePricing || config.Availability == nil || len(config.Availability.Prices) == 0 {
+			return nil
+		}
 
-		return err
+		return c.updateAppPrices(ctx, appID, *config.Availability)
 	})
 
 	g.Go(func() error {
@@ -0,0 +1,608 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/internal/parallel"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// subscriptionsLimit is the maximum number of subscription resources App Store Connect returns in a single page.
+const subscriptionsLimit = 200
+
+type errInvalidSubscriptionDuration struct {
+	ProductID string
+	Duration  string
+}
+
+func (e errInvalidSubscriptionDuration) Error() string {
+	return fmt.Sprintf("subscription %s has an invalid duration %q", e.ProductID, e.Duration)
+}
+
+type errInvalidIntroductoryOffer struct {
+	ProductID string
+	Mode      string
+	Duration  string
+}
+
+func (e errInvalidIntroductoryOffer) Error() string {
+	return fmt.Sprintf("introductory offer of subscription %s has an invalid mode %q or duration %q", e.ProductID, e.Mode, e.Duration)
+}
+
+type errSubscriptionPriceNotFound struct {
+	ProductID string
+	Territory string
+	Price     string
+}
+
+func (e errSubscriptionPriceNotFound) Error() string {
+	return fmt.Sprintf("no price point found for price %s in territory %s of subscription %s", e.Price, e.Territory, e.ProductID)
+}
+
+// subscriptionResource is a subscription group or subscription resource, which the App Store Connect API client
+// does not model.
+type subscriptionResource struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		ReferenceName string `json:"referenceName,omitempty"`
+		ProductID     string `json:"productId,omitempty"`
+	} `json:"attributes"`
+}
+
+type subscriptionResourcesResponse struct {
+	Data  []subscriptionResource `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+type subscriptionResourceResponse struct {
+	Data subscriptionResource `json:"data"`
+}
+
+type relationshipResponse struct {
+	Data *asc.RelationshipData `json:"data"`
+}
+
+type subscriptionPricesResponse struct {
+	Data []struct {
+		Attributes struct {
+			StartDate string `json:"startDate"`
+		} `json:"attributes"`
+		Relationships struct {
+			Territory              relationshipResponse `json:"territory"`
+			SubscriptionPricePoint relationshipResponse `json:"subscriptionPricePoint"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+// introductoryOffer is a subscription introductory offer resource, which the App Store Connect API client
+// does not model.
+type introductoryOffer struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Duration        string `json:"duration"`
+		OfferMode       string `json:"offerMode"`
+		NumberOfPeriods int    `json:"numberOfPeriods"`
+		StartDate       string `json:"startDate"`
+		EndDate         string `json:"endDate"`
+	} `json:"attributes"`
+	Relationships struct {
+		Territory              relationshipResponse `json:"territory"`
+		SubscriptionPricePoint relationshipResponse `json:"subscriptionPricePoint"`
+	} `json:"relationships"`
+}
+
+type introductoryOffersResponse struct {
+	Data  []introductoryOffer    `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+func (c *ascClient) UpdateSubscriptionGroups(ctx *context.Context, appID string, config config.SubscriptionGroups) error {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(subscriptionsLimit))
+
+	existing, err := c.listSubscriptionResources(ctx, fmt.Sprintf("v1/apps/%s/subscriptionGroups?%s", appID, query.Encode()))
+	if err != nil {
+		return err
+	}
+
+	groups := make(map[string]string, len(existing))
+	for _, group := range existing {
+		groups[group.Attributes.ReferenceName] = group.ID
+	}
+
+	var g = parallel.New(ctx.MaxProcesses)
+
+	for name, groupConfig := range config {
+		name := name
+		groupConfig := groupConfig
+
+		g.Go(func() error {
+			groupID, ok := groups[name]
+			if !ok {
+				var err error
+				if groupID, err = c.createSubscriptionGroup(ctx, appID, name); err != nil {
+					return err
+				}
+			}
+
+			if err := c.updateSubscriptionGroupLocalizations(ctx, groupID, groupConfig.Localizations); err != nil {
+				return err
+			}
+
+			return c.updateSubscriptions(ctx, groupID, groupConfig.Subscriptions)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ascClient) listSubscriptionResources(ctx *context.Context, path string) ([]subscriptionResource, error) {
+	var resources []subscriptionResource
+
+	for path != "" {
+		var resp subscriptionResourcesResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resp.Data...)
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return resources, nil
+}
+
+func (c *ascClient) createSubscriptionGroup(ctx *context.Context, appID string, name string) (string, error) {
+	ctx.Log.WithField("group", name).Debug("create subscription group")
+
+	body := apiDocument{Data: apiResource{
+		Type: "subscriptionGroups",
+		Attributes: map[string]interface{}{
+			"referenceName": name,
+		},
+		Relationships: map[string]apiRelationship{
+			"app": toOne("apps", appID),
+		},
+	}}
+
+	var resp subscriptionResourceResponse
+
+	if err := c.send(ctx, http.MethodPost, "v1/subscriptionGroups", body, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Data.ID, nil
+}
+
+func (c *ascClient) updateSubscriptionGroupLocalizations(ctx *context.Context, groupID string, config config.SubscriptionGroupLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+
+	for locale, locConfig := range config {
+		var customAppName interface{}
+		if locConfig.CustomAppName != "" {
+			customAppName = locConfig.CustomAppName
+		}
+
+		attrs[locale] = map[string]interface{}{
+			"name":          locConfig.Name,
+			"customAppName": customAppName,
+		}
+	}
+
+	_, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v1/subscriptionGroups/%s/subscriptionGroupLocalizations", groupID),
+		Type:         "subscriptionGroupLocalizations",
+		Relationship: "subscriptionGroup",
+		Product:      toOne("subscriptionGroups", groupID),
+	}, attrs)
+
+	return err
+}
+
+// updateSubscriptions creates or updates the subscriptions of a group one after the other, as App Store Connect
+// orders the levels of a group by the order its subscriptions are saved in.
+func (c *ascClient) updateSubscriptions(ctx *context.Context, groupID string, config config.Subscriptions) error {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(subscriptionsLimit))
+
+	existing, err := c.listSubscriptionResources(ctx, fmt.Sprintf("v1/subscriptionGroups/%s/subscriptions?%s", groupID, query.Encode()))
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[string]string, len(existing))
+	for _, sub := range existing {
+		subscriptions[sub.Attributes.ProductID] = sub.ID
+	}
+
+	for productID, subConfig := range config {
+		period := subConfig.Duration.APIValue()
+		if period == nil {
+			return errInvalidSubscriptionDuration{ProductID: productID, Duration: string(subConfig.Duration)}
+		}
+
+		attrs := map[string]interface{}{
+			"name":               subConfig.ReferenceName,
+			"subscriptionPeriod": *period,
+			"reviewNote":         subConfig.ReviewNotes,
+		}
+
+		if subConfig.GroupLevel > 0 {
+			attrs["groupLevel"] = subConfig.GroupLevel
+		}
+
+		subID, ok := subscriptions[productID]
+		if ok {
+			body := apiDocument{Data: apiResource{Type: "subscriptions", ID: subID, Attributes: attrs}}
+
+			if err := c.send(ctx, http.MethodPatch, "v1/subscriptions/"+subID, body, nil); err != nil {
+				return err
+			}
+		} else {
+			ctx.Log.WithField("product", productID).Debug("create subscription")
+
+			attrs["productId"] = productID
+			body := apiDocument{Data: apiResource{
+				Type:       "subscriptions",
+				Attributes: attrs,
+				Relationships: map[string]apiRelationship{
+					"group": toOne("subscriptionGroups", groupID),
+				},
+			}}
+
+			var resp subscriptionResourceResponse
+
+			if err := c.send(ctx, http.MethodPost, "v1/subscriptions", body, &resp); err != nil {
+				return err
+			}
+
+			subID = resp.Data.ID
+		}
+
+		if err := c.updateSubscriptionDetails(ctx, subID, productID, subConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateSubscriptionDetails updates the localizations, prices, introductory offers and review screenshot of
+// a subscription.
+func (c *ascClient) updateSubscriptionDetails(ctx *context.Context, subID string, productID string, config config.Subscription) error {
+	if err := c.updateSubscriptionLocalizations(ctx, subID, config.Localizations); err != nil {
+		return err
+	}
+
+	if err := c.updateSubscriptionPrices(ctx, subID, productID, config.Prices); err != nil {
+		return err
+	}
+
+	if err := c.updateIntroductoryOffers(ctx, subID, productID, config); err != nil {
+		return err
+	}
+
+	if config.ReviewScreenshot != nil {
+		return c.uploadProductReviewScreenshot(ctx, productResource{
+			Path:         fmt.Sprintf("v1/subscriptions/%s/appStoreReviewScreenshot", subID),
+			Type:         "subscriptionAppStoreReviewScreenshots",
+			Relationship: "subscription",
+			Product:      toOne("subscriptions", subID),
+		}, *config.ReviewScreenshot)
+	}
+
+	return nil
+}
+
+func (c *ascClient) updateSubscriptionLocalizations(ctx *context.Context, subID string, config config.SubscriptionLocalizations) error {
+	attrs := make(map[string]map[string]interface{}, len(config))
+	for locale, locConfig := range config {
+		attrs[locale] = map[string]interface{}{
+			"name":        locConfig.Name,
+			"description": locConfig.Description,
+		}
+	}
+
+	_, err := c.updateProductLocalizations(ctx, productResource{
+		Path:         fmt.Sprintf("v1/subscriptions/%s/subscriptionLocalizations", subID),
+		Type:         "subscriptionLocalizations",
+		Relationship: "subscription",
+		Product:      toOne("subscriptions", subID),
+	}, attrs)
+
+	return err
+}
+
+// updateSubscriptionPrices sets the price of the subscription in each territory whose current price differs
+// from the configured one.
+func (c *ascClient) updateSubscriptionPrices(ctx *context.Context, subID string, productID string, prices map[string]string) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	current, err := c.currentSubscriptionPrices(ctx, subID)
+	if err != nil {
+		return err
+	}
+
+	for territory, price := range prices {
+		pricePointID, err := c.findSubscriptionPricePoint(ctx, subID, productID, territory, price)
+		if err != nil {
+			return err
+		}
+
+		if current[territory] == pricePointID {
+			continue
+		}
+
+		ctx.Log.WithFields(log.Fields{
+			"product":   productID,
+			"territory": territory,
+			"price":     price,
+		}).Debug("update subscription price")
+
+		body := apiDocument{Data: apiResource{
+			Type: "subscriptionPrices",
+			Attributes: map[string]interface{}{
+				"startDate":            nil,
+				"preserveCurrentPrice": false,
+			},
+			Relationships: map[string]apiRelationship{
+				"subscription":           toOne("subscriptions", subID),
+				"subscriptionPricePoint": toOne("subscriptionPricePoints", pricePointID),
+				"territory":              toOne("territories", territory),
+			},
+		}}
+
+		if err := c.send(ctx, http.MethodPost, "v1/subscriptionPrices", body, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// currentSubscriptionPrices returns the IDs of the price points in effect today for the subscription, keyed by
+// territory. Prices scheduled for later are ignored.
+func (c *ascClient) currentSubscriptionPrices(ctx *context.Context, subID string) (map[string]string, error) {
+	query := url.Values{}
+	query.Set("include", "territory,subscriptionPricePoint")
+	query.Set("limit", strconv.Itoa(subscriptionsLimit))
+
+	today := time.Now().Format("2006-01-02")
+	current := make(map[string]string)
+	started := make(map[string]string)
+
+	path := fmt.Sprintf("v1/subscriptions/%s/prices?%s", subID, query.Encode())
+
+	for path != "" {
+		var resp subscriptionPricesResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, price := range resp.Data {
+			territory := price.Relationships.Territory.Data
+			point := price.Relationships.SubscriptionPricePoint.Data
+
+			if territory == nil || point == nil {
+				continue
+			}
+
+			// Dates are formatted as YYYY-MM-DD, and prices without one have always been in effect
+			start := price.Attributes.StartDate
+			if start > today {
+				continue
+			}
+
+			if last, ok := started[territory.ID]; !ok || start >= last {
+				started[territory.ID] = start
+				current[territory.ID] = point.ID
+			}
+		}
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return current, nil
+}
+
+func (c *ascClient) findSubscriptionPricePoint(ctx *context.Context, subID string, productID string, territory string, price string) (string, error) {
+	query := url.Values{}
+	query.Set("filter[territory]", territory)
+	query.Set("limit", strconv.Itoa(subscriptionsLimit))
+
+	path := fmt.Sprintf("v1/subscriptions/%s/pricePoints?%s", subID, query.Encode())
+
+	pricePointID, err := c.findPricePointByCustomerPrice(ctx, path, price)
+	if err != nil {
+		return "", err
+	}
+
+	if pricePointID == "" {
+		return "", errSubscriptionPriceNotFound{ProductID: productID, Territory: territory, Price: price}
+	}
+
+	return pricePointID, nil
+}
+
+// updateIntroductoryOffers replaces the introductory offer of each configured territory unless it already matches
+// the configuration. Offers in other territories are left as they are.
+func (c *ascClient) updateIntroductoryOffers(ctx *context.Context, subID string, productID string, config config.Subscription) error {
+	if len(config.IntroductoryOffers) == 0 {
+		return nil
+	}
+
+	existing, err := c.listIntroductoryOffers(ctx, subID)
+	if err != nil {
+		return err
+	}
+
+	for _, offerConfig := range config.IntroductoryOffers {
+		mode := offerConfig.Mode.APIValue()
+		duration := offerConfig.Duration.APIValue()
+
+		if mode == nil || duration == nil {
+			return errInvalidIntroductoryOffer{ProductID: productID, Mode: string(offerConfig.Mode), Duration: string(offerConfig.Duration)}
+		}
+
+		periods := offerConfig.Periods
+		if periods < 1 {
+			periods = 1
+		}
+
+		var startDate, endDate string
+		if offerConfig.StartDate != nil {
+			startDate = offerConfig.StartDate.Format("2006-01-02")
+		}
+
+		if offerConfig.EndDate != nil {
+			endDate = offerConfig.EndDate.Format("2006-01-02")
+		}
+
+		for _, territory := range offerConfig.AvailableTerritories(config) {
+			var pricePointID string
+
+			if price, ok := offerConfig.Prices[territory]; ok {
+				if pricePointID, err = c.findSubscriptionPricePoint(ctx, subID, productID, territory, price); err != nil {
+					return err
+				}
+			}
+
+			offer, ok := existing[territory]
+			if ok {
+				var offerPricePointID string
+				if point := offer.Relationships.SubscriptionPricePoint.Data; point != nil {
+					offerPricePointID = point.ID
+				}
+
+				if offer.Attributes.OfferMode == *mode &&
+					offer.Attributes.Duration == *duration &&
+					offer.Attributes.NumberOfPeriods == periods &&
+					offer.Attributes.StartDate == startDate &&
+					offer.Attributes.EndDate == endDate &&
+					offerPricePointID == pricePointID {
+					continue
+				}
+
+				ctx.Log.WithFields(log.Fields{
+					"product":   productID,
+					"territory": territory,
+				}).Debug("delete introductory offer")
+
+				if err := c.send(ctx, http.MethodDelete, "v1/subscriptionIntroductoryOffers/"+offer.ID, nil, nil); err != nil {
+					return err
+				}
+			}
+
+			ctx.Log.WithFields(log.Fields{
+				"product":   productID,
+				"territory": territory,
+			}).Debug("create introductory offer")
+
+			attrs := map[string]interface{}{
+				"offerMode":       *mode,
+				"duration":        *duration,
+				"numberOfPeriods": periods,
+			}
+
+			if startDate != "" {
+				attrs["startDate"] = startDate
+			}
+
+			if endDate != "" {
+				attrs["endDate"] = endDate
+			}
+
+			relationships := map[string]apiRelationship{
+				"subscription": toOne("subscriptions", subID),
+				"territory":    toOne("territories", territory),
+			}
+
+			if pricePointID != "" {
+				relationships["subscriptionPricePoint"] = toOne("subscriptionPricePoints", pricePointID)
+			}
+
+			body := apiDocument{Data: apiResource{
+				Type:          "subscriptionIntroductoryOffers",
+				Attributes:    attrs,
+				Relationships: relationships,
+			}}
+
+			if err := c.send(ctx, http.MethodPost, "v1/subscriptionIntroductoryOffers", body, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listIntroductoryOffers returns the introductory offers of the subscription keyed by territory.
+func (c *ascClient) listIntroductoryOffers(ctx *context.Context, subID string) (map[string]introductoryOffer, error) {
+	query := url.Values{}
+	query.Set("include", "territory,subscriptionPricePoint")
+	query.Set("limit", strconv.Itoa(subscriptionsLimit))
+
+	offers := make(map[string]introductoryOffer)
+
+	path := fmt.Sprintf("v1/subscriptions/%s/introductoryOffers?%s", subID, query.Encode())
+
+	for path != "" {
+		var resp introductoryOffersResponse
+
+		if err := c.send(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, offer := range resp.Data {
+			if territory := offer.Relationships.Territory.Data; territory != nil {
+				offers[territory.ID] = offer
+			}
+		}
+
+		path = ""
+		if resp.Links.Next != nil {
+			path = resp.Links.Next.String()
+		}
+	}
+
+	return offers, nil
+}
@@ -0,0 +1,314 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test UpdateSubscriptionGroups
+
+func TestUpdateSubscriptionGroups_HappyCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"group1","attributes":{"referenceName":"Premium"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"sub1","attributes":{"productId":"com.app.premium"}}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{
+		"Premium": {
+			Localizations: config.SubscriptionGroupLocalizations{
+				"en-US": {Name: "Premium"},
+			},
+			Subscriptions: config.Subscriptions{
+				"com.app.premium": {
+					ReferenceName: "Premium Monthly",
+					Duration:      config.SubscriptionDurationOneMonth,
+					GroupLevel:    1,
+					Localizations: config.SubscriptionLocalizations{
+						"en-US": {Name: "Premium", Description: "Everything, every month"},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateSubscriptionGroups_HappyUpdate(t *testing.T) {
+	t.Parallel()
+
+	asset := newTestAsset(t, "premium.png")
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v1/subscriptions/sub1/pricePoints?cursor=2")
+	assert.NoError(t, err)
+
+	ctx.SetResponses(
+		response{
+			RawResponse: `{"data":[{"id":"group1","attributes":{"referenceName":"Premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc1","attributes":{"locale":"en-US"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"sub1","attributes":{"productId":"com.app.premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"loc2","attributes":{"locale":"en-US"}}]}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[
+				{"attributes":{"startDate":null},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}},"subscriptionPricePoint":{"data":{"type":"subscriptionPricePoints","id":"pp0"}}}},
+				{"attributes":{"startDate":"9999-01-01"},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}},"subscriptionPricePoint":{"data":{"type":"subscriptionPricePoints","id":"pp2"}}}}
+			],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp0","attributes":{"customerPrice":"3.99"}}],"links":{"self":"","next":"` + next.String() + `"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp1","attributes":{"customerPrice":"4.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"offer1","attributes":{"offerMode":"FREE_TRIAL","duration":"ONE_WEEK","numberOfPeriods":1},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}}}}],"links":{"self":""}}`,
+		},
+		response{
+			StatusCode: http.StatusNoContent,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":null}`,
+		},
+		response{
+			RawResponse: `{"data":{"id":"shot1","attributes":{"uploadOperations":[]}}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+
+	err = client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{
+		"Premium": {
+			Localizations: config.SubscriptionGroupLocalizations{
+				"en-US": {Name: "Premium", CustomAppName: "App Premium"},
+			},
+			Subscriptions: config.Subscriptions{
+				"com.app.premium": {
+					ReferenceName: "Premium Monthly",
+					Duration:      config.SubscriptionDurationOneMonth,
+					Prices:        map[string]string{"USA": "4.99"},
+					IntroductoryOffers: []config.IntroductoryOffer{
+						{Mode: config.OfferModeFreeTrial, Duration: config.OfferDurationOneMonth},
+					},
+					Localizations: config.SubscriptionLocalizations{
+						"en-US": {Name: "Premium"},
+					},
+					ReviewScreenshot: &config.File{Path: asset.Name},
+					ReviewNotes:      "TEST",
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateSubscriptionGroups_HappyUnchangedPricesAndOffers(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"group1","attributes":{"referenceName":"Premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"sub1","attributes":{"productId":"com.app.premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"attributes":{"startDate":null},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}},"subscriptionPricePoint":{"data":{"type":"subscriptionPricePoints","id":"pp1"}}}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp1","attributes":{"customerPrice":"4.99"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"offer1","attributes":{"offerMode":"PAY_AS_YOU_GO","duration":"ONE_MONTH","numberOfPeriods":3},"relationships":{"territory":{"data":{"type":"territories","id":"USA"}},"subscriptionPricePoint":{"data":{"type":"subscriptionPricePoints","id":"pp0"}}}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp0","attributes":{"customerPrice":"0.99"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{
+		"Premium": {
+			Subscriptions: config.Subscriptions{
+				"com.app.premium": {
+					ReferenceName: "Premium Monthly",
+					Duration:      config.SubscriptionDurationOneMonth,
+					Prices:        map[string]string{"USA": "4.99"},
+					IntroductoryOffers: []config.IntroductoryOffer{
+						{
+							Mode:     config.OfferModePayAsYouGo,
+							Duration: config.OfferDurationOneMonth,
+							Periods:  3,
+							Prices:   map[string]string{"USA": "0.99"},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateSubscriptionGroups_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{})
+	assert.Error(t, err)
+}
+
+func TestUpdateSubscriptionGroups_ErrInvalidDuration(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"group1","attributes":{"referenceName":"Premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{
+		"Premium": {
+			Subscriptions: config.Subscriptions{
+				"com.app.premium": {ReferenceName: "Premium"},
+			},
+		},
+	})
+	assert.EqualError(t, err, errInvalidSubscriptionDuration{ProductID: "com.app.premium"}.Error())
+}
+
+func TestUpdateSubscriptionGroups_ErrPriceNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"group1","attributes":{"referenceName":"Premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"sub1","attributes":{"productId":"com.app.premium"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"pp0","attributes":{"customerPrice":"3.99"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateSubscriptionGroups(ctx.Context, testID, config.SubscriptionGroups{
+		"Premium": {
+			Subscriptions: config.Subscriptions{
+				"com.app.premium": {
+					ReferenceName: "Premium",
+					Duration:      config.SubscriptionDurationOneMonth,
+					Prices:        map[string]string{"USA": "4.98"},
+				},
+			},
+		},
+	})
+	assert.EqualError(t, err, errSubscriptionPriceNotFound{ProductID: "com.app.premium", Territory: "USA", Price: "4.98"}.Error())
+}
@@ -21,6 +21,12 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package client
 
 import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
 	"github.com/cidertool/asc-go/asc"
 	"github.com/cidertool/cider/internal/log"
 	"github.com/cidertool/cider/internal/parallel"
@@ -28,6 +34,23 @@ import (
 	"github.com/cidertool/cider/pkg/context"
 )
 
+// betaTestersLimit is the maximum number of beta testers App Store Connect returns in a single page.
+const betaTestersLimit = 200
+
+// buildsLimit is the maximum number of builds App Store Connect returns in a single page.
+const buildsLimit = 200
+
+// betaGroupsLimit is the maximum number of beta groups App Store Connect returns in a single page.
+const betaGroupsLimit = 200
+
+type errIncompleteList struct {
+	Kind string
+}
+
+func (e errIncompleteList) Error() string {
+	return fmt.Sprintf("could not read every page of %s from App Store Connect", e.Kind)
+}
+
 func (c *ascClient) UpdateBetaAppLocalizations(ctx *context.Context, appID string, config config.TestflightLocalizations) error {
 	var g = parallel.New(ctx.MaxProcesses)
 
@@ -196,6 +219,61 @@ func (c *ascClient) UpdateBetaBuildLocalizations(ctx *context.Context, buildID s
 	return g.Wait()
 }
 
+func (c *ascClient) GetPreviousBuildWhatsNew(ctx *context.Context, appID string, buildID string, locales []string) (string, error) {
+	version, err := c.buildPrereleaseVersion(ctx, buildID)
+	if err != nil {
+		return "", err
+	}
+
+	builds, err := c.listBuilds(ctx, asc.ListBuildsQuery{
+		FilterApp:                       []string{appID},
+		FilterPreReleaseVersionPlatform: []string{string(*version.Attributes.Platform)},
+		Sort:                            []string{"-uploadedDate"},
+		Limit:                           buildsLimit,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var previousID string
+
+	for i, build := range builds {
+		if build.ID == buildID && i+1 < len(builds) {
+			previousID = builds[i+1].ID
+
+			break
+		}
+	}
+
+	if previousID == "" {
+		return "", nil
+	}
+
+	locListResp, _, err := c.client.TestFlight.ListBetaBuildLocalizationsForBuild(ctx, previousID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Map of locale -> whatsNew
+	var notes = make(map[string]string, len(locListResp.Data))
+
+	for _, loc := range locListResp.Data {
+		if loc.Attributes == nil || loc.Attributes.Locale == nil || loc.Attributes.WhatsNew == nil {
+			continue
+		}
+
+		notes[*loc.Attributes.Locale] = *loc.Attributes.WhatsNew
+	}
+
+	for _, locale := range locales {
+		if notes[locale] != "" {
+			return notes[locale], nil
+		}
+	}
+
+	return "", nil
+}
+
 func (c *ascClient) UpdateBetaLicenseAgreement(ctx *context.Context, appID string, config config.Testflight) error {
 	if config.LicenseAgreement == "" {
 		return nil
@@ -220,9 +298,7 @@ func (c *ascClient) AssignBetaGroups(ctx *context.Context, appID string, buildID
 		return nil
 	}
 
-	existingGroupsResp, _, err := c.client.TestFlight.ListBetaGroups(ctx, &asc.ListBetaGroupsQuery{
-		FilterApp: []string{appID},
-	})
+	existingGroups, err := c.listBetaGroups(ctx, appID)
 	if err != nil {
 		return err
 	}
@@ -238,8 +314,8 @@ func (c *ascClient) AssignBetaGroups(ctx *context.Context, appID string, buildID
 	// Map of group names -> whether or not they exist in the configuration
 	var found = make(map[string]bool)
 
-	for i := range existingGroupsResp.Data {
-		group := existingGroupsResp.Data[i]
+	for i := range existingGroups {
+		group := existingGroups[i]
 		if group.Attributes == nil || group.Attributes.Name == nil {
 			continue
 		}
@@ -255,6 +331,11 @@ func (c *ascClient) AssignBetaGroups(ctx *context.Context, appID string, buildID
 		}
 
 		g.Go(func() error {
+			isInternal := group.Attributes.IsInternalGroup != nil && *group.Attributes.IsInternalGroup
+			if isInternal != configGroup.IsInternal {
+				return errBetaGroupTypeMismatch{Name: name, IsInternal: isInternal}
+			}
+
 			ctx.Log.WithField("group", name).Debug("update beta group")
 
 			return c.updateBetaGroup(ctx, g, appID, group.ID, buildID, configGroup)
@@ -281,60 +362,369 @@ func (c *ascClient) AssignBetaGroups(ctx *context.Context, appID string, buildID
 	return g.Wait()
 }
 
-func (c *ascClient) updateBetaGroup(ctx *context.Context, g parallel.Group, appID string, groupID string, buildID string, group config.BetaGroup) error {
-	g.Go(func() error {
-		_, _, err := c.client.TestFlight.UpdateBetaGroup(ctx, groupID, &asc.BetaGroupUpdateRequestAttributes{
-			FeedbackEnabled:        &group.FeedbackEnabled,
-			Name:                   &group.Name,
-			PublicLinkEnabled:      &group.EnablePublicLink,
-			PublicLinkLimit:        &group.PublicLinkLimit,
-			PublicLinkLimitEnabled: &group.EnablePublicLinkLimit,
-		})
+func (c *ascClient) ListBetaGroupPublicLinks(ctx *context.Context, appID string) (map[string]string, error) {
+	groups, err := c.listBetaGroups(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	var links = make(map[string]string)
+
+	for _, group := range groups {
+		if group.Attributes == nil || group.Attributes.Name == nil || group.Attributes.PublicLink == nil {
+			continue
+		} else if group.Attributes.PublicLinkEnabled == nil || !*group.Attributes.PublicLinkEnabled {
+			continue
+		}
+
+		links[*group.Attributes.Name] = *group.Attributes.PublicLink
+	}
 
+	return links, nil
+}
+
+func (c *ascClient) RemovePreviousBuildsFromBetaGroups(ctx *context.Context, appID string, buildID string, groups []config.BetaGroup) error {
+	var g = parallel.New(ctx.MaxProcesses)
+
+	version, err := c.buildPrereleaseVersion(ctx, buildID)
+	if err != nil {
+		return err
+	}
+
+	existingGroups, err := c.listBetaGroups(ctx, appID)
+	if err != nil {
 		return err
+	}
+
+	// Map of group names -> config.BetaGroup
+	var groupConfigs = make(map[string]config.BetaGroup, len(groups))
+
+	for _, group := range groups {
+		groupConfigs[group.Name] = group
+	}
+
+	for i := range existingGroups {
+		group := existingGroups[i]
+		if group.Attributes == nil || group.Attributes.Name == nil {
+			continue
+		}
+
+		name := *group.Attributes.Name
+
+		configGroup, ok := groupConfigs[name]
+		if !ok {
+			continue
+		} else if configGroup.IsInternal && configGroup.HasAccessToAllBuilds {
+			ctx.Log.WithField("group", name).Debug("has access to all builds. skipping...")
+
+			continue
+		}
+
+		g.Go(func() error {
+			builds, err := c.listBuilds(ctx, asc.ListBuildsQuery{
+				FilterApp:                       []string{appID},
+				FilterBetaGroups:                []string{group.ID},
+				FilterPreReleaseVersionPlatform: []string{string(*version.Attributes.Platform)},
+				Limit:                           buildsLimit,
+			})
+			if err != nil {
+				return err
+			}
+
+			buildIDs := make([]string, 0)
+
+			for _, build := range builds {
+				if build.ID != buildID {
+					buildIDs = append(buildIDs, build.ID)
+				}
+			}
+
+			if len(buildIDs) == 0 {
+				return nil
+			}
+
+			if _, err := c.client.TestFlight.RemoveBuildsFromBetaGroup(ctx, group.ID, buildIDs); err != nil {
+				return err
+			}
+
+			ctx.Log.
+				WithFields(log.Fields{
+					"group":   name,
+					"removed": len(buildIDs),
+				}).
+				Info("removed previous builds")
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ascClient) ExpireBuilds(ctx *context.Context, appID string, buildID string, config config.ExpireBuilds) (int, error) {
+	var g = parallel.New(ctx.MaxProcesses)
+
+	if config.KeepLatest <= 0 && config.OlderThanDays <= 0 {
+		return 0, nil
+	}
+
+	version, err := c.buildPrereleaseVersion(ctx, buildID)
+	if err != nil {
+		return 0, err
+	}
+
+	builds, err := c.listBuilds(ctx, asc.ListBuildsQuery{
+		FilterApp:                       []string{appID},
+		FilterExpired:                   []string{"false"},
+		FilterPreReleaseVersionPlatform: []string{string(*version.Attributes.Platform)},
+		Include:                         []string{"preReleaseVersion"},
+		Sort:                            []string{"-uploadedDate"},
+		Limit:                           buildsLimit,
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := ctx.Date.AddDate(0, 0, -config.OlderThanDays)
+
+	var expired int
+
+	var mu sync.Mutex
+
+	// Map of pre-release version IDs -> rank by most recent upload
+	var versionRanks = make(map[string]int)
+
+	for i := range builds {
+		build := builds[i]
+
+		versionID := build.ID
+		if build.Relationships != nil &&
+			build.Relationships.PreReleaseVersion != nil &&
+			build.Relationships.PreReleaseVersion.Data != nil {
+			versionID = build.Relationships.PreReleaseVersion.Data.ID
+		}
+
+		rank, ok := versionRanks[versionID]
+		if !ok {
+			rank = len(versionRanks)
+			versionRanks[versionID] = rank
+		}
+
+		if build.ID == buildID || versionID == version.ID {
+			continue
+		}
+
+		tooMany := config.KeepLatest > 0 && rank >= config.KeepLatest
+		tooOld := config.OlderThanDays > 0 &&
+			build.Attributes != nil &&
+			build.Attributes.UploadedDate != nil &&
+			build.Attributes.UploadedDate.Before(cutoff)
+
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		g.Go(func() error {
+			ctx.Log.WithField("build", build.ID).Debug("expire build")
+
+			if _, _, err := c.client.Builds.UpdateBuild(ctx, build.ID, asc.Bool(true), nil, nil); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			expired++
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+
+	return expired, err
+}
+
+func (c *ascClient) PruneBetaGroups(ctx *context.Context, appID string, groups []config.BetaGroup, pattern string) ([]string, error) {
+	var g = parallel.New(ctx.MaxProcesses)
+
+	if pattern == "" {
+		return nil, errMissingPrunePattern
+	} else if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	existingGroups, err := c.listBetaGroups(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set of group names in the configuration
+	var listed = make(map[string]bool, len(groups))
+
+	for _, group := range groups {
+		listed[group.Name] = true
+	}
+
+	pruned := make([]string, 0)
+
+	for i := range existingGroups {
+		group := existingGroups[i]
+		if group.Attributes == nil || group.Attributes.Name == nil {
+			continue
+		}
+
+		name := *group.Attributes.Name
+		if listed[name] {
+			continue
+		} else if matched, _ := path.Match(pattern, name); !matched {
+			continue
+		} else if group.Attributes.IsInternalGroup != nil && *group.Attributes.IsInternalGroup {
+			ctx.Log.WithField("group", name).Warn("not pruning internal beta group")
+
+			continue
+		}
+
+		pruned = append(pruned, name)
+
+		if ctx.PruneDryRun {
+			ctx.Log.WithField("group", name).Info("would delete beta group")
+
+			continue
+		}
+
+		g.Go(func() error {
+			ctx.Log.WithField("group", name).Info("delete beta group")
+			_, err := c.client.TestFlight.DeleteBetaGroup(ctx, group.ID)
+
+			return err
+		})
+	}
+
+	return pruned, g.Wait()
+}
+
+func (c *ascClient) updateBetaGroup(ctx *context.Context, g parallel.Group, appID string, groupID string, buildID string, group config.BetaGroup) error {
 	g.Go(func() error {
-		_, err := c.client.TestFlight.AddBuildsToBetaGroup(ctx, groupID, []string{buildID})
+		body := apiDocument{
+			Data: apiResource{
+				Type:       "betaGroups",
+				ID:         groupID,
+				Attributes: betaGroupAttributes(group),
+			},
+		}
 
-		return err
+		return c.send(ctx, http.MethodPatch, "v1/betaGroups/"+groupID, body, nil)
 	})
+
+	if group.IsInternal && group.HasAccessToAllBuilds {
+		ctx.Log.WithField("group", group.Name).Debug("has access to all builds. skipping adding build...")
+	} else {
+		g.Go(func() error {
+			_, err := c.client.TestFlight.AddBuildsToBetaGroup(ctx, groupID, []string{buildID})
+
+			return err
+		})
+	}
+
 	g.Go(func() error {
-		return c.updateBetaTestersForGroup(ctx, g, appID, groupID, group.Testers)
+		return c.syncBetaTestersForGroup(ctx, g, appID, groupID, group)
 	})
 
 	return nil
 }
 
+// betaGroupAttributes returns the attributes of a beta group to create or update it with. The App Store Connect
+// API client does not model whether a group is internal or has access to all builds, so beta groups are created
+// and updated with send.
+func betaGroupAttributes(group config.BetaGroup) map[string]interface{} {
+	attrs := map[string]interface{}{
+		"name":                 group.Name,
+		"feedbackEnabled":      group.FeedbackEnabled,
+		"isInternalGroup":      group.IsInternal,
+		"hasAccessToAllBuilds": group.IsInternal && group.HasAccessToAllBuilds,
+	}
+
+	// Public links are only available to external groups
+	if !group.IsInternal {
+		attrs["publicLinkEnabled"] = group.EnablePublicLink
+		attrs["publicLinkLimit"] = group.PublicLinkLimit
+		attrs["publicLinkLimitEnabled"] = group.EnablePublicLinkLimit
+	}
+
+	return attrs
+}
+
 func (c *ascClient) createBetaGroup(ctx *context.Context, g parallel.Group, appID string, buildID string, group config.BetaGroup) error {
-	newGroupResp, _, err := c.client.TestFlight.CreateBetaGroup(ctx, asc.BetaGroupCreateRequestAttributes{
-		FeedbackEnabled:        &group.FeedbackEnabled,
-		Name:                   group.Name,
-		PublicLinkEnabled:      &group.EnablePublicLink,
-		PublicLinkLimit:        &group.PublicLinkLimit,
-		PublicLinkLimitEnabled: &group.EnablePublicLinkLimit,
-	}, appID, nil, []string{buildID})
-	if err != nil {
+	relationships := map[string]apiRelationship{
+		"app": toOne("apps", appID),
+	}
+
+	if !group.IsInternal || !group.HasAccessToAllBuilds {
+		relationships["builds"] = toMany("builds", buildID)
+	}
+
+	body := apiDocument{
+		Data: apiResource{
+			Type:          "betaGroups",
+			Attributes:    betaGroupAttributes(group),
+			Relationships: relationships,
+		},
+	}
+
+	var newGroupResp asc.BetaGroupResponse
+	if err := c.send(ctx, http.MethodPost, "v1/betaGroups", body, &newGroupResp); err != nil {
 		return err
 	}
 
 	g.Go(func() error {
-		return c.updateBetaTestersForGroup(ctx, g, appID, newGroupResp.Data.ID, group.Testers)
+		return c.syncBetaTestersForGroup(ctx, g, appID, newGroupResp.Data.ID, group)
 	})
 
 	return nil
 }
 
-func (c *ascClient) updateBetaTestersForGroup(ctx *context.Context, g parallel.Group, appID string, groupID string, testers []config.BetaTester) error {
+func (c *ascClient) syncBetaTestersForGroup(ctx *context.Context, g parallel.Group, appID string, groupID string, group config.BetaGroup) error {
+	added, err := c.updateBetaTestersForGroup(ctx, g, appID, groupID, group.Testers)
+	if err != nil {
+		return err
+	}
+
+	var removed int
+
+	if group.Sync == config.SyncModeExact {
+		removed, err = c.removeUnlistedBetaTestersFromGroup(ctx, groupID, group.Testers)
+		if err != nil {
+			return err
+		}
+	}
+
+	if added > 0 || removed > 0 {
+		ctx.Log.
+			WithFields(log.Fields{
+				"group":   group.Name,
+				"added":   added,
+				"removed": removed,
+			}).
+			Info("synced beta testers")
+	}
+
+	return nil
+}
+
+// updateBetaTestersForGroup adds the given testers to a beta group, creating testers that do not exist yet.
+// It returns the number of testers that are being added.
+func (c *ascClient) updateBetaTestersForGroup(ctx *context.Context, g parallel.Group, appID string, groupID string, testers []config.BetaTester) (int, error) {
 	if len(testers) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	existingTesters, err := c.listBetaTesters(ctx, appID, testers)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	betaTesterIDs, found := filterTestersNotInBetaGroup(existingTesters, groupID)
+	added := len(betaTesterIDs)
 
 	g.Go(func() error {
 		_, err = c.client.TestFlight.AddBetaTestersToBetaGroup(ctx, groupID, betaTesterIDs)
@@ -352,12 +742,60 @@ func (c *ascClient) updateBetaTestersForGroup(ctx *context.Context, g parallel.G
 			continue
 		}
 
+		added++
+
 		g.Go(func() error {
 			return c.createBetaTester(ctx, tester, []string{groupID}, nil)
 		})
 	}
 
-	return err
+	return added, err
+}
+
+// removeUnlistedBetaTestersFromGroup removes testers from a beta group if they are not listed in testers.
+// It returns the number of testers removed.
+func (c *ascClient) removeUnlistedBetaTestersFromGroup(ctx *context.Context, groupID string, testers []config.BetaTester) (int, error) {
+	groupTesters, err := c.listBetaTestersForBetaGroup(ctx, groupID)
+	if err != nil {
+		return 0, err
+	}
+
+	betaTesterIDs := filterUnlistedTesters(groupTesters, testers, nil)
+	if len(betaTesterIDs) == 0 {
+		return 0, nil
+	}
+
+	if err := c.reserveTesterRemovals(ctx, len(betaTesterIDs)); err != nil {
+		return 0, err
+	}
+
+	_, err = c.client.TestFlight.RemoveBetaTestersFromBetaGroup(ctx, groupID, betaTesterIDs)
+
+	return len(betaTesterIDs), err
+}
+
+// filterUnlistedTesters returns the IDs of testers whose email is not listed in config, and whose ID is not
+// kept. Testers are matched by email regardless of case, and testers without an email are never returned.
+func filterUnlistedTesters(testers []asc.BetaTester, config []config.BetaTester, keep map[string]bool) []string {
+	var listed = make(map[string]bool, len(config))
+
+	for _, tester := range config {
+		listed[strings.ToLower(tester.Email)] = true
+	}
+
+	betaTesterIDs := make([]string, 0)
+
+	for _, tester := range testers {
+		if tester.Attributes == nil || tester.Attributes.Email == nil {
+			continue
+		} else if listed[strings.ToLower(string(*tester.Attributes.Email))] || keep[tester.ID] {
+			continue
+		}
+
+		betaTesterIDs = append(betaTesterIDs, tester.ID)
+	}
+
+	return betaTesterIDs
 }
 
 func filterTestersNotInBetaGroup(testers []asc.BetaTester, groupID string) (betaTesterIDs []string, found map[string]bool) {
@@ -408,6 +846,8 @@ func (c *ascClient) AssignBetaTesters(ctx *context.Context, appID string, buildI
 	// Map of tester emails -> whether or not they exist in the configuration
 	var found = make(map[string]bool)
 
+	var added int
+
 	for i := range existingTesters {
 		tester := existingTesters[i]
 		if tester.Attributes == nil || tester.Attributes.Email == nil {
@@ -440,6 +880,8 @@ func (c *ascClient) AssignBetaTesters(ctx *context.Context, appID string, buildI
 			continue
 		}
 
+		added++
+
 		g.Go(func() error {
 			ctx.Log.WithField("email", tester.Email).Debug("create individual beta tester")
 
@@ -447,9 +889,123 @@ func (c *ascClient) AssignBetaTesters(ctx *context.Context, appID string, buildI
 		})
 	}
 
+	if added > 0 {
+		ctx.Log.WithField("added", added).Info("synced individual beta testers")
+	}
+
 	return g.Wait()
 }
 
+func (c *ascClient) RemoveUnlistedBetaTesters(ctx *context.Context, appID string, testers []config.BetaTester) error {
+	var g = parallel.New(ctx.MaxProcesses)
+
+	// Every list is read in full before anything is removed, so that testers on a page that could not be read
+	// are never mistaken for unlisted ones.
+	groups, err := c.listBetaGroups(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	// Set of tester IDs that belong to one of the app's beta groups
+	var inGroup = make(map[string]bool)
+
+	for _, group := range groups {
+		ids, err := c.listBetaTesterIDsForBetaGroup(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			inGroup[id] = true
+		}
+	}
+
+	appTesters, err := c.listBetaTestersForApp(ctx, appID)
+	if err != nil {
+		return err
+	}
+
+	betaTesterIDs := filterUnlistedTesters(appTesters, testers, inGroup)
+	if len(betaTesterIDs) == 0 {
+		return nil
+	}
+
+	if err := c.reserveTesterRemovals(ctx, len(betaTesterIDs)); err != nil {
+		return err
+	}
+
+	for i := range betaTesterIDs {
+		id := betaTesterIDs[i]
+
+		g.Go(func() error {
+			ctx.Log.WithField("tester", id).Debug("remove individual beta tester")
+			_, err := c.client.TestFlight.RemoveSingleBetaTesterAccessApps(ctx, id, []string{appID})
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	ctx.Log.WithField("removed", len(betaTesterIDs)).Info("synced individual beta testers")
+
+	return nil
+}
+
+func (c *ascClient) ListBetaGroupTesters(ctx *context.Context, appID string) ([]config.TesterRecord, error) {
+	groups, err := c.listBetaGroups(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	var records = make([]config.TesterRecord, 0)
+
+	// Map of tester IDs -> index of their record
+	var indices = make(map[string]int)
+
+	for _, group := range groups {
+		if group.Attributes == nil || group.Attributes.Name == nil {
+			continue
+		}
+
+		groupTesters, err := c.listBetaTestersForBetaGroup(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tester := range groupTesters {
+			if tester.Attributes == nil || tester.Attributes.Email == nil {
+				continue
+			}
+
+			i, ok := indices[tester.ID]
+			if !ok {
+				i = len(records)
+				indices[tester.ID] = i
+				record := config.TesterRecord{
+					BetaTester: config.BetaTester{Email: string(*tester.Attributes.Email)},
+				}
+
+				if tester.Attributes.FirstName != nil {
+					record.FirstName = *tester.Attributes.FirstName
+				}
+
+				if tester.Attributes.LastName != nil {
+					record.LastName = *tester.Attributes.LastName
+				}
+
+				records = append(records, record)
+			}
+
+			records[i].Groups = append(records[i].Groups, *group.Attributes.Name)
+		}
+	}
+
+	return records, nil
+}
+
 func (c *ascClient) listBetaTesters(ctx *context.Context, appID string, config []config.BetaTester) ([]asc.BetaTester, error) {
 	emailFilters := make([]string, 0)
 	firstNameFilters := make([]string, 0)
@@ -533,3 +1089,184 @@ func (c *ascClient) SubmitBetaApp(ctx *context.Context, buildID string) error {
 
 	return err
 }
+
+func (c *ascClient) GetBetaReviewState(ctx *context.Context, buildID string) (asc.BetaReviewState, error) {
+	resp, _, err := c.client.TestFlight.ListBetaAppReviewSubmissions(ctx, &asc.ListBetaAppReviewSubmissionsQuery{
+		FilterBuild: []string{buildID},
+		Limit:       1,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.Data) == 0 || resp.Data[0].Attributes == nil || resp.Data[0].Attributes.BetaReviewState == nil {
+		return "", nil
+	}
+
+	return *resp.Data[0].Attributes.BetaReviewState, nil
+}
+
+func (c *ascClient) NotifyBetaTesters(ctx *context.Context, buildID string) error {
+	_, _, err := c.client.TestFlight.CreateAvailableBuildNotification(ctx, buildID)
+
+	return err
+}
+
+// listBetaGroups returns every beta group of the app, following all pages of results.
+func (c *ascClient) listBetaGroups(ctx *context.Context, appID string) ([]asc.BetaGroup, error) {
+	query := asc.ListBetaGroupsQuery{
+		FilterApp: []string{appID},
+		Limit:     betaGroupsLimit,
+	}
+
+	var groups []asc.BetaGroup
+
+	for {
+		resp, _, err := c.client.TestFlight.ListBetaGroups(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, resp.Data...)
+
+		query.Cursor, err = nextCursor("beta groups", query.Cursor, len(groups), resp.Links, resp.Meta)
+		if err != nil {
+			return nil, err
+		} else if query.Cursor == "" {
+			return groups, nil
+		}
+	}
+}
+
+// buildPrereleaseVersion returns the pre-release version of the build, which carries its platform.
+func (c *ascClient) buildPrereleaseVersion(ctx *context.Context, buildID string) (*asc.PrereleaseVersion, error) {
+	resp, _, err := c.client.TestFlight.GetPrereleaseVersionForBuild(ctx, buildID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Data.Attributes == nil || resp.Data.Attributes.Platform == nil {
+		return nil, errBuildNoPlatform{id: buildID}
+	}
+
+	return &resp.Data, nil
+}
+
+// listBuilds returns every build matching the query, following all pages of results.
+func (c *ascClient) listBuilds(ctx *context.Context, query asc.ListBuildsQuery) ([]asc.Build, error) {
+	var builds []asc.Build
+
+	for {
+		resp, _, err := c.client.Builds.ListBuilds(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, resp.Data...)
+
+		query.Cursor, err = nextCursor("builds", query.Cursor, len(builds), resp.Links, resp.Meta)
+		if err != nil {
+			return nil, err
+		} else if query.Cursor == "" {
+			return builds, nil
+		}
+	}
+}
+
+// listBetaTesterIDsForBetaGroup returns the IDs of every beta tester in the beta group, following all pages of
+// results.
+func (c *ascClient) listBetaTesterIDsForBetaGroup(ctx *context.Context, groupID string) ([]string, error) {
+	query := asc.ListBetaTesterIDsForBetaGroupQuery{
+		Limit: betaTestersLimit,
+	}
+
+	var ids []string
+
+	for {
+		resp, _, err := c.client.TestFlight.ListBetaTesterIDsForBetaGroup(ctx, groupID, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rel := range resp.Data {
+			ids = append(ids, rel.ID)
+		}
+
+		query.Cursor, err = nextCursor("beta testers", query.Cursor, len(ids), resp.Links, resp.Meta)
+		if err != nil {
+			return nil, err
+		} else if query.Cursor == "" {
+			return ids, nil
+		}
+	}
+}
+
+// listBetaTestersForBetaGroup returns every beta tester in the beta group, following all pages of results.
+func (c *ascClient) listBetaTestersForBetaGroup(ctx *context.Context, groupID string) ([]asc.BetaTester, error) {
+	query := asc.ListBetaTestersForBetaGroupQuery{
+		Limit: betaTestersLimit,
+	}
+
+	var testers []asc.BetaTester
+
+	for {
+		resp, _, err := c.client.TestFlight.ListBetaTestersForBetaGroup(ctx, groupID, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		testers = append(testers, resp.Data...)
+
+		query.Cursor, err = nextCursor("beta testers", query.Cursor, len(testers), resp.Links, resp.Meta)
+		if err != nil {
+			return nil, err
+		} else if query.Cursor == "" {
+			return testers, nil
+		}
+	}
+}
+
+// listBetaTestersForApp returns every beta tester of the app, following all pages of results.
+func (c *ascClient) listBetaTestersForApp(ctx *context.Context, appID string) ([]asc.BetaTester, error) {
+	query := asc.ListBetaTestersQuery{
+		FilterApps: []string{appID},
+		Limit:      betaTestersLimit,
+	}
+
+	var testers []asc.BetaTester
+
+	for {
+		resp, _, err := c.client.TestFlight.ListBetaTesters(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		testers = append(testers, resp.Data...)
+
+		query.Cursor, err = nextCursor("beta testers", query.Cursor, len(testers), resp.Links, resp.Meta)
+		if err != nil {
+			return nil, err
+		} else if query.Cursor == "" {
+			return testers, nil
+		}
+	}
+}
+
+// nextCursor returns the cursor of the page after the one with links, or an empty string after the last page.
+// It fails if the cursor does not advance, or if fewer items were read than App Store Connect reports in total.
+func nextCursor(kind string, cursor string, read int, links asc.PagedDocumentLinks, meta *asc.PagingInformation) (string, error) {
+	if links.Next == nil {
+		if meta != nil && meta.Paging.Total > read {
+			return "", errIncompleteList{Kind: kind}
+		}
+
+		return "", nil
+	}
+
+	next := links.Next.Cursor()
+	if next == "" || next == cursor {
+		return "", errIncompleteList{Kind: kind}
+	}
+
+	return next, nil
+}
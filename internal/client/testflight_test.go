@@ -23,6 +23,7 @@ package client
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/cidertool/asc-go/asc"
 	"github.com/cidertool/cider/pkg/config"
@@ -350,6 +351,122 @@ func TestUpdateBetaBuildLocalizations_ErrCreate(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// Test GetPreviousBuildWhatsNew
+
+func TestGetPreviousBuildWhatsNew_Happy(t *testing.T) {
+	t.Parallel()
+
+	locale := func(locale string, whatsNew string) asc.BetaBuildLocalization {
+		return asc.BetaBuildLocalization{
+			Attributes: &asc.BetaBuildLocalizationAttributes{
+				Locale:   asc.String(locale),
+				WhatsNew: asc.String(whatsNew),
+			},
+		}
+	}
+
+	ctx, client := newTestContext(
+		prereleaseVersionResponse(testID),
+		response{
+			RawResponse: `{"data":[{"id":"next"}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/builds?cursor=builds2"}}`,
+		},
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: testID}, {ID: "previous"}},
+			},
+		},
+		response{
+			Response: asc.BetaBuildLocalizationsResponse{
+				Data: []asc.BetaBuildLocalization{
+					locale("de-DE", "Fehler behoben"),
+					locale("en-GB", ""),
+					locale("en-US", "Fixed bugs"),
+				},
+			},
+		},
+	)
+	defer ctx.Close()
+
+	notes, err := client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-GB", "en-US"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Fixed bugs", notes)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: testID}, {ID: "previous"}},
+			},
+		},
+		response{
+			Response: asc.BetaBuildLocalizationsResponse{
+				Data: []asc.BetaBuildLocalization{
+					locale("de-DE", "Fehler behoben"),
+				},
+			},
+		},
+	)
+
+	notes, err = client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-US"})
+	assert.NoError(t, err)
+	assert.Empty(t, notes)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: testID}},
+			},
+		},
+	)
+
+	notes, err = client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-US"})
+	assert.NoError(t, err)
+	assert.Empty(t, notes)
+}
+
+func TestGetPreviousBuildWhatsNew_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-US"})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	_, err = client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-US"})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: testID}, {ID: "previous"}},
+			},
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	_, err = client.GetPreviousBuildWhatsNew(ctx.Context, testID, testID, []string{"en-US"})
+	assert.Error(t, err)
+}
+
 // Test UpdateBetaLicenseAgreement
 
 func TestUpdateBetaLicenseAgreement_Happy(t *testing.T) {
@@ -604,192 +721,1126 @@ func TestAssignBetaGroups_ErrCreate(t *testing.T) {
 	assert.Error(t, err)
 }
 
-// Test AssignBetaTesters
-
-func TestAssignBetaTesters_Happy(t *testing.T) {
+func TestAssignBetaGroups_Internal(t *testing.T) {
 	t.Parallel()
 
 	ctx, client := newTestContext(
 		response{
-			Response: asc.BetaTestersResponse{
-				Data: []asc.BetaTester{
-					{ID: testID},
-					{ID: testID},
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{
+						ID: testID,
+						Attributes: &asc.BetaGroupAttributes{
+							Name:            asc.String("Team"),
+							IsInternalGroup: asc.Bool(true),
+						},
+					},
 				},
 			},
 		},
 		response{
 			RawResponse: `{}`,
 		},
-		response{
-			RawResponse: `{}`,
-		},
-		response{
-			RawResponse: `{}`,
-		},
 	)
 	defer ctx.Close()
 
-	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{
-		{
-			Email:     "test@email.com",
-			FirstName: "John",
-			LastName:  "Doe",
-		},
-		{
-			Email:     "test+1@email.com",
-			FirstName: "Jane",
-			LastName:  "Doe",
-		},
-		{
-			Email:     "test+2@email.com",
-			FirstName: "Joel",
-			LastName:  "Doe",
-		},
+	err := client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: "Team", IsInternal: true, HasAccessToAllBuilds: true},
 	})
 	assert.NoError(t, err)
 }
 
-func TestAssignBetaTesters_WarnNoTestersInput(t *testing.T) {
+func TestAssignBetaGroups_CreateInternal(t *testing.T) {
 	t.Parallel()
 
-	ctx, client := newTestContext()
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			StatusCode:  http.StatusCreated,
+			RawResponse: `{"data":{"type":"betaGroups","id":"TEST","attributes":{"name":"Team","isInternalGroup":true}}}`,
+		},
+	)
 	defer ctx.Close()
 
-	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{})
+	err := client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: "Team", IsInternal: true, HasAccessToAllBuilds: true},
+	})
 	assert.NoError(t, err)
+	assert.Equal(t, len(ctx.Responses), ctx.CurrentResponseIndex)
 }
 
-func TestAssignBetaTesters_ErrList(t *testing.T) {
+func TestAssignBetaGroups_ErrTypeMismatch(t *testing.T) {
 	t.Parallel()
 
 	ctx, client := newTestContext(
 		response{
-			StatusCode:  http.StatusNotFound,
-			RawResponse: `{}`,
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{
+						ID: testID,
+						Attributes: &asc.BetaGroupAttributes{
+							Name:            asc.String("Team"),
+							IsInternalGroup: asc.Bool(true),
+						},
+					},
+				},
+			},
 		},
 	)
 	defer ctx.Close()
 
-	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
-	assert.Error(t, err)
-}
-
-func TestAssignBetaTesters_WarnNoTestersMatching(t *testing.T) {
-	t.Parallel()
+	err := client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: "Team"},
+	})
+	assert.EqualError(t, err, errBetaGroupTypeMismatch{Name: "Team", IsInternal: true}.Error())
 
-	ctx, client := newTestContext(
+	ctx.SetResponses(
 		response{
-			RawResponse: `{"data":[]}`,
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{
+						ID: testID,
+						Attributes: &asc.BetaGroupAttributes{
+							Name: asc.String("Team"),
+						},
+					},
+				},
+			},
 		},
 	)
-	defer ctx.Close()
 
-	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
-	assert.NoError(t, err)
+	err = client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: "Team", IsInternal: true},
+	})
+	assert.EqualError(t, err, errBetaGroupTypeMismatch{Name: "Team", IsInternal: false}.Error())
 }
 
-func TestAssignBetaTesters_ErrAssign(t *testing.T) {
+func TestBetaGroupAttributes(t *testing.T) {
 	t.Parallel()
 
-	testEmail := asc.Email("test@email.com")
+	assert.Equal(t, map[string]interface{}{
+		"name":                 "Team",
+		"feedbackEnabled":      false,
+		"isInternalGroup":      true,
+		"hasAccessToAllBuilds": true,
+	}, betaGroupAttributes(config.BetaGroup{Name: "Team", IsInternal: true, HasAccessToAllBuilds: true, EnablePublicLink: true}))
+
+	assert.Equal(t, map[string]interface{}{
+		"name":                   "Public",
+		"feedbackEnabled":        true,
+		"isInternalGroup":        false,
+		"hasAccessToAllBuilds":   false,
+		"publicLinkEnabled":      true,
+		"publicLinkLimit":        10,
+		"publicLinkLimitEnabled": true,
+	}, betaGroupAttributes(config.BetaGroup{
+		Name:                  "Public",
+		FeedbackEnabled:       true,
+		HasAccessToAllBuilds:  true,
+		EnablePublicLink:      true,
+		EnablePublicLinkLimit: true,
+		PublicLinkLimit:       10,
+	}))
+}
+
+func TestAssignBetaGroups_ExactSync(t *testing.T) {
+	t.Parallel()
+
+	keepEmail := asc.Email("keep@test.com")
+	dropEmail := asc.Email("drop@test.com")
 	ctx, client := newTestContext(
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{
+						ID:         testID,
+						Attributes: &asc.BetaGroupAttributes{Name: asc.String(testID)},
+					},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
 		response{
 			Response: asc.BetaTestersResponse{
 				Data: []asc.BetaTester{
 					{
-						ID: testID,
-						Attributes: &asc.BetaTesterAttributes{
-							Email: &testEmail,
+						ID:         "keep",
+						Attributes: &asc.BetaTesterAttributes{Email: &keepEmail},
+						Relationships: &asc.BetaTesterRelationships{
+							BetaGroups: &asc.PagedRelationship{
+								Data: []asc.RelationshipData{{ID: testID}},
+							},
 						},
 					},
 				},
 			},
 		},
 		response{
-			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "keep", Attributes: &asc.BetaTesterAttributes{Email: &keepEmail}},
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+					{ID: "unknown"},
+				},
+			},
+		},
+		response{
 			RawResponse: `{}`,
 		},
 	)
-
 	defer ctx.Close()
 
-	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
-	assert.Error(t, err)
+	err := client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{
+			Name:    testID,
+			Testers: []config.BetaTester{{Email: "keep@test.com"}},
+			Sync:    config.SyncModeExact,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, len(ctx.Responses), ctx.CurrentResponseIndex)
 }
 
-// Test UpdateBetaReviewDetails
-
-func TestUpdateBetaReviewDetails_Happy(t *testing.T) {
+func TestAssignBetaGroups_ErrTooManyRemovals(t *testing.T) {
 	t.Parallel()
 
+	dropEmail := asc.Email("drop@test.com")
 	ctx, client := newTestContext(
 		response{
-			RawResponse: `{"data":{"id":"TEST"}}`,
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{
+						ID:         testID,
+						Attributes: &asc.BetaGroupAttributes{Name: asc.String(testID)},
+					},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
 		},
 		response{
 			RawResponse: `{}`,
 		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+				},
+			},
+		},
 	)
 	defer ctx.Close()
 
-	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{
-		Contact:     &config.ContactPerson{},
-		DemoAccount: &config.DemoAccount{},
-		Attachments: []config.File{
-			{Path: "friend"},
-		},
+	ctx.Context.MaxTesterRemovals = 0
+
+	err := client.AssignBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: testID, Sync: config.SyncModeExact},
 	})
-	assert.NoError(t, err)
+	assert.EqualError(t, err, "refusing to remove 1 beta testers after 0 were already removed, which would exceed the limit of 0 removals per run")
 }
 
-func TestUpdateBetaReviewDetails_ErrGet(t *testing.T) {
+// Test ListBetaGroupPublicLinks
+
+func TestListBetaGroupPublicLinks_Happy(t *testing.T) {
 	t.Parallel()
 
 	ctx, client := newTestContext(
 		response{
-			StatusCode:  http.StatusNotFound,
-			RawResponse: `{}`,
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{ID: "1", Attributes: &asc.BetaGroupAttributes{
+						Name:              asc.String("Public"),
+						PublicLink:        asc.String("https://testflight.apple.com/join/TEST"),
+						PublicLinkEnabled: asc.Bool(true),
+					}},
+					{ID: "2", Attributes: &asc.BetaGroupAttributes{
+						Name:              asc.String("Disabled"),
+						PublicLink:        asc.String("https://testflight.apple.com/join/OLD"),
+						PublicLinkEnabled: asc.Bool(false),
+					}},
+					{ID: "3", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Private")}},
+					{ID: "4"},
+				},
+			},
 		},
 	)
 	defer ctx.Close()
 
-	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{})
-	assert.Error(t, err)
+	links, err := client.ListBetaGroupPublicLinks(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Public": "https://testflight.apple.com/join/TEST",
+	}, links)
 }
 
-func TestUpdateBetaReviewDetails_ErrUpdate(t *testing.T) {
+func TestListBetaGroupPublicLinks_Paged(t *testing.T) {
 	t.Parallel()
 
 	ctx, client := newTestContext(
 		response{
-			RawResponse: `{"data":{"id":"TEST"}}`,
+			RawResponse: `{"data":[{"id":"1","attributes":{"name":"QA","publicLink":"https://testflight.apple.com/join/QA","publicLinkEnabled":true}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups?cursor=groups2"}}`,
 		},
 		response{
-			StatusCode:  http.StatusNotFound,
-			RawResponse: `{}`,
+			RawResponse: `{"data":[{"id":"2","attributes":{"name":"Public","publicLink":"https://testflight.apple.com/join/TEST","publicLinkEnabled":true}}],"links":{"self":""}}`,
 		},
 	)
 	defer ctx.Close()
 
-	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{})
-	assert.Error(t, err)
+	links, err := client.ListBetaGroupPublicLinks(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"QA":     "https://testflight.apple.com/join/QA",
+		"Public": "https://testflight.apple.com/join/TEST",
+	}, links)
 }
 
-// Test SubmitBetaApp
-
-func TestSubmitBetaApp_Happy(t *testing.T) {
+func TestListBetaGroupPublicLinks_Err(t *testing.T) {
 	t.Parallel()
 
 	ctx, client := newTestContext(
 		response{
+			StatusCode:  http.StatusNotFound,
 			RawResponse: `{}`,
 		},
 	)
 	defer ctx.Close()
 
-	err := client.SubmitBetaApp(ctx.Context, testID)
-	assert.NoError(t, err)
+	_, err := client.ListBetaGroupPublicLinks(ctx.Context, testID)
+	assert.Error(t, err)
+}
+
+// Test RemovePreviousBuildsFromBetaGroups
+
+func prereleaseVersionResponse(id string) response {
+	platform := asc.PlatformIOS
+
+	return response{
+		Response: asc.PrereleaseVersionResponse{
+			Data: asc.PrereleaseVersion{
+				ID: id,
+				Attributes: &asc.PrereleaseVersionAttributes{
+					Platform: &platform,
+				},
+			},
+		},
+	}
+}
+
+func TestRemovePreviousBuildsFromBetaGroups_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{ID: "1", Attributes: &asc.BetaGroupAttributes{Name: asc.String("QA")}},
+					{ID: "2", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Team")}},
+					{ID: "3", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Other")}},
+					{ID: "4"},
+				},
+			},
+		},
+		response{
+			RawResponse: `{"data":[{"id":"TEST"}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/builds?cursor=builds2"}}`,
+		},
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: "old"}},
+			},
+		},
+		response{},
+	)
+	defer ctx.Close()
+
+	err := client.RemovePreviousBuildsFromBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{
+		{Name: "QA"},
+		{Name: "Team", IsInternal: true, HasAccessToAllBuilds: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, ctx.CurrentResponseIndex)
+}
+
+func TestRemovePreviousBuildsFromBetaGroups_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemovePreviousBuildsFromBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{{Name: "QA"}})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		response{
+			Response: asc.PrereleaseVersionResponse{
+				Data: asc.PrereleaseVersion{ID: testID},
+			},
+		},
+	)
+
+	err = client.RemovePreviousBuildsFromBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{{Name: "QA"}})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	err = client.RemovePreviousBuildsFromBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{{Name: "QA"}})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{ID: "1", Attributes: &asc.BetaGroupAttributes{Name: asc.String("QA")}},
+				},
+			},
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	err = client.RemovePreviousBuildsFromBetaGroups(ctx.Context, testID, testID, []config.BetaGroup{{Name: "QA"}})
+	assert.Error(t, err)
+}
+
+// Test ExpireBuilds
+
+func TestExpireBuilds_Happy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	build := func(id string, version string, days int) asc.Build {
+		return asc.Build{
+			ID: id,
+			Attributes: &asc.BuildAttributes{
+				UploadedDate: &asc.DateTime{Time: now.AddDate(0, 0, -days)},
+			},
+			Relationships: &asc.BuildRelationships{
+				PreReleaseVersion: &asc.Relationship{
+					Data: &asc.RelationshipData{ID: version},
+				},
+			},
+		}
+	}
+
+	ctx, client := newTestContext(
+		prereleaseVersionResponse("1.0"),
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{
+					build(testID, "1.0", 0),
+					build("2", "1.0", 1),
+					build("3", "0.9", 10),
+					build("4", "0.9", 11),
+					build("5", "0.8", 12),
+				},
+			},
+		},
+		response{},
+		response{},
+	)
+	defer ctx.Close()
+
+	ctx.Context.Date = now
+
+	expired, err := client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{OlderThanDays: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse("1.0"),
+		response{
+			RawResponse: `{"data":[
+				{"id":"2","relationships":{"preReleaseVersion":{"data":{"id":"1.1"}}}},
+				{"id":"TEST","relationships":{"preReleaseVersion":{"data":{"id":"1.0"}}}},
+				{"id":"3","relationships":{"preReleaseVersion":{"data":{"id":"1.1"}}}},
+				{"id":"4","relationships":{"preReleaseVersion":{"data":{"id":"1.0"}}}},
+				{"id":"5","relationships":{"preReleaseVersion":{"data":{"id":"0.9"}}}}
+			],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/builds?cursor=builds2"}}`,
+		},
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{
+					build("6", "0.9", 6),
+					build("7", "0.8", 7),
+				},
+			},
+		},
+		response{},
+		response{},
+		response{},
+	)
+
+	expired, err = client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{KeepLatest: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+
+	expired, err = client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+}
+
+func TestExpireBuilds_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{KeepLatest: 1})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	_, err = client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{KeepLatest: 1})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			RawResponse: `{"data":[{"id":"TEST"}],"links":{"self":""},"meta":{"paging":{"limit":200,"total":2}}}`,
+		},
+	)
+
+	_, err = client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{KeepLatest: 1})
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		prereleaseVersionResponse(testID),
+		response{
+			Response: asc.BuildsResponse{
+				Data: []asc.Build{{ID: testID}, {ID: "2"}},
+			},
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	expired, err := client.ExpireBuilds(ctx.Context, testID, testID, config.ExpireBuilds{KeepLatest: 1})
+	assert.Error(t, err)
+	assert.Equal(t, 0, expired)
+}
+
+// Test PruneBetaGroups
+
+func pruneBetaGroupsResponse() response {
+	return response{
+		Response: asc.BetaGroupsResponse{
+			Data: []asc.BetaGroup{
+				{ID: "1", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Sprint 42")}},
+				{ID: "2", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Sprint 41")}},
+				{ID: "3", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Sprint 40"), IsInternalGroup: asc.Bool(true)}},
+				{ID: "4", Attributes: &asc.BetaGroupAttributes{Name: asc.String("QA")}},
+				{ID: "5"},
+			},
+		},
+	}
+}
+
+func TestPruneBetaGroups_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		pruneBetaGroupsResponse(),
+		response{},
+	)
+	defer ctx.Close()
+
+	pruned, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{
+		{Name: "Sprint 42"},
+		{Name: "QA"},
+	}, "Sprint *")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sprint 41"}, pruned)
+}
+
+func TestPruneBetaGroups_DryRun(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		pruneBetaGroupsResponse(),
+	)
+	defer ctx.Close()
+
+	ctx.Context.PruneDryRun = true
+
+	pruned, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sprint 42", "Sprint 41", "QA"}, pruned)
+}
+
+func TestPruneBetaGroups_Paged(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"1","attributes":{"name":"Sprint 42"}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups?cursor=groups2"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"2","attributes":{"name":"Sprint 41"}},{"id":"3","attributes":{"name":"Team/Sprint 40"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	ctx.Context.PruneDryRun = true
+
+	pruned, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sprint 42", "Sprint 41"}, pruned)
+}
+
+func TestPruneBetaGroups_ErrPattern(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	_, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "")
+	assert.ErrorIs(t, err, errMissingPrunePattern)
+
+	_, err = client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "Sprint [")
+	assert.Error(t, err)
+}
+
+func TestPruneBetaGroups_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "*")
+	assert.Error(t, err)
+}
+
+func TestPruneBetaGroups_ErrDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		pruneBetaGroupsResponse(),
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.PruneBetaGroups(ctx.Context, testID, []config.BetaGroup{}, "QA")
+	assert.Error(t, err)
+}
+
+// Test AssignBetaTesters
+
+func TestAssignBetaTesters_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: testID},
+					{ID: testID},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{
+		{
+			Email:     "test@email.com",
+			FirstName: "John",
+			LastName:  "Doe",
+		},
+		{
+			Email:     "test+1@email.com",
+			FirstName: "Jane",
+			LastName:  "Doe",
+		},
+		{
+			Email:     "test+2@email.com",
+			FirstName: "Joel",
+			LastName:  "Doe",
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestAssignBetaTesters_WarnNoTestersInput(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{})
+	assert.NoError(t, err)
+}
+
+func TestAssignBetaTesters_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
+	assert.Error(t, err)
+}
+
+func TestAssignBetaTesters_WarnNoTestersMatching(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
+	assert.NoError(t, err)
+}
+
+func TestAssignBetaTesters_ErrAssign(t *testing.T) {
+	t.Parallel()
+
+	testEmail := asc.Email("test@email.com")
+	ctx, client := newTestContext(
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{
+						ID: testID,
+						Attributes: &asc.BetaTesterAttributes{
+							Email: &testEmail,
+						},
+					},
+				},
+			},
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	defer ctx.Close()
+
+	err := client.AssignBetaTesters(ctx.Context, testID, testID, []config.BetaTester{{}})
+	assert.Error(t, err)
+}
+
+// Test RemoveUnlistedBetaTesters
+
+func TestRemoveUnlistedBetaTesters_Happy(t *testing.T) {
+	t.Parallel()
+
+	memberEmail := asc.Email("member@test.com")
+	listedEmail := asc.Email("listed@test.com")
+	dropEmail := asc.Email("drop@test.com")
+	ctx, client := newTestContext(
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{{ID: testID}},
+			},
+		},
+		response{
+			Response: asc.BetaGroupBetaTestersLinkagesResponse{
+				Data: []asc.RelationshipData{{ID: "member"}},
+			},
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "member", Attributes: &asc.BetaTesterAttributes{Email: &memberEmail}},
+					{ID: "listed", Attributes: &asc.BetaTesterAttributes{Email: &listedEmail}},
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, []config.BetaTester{{Email: "listed@test.com"}})
+	assert.NoError(t, err)
+	assert.Equal(t, len(ctx.Responses), ctx.CurrentResponseIndex)
+}
+
+func TestRemoveUnlistedBetaTesters_Paged(t *testing.T) {
+	t.Parallel()
+
+	memberEmail := asc.Email("member@test.com")
+	dropEmail := asc.Email("drop@test.com")
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"group1"}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups?cursor=groups2"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"group2"}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups/group2/relationships/betaTesters?cursor=testers2"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"type":"betaTesters","id":"member"}],"links":{"self":""}}`,
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "member", Attributes: &asc.BetaTesterAttributes{Email: &memberEmail}},
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+				},
+			},
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	ctx.Context.MaxTesterRemovals = 1
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, len(ctx.Responses), ctx.CurrentResponseIndex)
+}
+
+func TestRemoveUnlistedBetaTesters_ErrIncompleteList(t *testing.T) {
+	t.Parallel()
+
+	dropEmail := asc.Email("drop@test.com")
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[],"links":{"self":""}}`,
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+				},
+				Meta: &asc.PagingInformation{Paging: struct {
+					Limit int `json:"limit"`
+					Total int `json:"total"`
+				}{Limit: betaTestersLimit, Total: 2}},
+			},
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.EqualError(t, err, errIncompleteList{Kind: "beta testers"}.Error())
+	assert.Equal(t, len(ctx.Responses), ctx.CurrentResponseIndex)
+}
+
+func TestRemoveUnlistedBetaTesters_ErrCursorNotAdvanced(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"group1"}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups"}}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.EqualError(t, err, errIncompleteList{Kind: "beta groups"}.Error())
+}
+
+func TestRemoveUnlistedBetaTesters_NoneUnlisted(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.NoError(t, err)
+}
+
+func TestRemoveUnlistedBetaTesters_ErrList(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.Error(t, err)
+}
+
+func TestRemoveUnlistedBetaTesters_ErrTooManyRemovals(t *testing.T) {
+	t.Parallel()
+
+	dropEmail := asc.Email("drop@test.com")
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{
+					{ID: "drop", Attributes: &asc.BetaTesterAttributes{Email: &dropEmail}},
+				},
+			},
+		},
+	)
+	defer ctx.Close()
+
+	ctx.Context.MaxTesterRemovals = 0
+
+	err := client.RemoveUnlistedBetaTesters(ctx.Context, testID, nil)
+	assert.Error(t, err)
+}
+
+// Test ListBetaGroupTesters
+
+func TestListBetaGroupTesters_Happy(t *testing.T) {
+	t.Parallel()
+
+	email := asc.Email("test@example.com")
+	tester := asc.BetaTester{
+		ID: testID,
+		Attributes: &asc.BetaTesterAttributes{
+			Email:     &email,
+			FirstName: asc.String("Person"),
+		},
+	}
+	ctx, client := newTestContext(
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{ID: "1", Attributes: &asc.BetaGroupAttributes{Name: asc.String("QA")}},
+					{ID: "2", Attributes: &asc.BetaGroupAttributes{Name: asc.String("Staging")}},
+					{ID: "3"},
+				},
+			},
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{tester, {ID: "noemail"}},
+			},
+		},
+		response{
+			Response: asc.BetaTestersResponse{
+				Data: []asc.BetaTester{tester},
+			},
+		},
+	)
+	defer ctx.Close()
+
+	records, err := client.ListBetaGroupTesters(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.TesterRecord{
+		{
+			BetaTester: config.BetaTester{Email: "test@example.com", FirstName: "Person"},
+			Groups:     []string{"QA", "Staging"},
+		},
+	}, records)
+}
+
+func TestListBetaGroupTesters_Paged(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"1","attributes":{"name":"QA"}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups?cursor=groups2"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"2","attributes":{"name":"Staging"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"a","attributes":{"email":"a@example.com"}}],"links":{"self":"","next":"https://api.appstoreconnect.apple.com/v1/betaGroups/1/betaTesters?cursor=testers2"}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"b","attributes":{"email":"b@example.com"}}],"links":{"self":""}}`,
+		},
+		response{
+			RawResponse: `{"data":[{"id":"b","attributes":{"email":"b@example.com"}}],"links":{"self":""}}`,
+		},
+	)
+	defer ctx.Close()
+
+	records, err := client.ListBetaGroupTesters(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Equal(t, []config.TesterRecord{
+		{BetaTester: config.BetaTester{Email: "a@example.com"}, Groups: []string{"QA"}},
+		{BetaTester: config.BetaTester{Email: "b@example.com"}, Groups: []string{"QA", "Staging"}},
+	}, records)
+}
+
+func TestListBetaGroupTesters_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.ListBetaGroupTesters(ctx.Context, testID)
+	assert.Error(t, err)
+
+	ctx.SetResponses(
+		response{
+			Response: asc.BetaGroupsResponse{
+				Data: []asc.BetaGroup{
+					{ID: "1", Attributes: &asc.BetaGroupAttributes{Name: asc.String("QA")}},
+				},
+			},
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+
+	_, err = client.ListBetaGroupTesters(ctx.Context, testID)
+	assert.Error(t, err)
+}
+
+// Test UpdateBetaReviewDetails
+
+func TestUpdateBetaReviewDetails_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":{"id":"TEST"}}`,
+		},
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{
+		Contact:     &config.ContactPerson{},
+		DemoAccount: &config.DemoAccount{},
+		Attachments: []config.File{
+			{Path: "friend"},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateBetaReviewDetails_ErrGet(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{})
+	assert.Error(t, err)
+}
+
+func TestUpdateBetaReviewDetails_ErrUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":{"id":"TEST"}}`,
+		},
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.UpdateBetaReviewDetails(ctx.Context, testID, config.ReviewDetails{})
+	assert.Error(t, err)
+}
+
+// Test SubmitBetaApp
+
+func TestSubmitBetaApp_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.SubmitBetaApp(ctx.Context, testID)
+	assert.NoError(t, err)
 }
 
 func TestSubmitBetaApp_Err(t *testing.T) {
@@ -806,3 +1857,73 @@ func TestSubmitBetaApp_Err(t *testing.T) {
 	err := client.SubmitBetaApp(ctx.Context, testID)
 	assert.Error(t, err)
 }
+
+// Test GetBetaReviewState
+
+func TestGetBetaReviewState_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data":[{"id":"TEST","attributes":{"betaReviewState":"APPROVED"}}]}`,
+		},
+		response{
+			RawResponse: `{"data":[]}`,
+		},
+	)
+	defer ctx.Close()
+
+	state, err := client.GetBetaReviewState(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Equal(t, asc.BetaReviewStateApproved, state)
+
+	state, err = client.GetBetaReviewState(ctx.Context, testID)
+	assert.NoError(t, err)
+	assert.Empty(t, state)
+}
+
+func TestGetBetaReviewState_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.GetBetaReviewState(ctx.Context, testID)
+	assert.Error(t, err)
+}
+
+// Test NotifyBetaTesters
+
+func TestNotifyBetaTesters_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			Response: asc.BuildBetaNotificationResponse{},
+		},
+	)
+	defer ctx.Close()
+
+	err := client.NotifyBetaTesters(ctx.Context, testID)
+	assert.NoError(t, err)
+}
+
+func TestNotifyBetaTesters_Err(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusConflict,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	err := client.NotifyBetaTesters(ctx.Context, testID)
+	assert.Error(t, err)
+}
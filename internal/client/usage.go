@@ -0,0 +1,173 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// BetaTesterStatus is the invitation state of a beta tester of an App, along with their usage of it in TestFlight.
+type BetaTesterStatus struct {
+	Email      string    `json:"email"`
+	FirstName  string    `json:"firstName,omitempty"`
+	LastName   string    `json:"lastName,omitempty"`
+	InviteType string    `json:"inviteType,omitempty"`
+	State      string    `json:"state,omitempty"`
+	Sessions   int       `json:"sessions"`
+	Crashes    int       `json:"crashes"`
+	Feedback   int       `json:"feedback"`
+	LastActive time.Time `json:"lastActive,omitempty"`
+}
+
+// betaTestersStateResponse is a page of betaTesters including their state, which the App Store Connect API
+// client does not model.
+type betaTestersStateResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes *struct {
+			Email      *string `json:"email,omitempty"`
+			FirstName  *string `json:"firstName,omitempty"`
+			LastName   *string `json:"lastName,omitempty"`
+			InviteType *string `json:"inviteType,omitempty"`
+			State      *string `json:"state,omitempty"`
+		} `json:"attributes,omitempty"`
+	} `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+// betaTesterUsagesResponse is a page of betaTesterUsages metrics grouped by beta tester, which the App Store
+// Connect API client does not model.
+type betaTesterUsagesResponse struct {
+	Data []struct {
+		DataPoints []struct {
+			Start  *asc.DateTime `json:"start,omitempty"`
+			End    *asc.DateTime `json:"end,omitempty"`
+			Values struct {
+				CrashCount    int `json:"crashCount"`
+				SessionCount  int `json:"sessionCount"`
+				FeedbackCount int `json:"feedbackCount"`
+			} `json:"values"`
+		} `json:"dataPoints"`
+		Dimensions struct {
+			BetaTesters *asc.Relationship `json:"betaTesters,omitempty"`
+		} `json:"dimensions"`
+	} `json:"data"`
+	Links asc.PagedDocumentLinks `json:"links"`
+}
+
+func (c *ascClient) ListBetaTesterStatuses(ctx *context.Context, appID string, period string) ([]BetaTesterStatus, error) {
+	query := url.Values{}
+	query.Set("filter[apps]", appID)
+	query.Set("limit", strconv.Itoa(betaTestersLimit))
+
+	ref := &asc.Reference{URL: url.URL{Path: "betaTesters", RawQuery: query.Encode()}}
+
+	statuses := make([]BetaTesterStatus, 0)
+
+	// Map of tester IDs -> index of their status
+	var indices = make(map[string]int)
+
+	for ref != nil {
+		var resp betaTestersStateResponse
+
+		if _, err := c.client.FollowReference(ctx, ref, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, tester := range resp.Data {
+			if tester.Attributes == nil || tester.Attributes.Email == nil {
+				continue
+			}
+
+			indices[tester.ID] = len(statuses)
+			statuses = append(statuses, BetaTesterStatus{
+				Email:      *tester.Attributes.Email,
+				FirstName:  stringValue(tester.Attributes.FirstName),
+				LastName:   stringValue(tester.Attributes.LastName),
+				InviteType: stringValue(tester.Attributes.InviteType),
+				State:      stringValue(tester.Attributes.State),
+			})
+		}
+
+		ref = resp.Links.Next
+	}
+
+	query = url.Values{}
+	query.Set("groupBy", "betaTesters")
+	query.Set("period", period)
+	query.Set("limit", strconv.Itoa(betaTestersLimit))
+
+	ref = &asc.Reference{URL: url.URL{
+		Path:     fmt.Sprintf("apps/%s/metrics/betaTesterUsages", appID),
+		RawQuery: query.Encode(),
+	}}
+
+	for ref != nil {
+		var resp betaTesterUsagesResponse
+
+		if _, err := c.client.FollowReference(ctx, ref, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, usage := range resp.Data {
+			if usage.Dimensions.BetaTesters == nil || usage.Dimensions.BetaTesters.Data == nil {
+				continue
+			}
+
+			i, ok := indices[usage.Dimensions.BetaTesters.Data.ID]
+			if !ok {
+				continue
+			}
+
+			for _, point := range usage.DataPoints {
+				statuses[i].Sessions += point.Values.SessionCount
+				statuses[i].Crashes += point.Values.CrashCount
+				statuses[i].Feedback += point.Values.FeedbackCount
+
+				if point.Values.SessionCount > 0 && point.End != nil && point.End.After(statuses[i].LastActive) {
+					statuses[i].LastActive = point.End.Time
+				}
+			}
+		}
+
+		ref = resp.Links.Next
+	}
+
+	return statuses, nil
+}
+
+// FindBetaTesterStatus returns the status of the tester with the given email, compared regardless of case.
+func FindBetaTesterStatus(statuses []BetaTesterStatus, email string) (BetaTesterStatus, bool) {
+	for _, status := range statuses {
+		if strings.EqualFold(status.Email, email) {
+			return status, true
+		}
+	}
+
+	return BetaTesterStatus{}, false
+}
@@ -0,0 +1,154 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ListBetaTesterStatuses
+
+func TestListBetaTesterStatuses_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext()
+	defer ctx.Close()
+
+	next, err := ctx.URL("v1/apps/TEST/metrics/betaTesterUsages?cursor=2")
+	assert.NoError(t, err)
+
+	ctx.SetResponses(
+		response{
+			RawResponse: `{
+				"data": [
+					{
+						"id": "1",
+						"attributes": {
+							"email": "test@example.com",
+							"firstName": "Person",
+							"lastName": "Personson",
+							"inviteType": "EMAIL",
+							"state": "INSTALLED"
+						}
+					},
+					{"id": "2", "attributes": {"email": "other@example.com", "state": "INVITED"}},
+					{"id": "noattributes"}
+				],
+				"links": {"self": ""}
+			}`,
+		},
+		response{
+			RawResponse: `{
+				"data": [
+					{
+						"dataPoints": [
+							{"start": "2021-03-25T00:00:00Z", "end": "2021-03-26T00:00:00Z", "values": {"sessionCount": 2, "crashCount": 1}},
+							{"start": "2021-03-31T00:00:00Z", "end": "2021-04-01T00:00:00Z", "values": {"sessionCount": 3, "feedbackCount": 1}},
+							{"start": "2021-04-01T00:00:00Z", "end": "2021-04-02T00:00:00Z", "values": {}}
+						],
+						"dimensions": {"betaTesters": {"data": {"type": "betaTesters", "id": "1"}}}
+					},
+					{
+						"dataPoints": [{"values": {"sessionCount": 1}}],
+						"dimensions": {"betaTesters": {"data": {"type": "betaTesters", "id": "unknown"}}}
+					},
+					{"dataPoints": [], "dimensions": {}}
+				],
+				"links": {"self": "", "next": "` + next.String() + `"}
+			}`,
+		},
+		response{
+			RawResponse: `{
+				"data": [
+					{
+						"dataPoints": [{"end": "2021-03-20T00:00:00Z", "values": {"sessionCount": 1}}],
+						"dimensions": {"betaTesters": {"data": {"type": "betaTesters", "id": "1"}}}
+					}
+				],
+				"links": {"self": ""}
+			}`,
+		},
+	)
+
+	statuses, err := client.ListBetaTesterStatuses(ctx.Context, testID, "P30D")
+	assert.NoError(t, err)
+	assert.Equal(t, []BetaTesterStatus{
+		{
+			Email:      "test@example.com",
+			FirstName:  "Person",
+			LastName:   "Personson",
+			InviteType: "EMAIL",
+			State:      "INSTALLED",
+			Sessions:   6,
+			Crashes:    1,
+			Feedback:   1,
+			LastActive: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Email: "other@example.com",
+			State: "INVITED",
+		},
+	}, statuses)
+
+	status, ok := FindBetaTesterStatus(statuses, "TEST@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "INSTALLED", status.State)
+
+	_, ok = FindBetaTesterStatus(statuses, "missing@example.com")
+	assert.False(t, ok)
+}
+
+func TestListBetaTesterStatuses_ErrTesters(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			StatusCode:  http.StatusNotFound,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.ListBetaTesterStatuses(ctx.Context, testID, "P30D")
+	assert.Error(t, err)
+}
+
+func TestListBetaTesterStatuses_ErrUsages(t *testing.T) {
+	t.Parallel()
+
+	ctx, client := newTestContext(
+		response{
+			RawResponse: `{"data": [], "links": {"self": ""}}`,
+		},
+		response{
+			StatusCode:  http.StatusForbidden,
+			RawResponse: `{}`,
+		},
+	)
+	defer ctx.Close()
+
+	_, err := client.ListBetaTesterStatuses(ctx.Context, testID, "P30D")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package iap is a pipe that processes the in-app purchases of an app's release to the App Store
+package iap
+
+import (
+	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+// Pipe is a global hook pipe.
+type Pipe struct {
+	Client client.Client
+}
+
+// String is the name of this pipe.
+func (Pipe) String() string {
+	return "committing in-app purchases"
+}
+
+// Publish in-app purchases to App Store Connect. They are submitted for review by the store pipe, along with the
+// version.
+func (p *Pipe) Publish(ctx *context.Context) error {
+	if p.Client == nil {
+		p.Client = client.New(ctx)
+	}
+
+	for _, name := range ctx.AppsToRelease {
+		app, ok := ctx.Config[name]
+		if !ok {
+			return pipe.ErrMissingApp{Name: name}
+		}
+
+		if len(app.InAppPurchases) == 0 {
+			continue
+		}
+
+		ctx.Log.WithField("app", name).Info("updating in-app purchases")
+
+		if err := p.doRelease(ctx, app); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
+	app, err := p.Client.GetAppForBundleID(ctx, config.BundleID)
+	if err != nil {
+		return err
+	}
+
+	if ctx.SkipUpdateMetadata {
+		ctx.Log.Warn("skipping updating metdata")
+
+		return nil
+	}
+
+	ctx.Log.Infof("updating %d in-app purchases", len(config.InAppPurchases))
+
+	return p.Client.UpdateInAppPurchases(ctx, app.ID, config.InAppPurchases)
+}
@@ -0,0 +1,111 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package iap
+
+import (
+	"testing"
+
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIAP_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			InAppPurchases: config.InAppPurchases{
+				"com.test.TEST.coins": {
+					ReferenceName: "Coins",
+					Type:          config.InAppPurchaseTypeConsumable,
+					Price:         "0.99",
+					Localizations: config.InAppPurchaseLocalizations{
+						"en-US": {Name: "Coins", Description: "A pile of coins"},
+					},
+					ReviewScreenshot: &config.File{Path: "TEST"},
+					ReviewNotes:      "TEST",
+				},
+			},
+		},
+		"NOIAPS": {
+			BundleID: "com.test.NOIAPS",
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST", "NOIAPS"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	assert.Equal(t, "committing in-app purchases", p.String())
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestIAP_Happy_Skips(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			InAppPurchases: config.InAppPurchases{
+				"com.test.TEST.coins": {ReferenceName: "Coins"},
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+	ctx.SkipUpdateMetadata = true
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestIAP_Happy_NoApps(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.Credentials = &clienttest.Credentials{}
+
+	p := Pipe{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestIAP_Err_MissingApp(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.EqualError(t, err, pipe.ErrMissingApp{Name: "TEST"}.Error())
+}
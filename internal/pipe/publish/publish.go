@@ -27,6 +27,7 @@ import (
 	"github.com/cidertool/cider/internal/client"
 	"github.com/cidertool/cider/internal/middleware"
 	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/internal/pipe/iap"
 	"github.com/cidertool/cider/internal/pipe/store"
 	"github.com/cidertool/cider/internal/pipe/testflight"
 	"github.com/cidertool/cider/pkg/context"
@@ -64,23 +65,26 @@ func (p Pipe) Run(ctx *context.Context) error {
 		return pipe.ErrSkipNoAppsToPublish
 	}
 
-	var publisher Publisher
+	var publishers []Publisher
 
 	switch ctx.PublishMode {
 	case context.PublishModeTestflight:
-		publisher = &testflight.Pipe{Client: p.client}
+		publishers = []Publisher{&testflight.Pipe{Client: p.client}}
 	case context.PublishModeAppStore:
-		publisher = &store.Pipe{Client: p.client}
+		// In-app purchases are updated before the version, and submitted along with it by the store pipe
+		publishers = []Publisher{&iap.Pipe{Client: p.client}, &store.Pipe{Client: p.client}}
 	default:
 		return errUnsupportedPublishMode{ctx.PublishMode}
 	}
 
-	if err := middleware.Logging(
-		publisher.String(),
-		middleware.ErrHandler(publisher.Publish),
-		middleware.ExtraPadding,
-	)(ctx); err != nil {
-		return fmt.Errorf("%s: failed to publish: %w", publisher.String(), err)
+	for _, publisher := range publishers {
+		if err := middleware.Logging(
+			publisher.String(),
+			middleware.ErrHandler(publisher.Publish),
+			middleware.ExtraPadding,
+		)(ctx); err != nil {
+			return fmt.Errorf("%s: failed to publish: %w", publisher.String(), err)
+		}
 	}
 
 	return nil
@@ -76,51 +76,86 @@ func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
 
 	ctx.VersionIsInitialRelease = isInitial
 
-	build, err := p.Client.GetBuild(ctx, app)
-	if err != nil {
-		return err
-	}
+	versionConfigs := config.Versions.PlatformVersions()
+	versions := make([]*asc.AppStoreVersion, len(versionConfigs))
 
-	version, err := p.Client.CreateVersionIfNeeded(ctx, app.ID, build.ID, config.Versions)
-	if err != nil {
-		return err
-	}
+	for i, versionConfig := range versionConfigs {
+		build, err := p.Client.GetBuildForPlatform(ctx, app, versionConfig.Platform)
+		if err != nil {
+			return err
+		}
+
+		version, err := p.Client.CreateVersionIfNeeded(ctx, app.ID, build.ID, versionConfig)
+		if err != nil {
+			return err
+		}
 
-	ctx.Log.WithFields(log.Fields{
-		"app":     *app.Attributes.BundleID,
-		"build":   *build.Attributes.Version,
-		"version": *version.Attributes.VersionString,
-	}).Info("found resources")
+		ctx.Log.WithFields(log.Fields{
+			"app":      *app.Attributes.BundleID,
+			"platform": versionConfig.Platform,
+			"build":    *build.Attributes.Version,
+			"version":  *version.Attributes.VersionString,
+		}).Info("found resources")
+
+		versions[i] = version
+	}
 
 	if ctx.SkipUpdateMetadata {
 		ctx.Log.Warn("skipping updating metdata")
 	} else {
 		ctx.Log.Info("updating metadata")
-		if err := p.updateVersionDetails(ctx, config, app, version); err != nil {
+		if err := p.updateAppDetails(ctx, config, app, versions[0]); err != nil {
 			return err
 		}
+
+		for i, versionConfig := range versionConfigs {
+			if err := p.updateVersionDetails(ctx, config, versionConfig, app, versions[i]); err != nil {
+				return err
+			}
+		}
 	}
 
 	if ctx.SkipSubmit {
 		return pipe.ErrSkipSubmitEnabled
 	}
 
-	if config.Versions.PhasedReleaseEnabled && !ctx.VersionIsInitialRelease {
-		ctx.Log.Info("preparing phased release details")
-
-		if err := p.Client.EnablePhasedRelease(ctx, version.ID); err != nil {
+	if len(config.InAppPurchases) > 0 {
+		// In-app purchases are submitted right before the versions, so they are reviewed along with them
+		submitted, err := p.Client.SubmitInAppPurchases(ctx, app.ID, config.InAppPurchases)
+		if err != nil {
 			return err
 		}
+
+		ctx.Log.Infof("submitted %d in-app purchases with the version", submitted)
 	}
 
-	ctx.Log.
-		WithField("version", *version.Attributes.VersionString).
-		Info("submitting to app store")
+	for i, versionConfig := range versionConfigs {
+		version := versions[i]
+
+		if versionConfig.PhasedReleaseEnabled && !ctx.VersionIsInitialRelease {
+			ctx.Log.WithField("platform", versionConfig.Platform).Info("preparing phased release details")
+
+			if err := p.Client.EnablePhasedRelease(ctx, version.ID); err != nil {
+				return err
+			}
+		}
+
+		ctx.Log.
+			WithField("platform", versionConfig.Platform).
+			WithField("version", *version.Attributes.VersionString).
+			Info("submitting to app store")
+
+		if err := p.Client.SubmitApp(ctx, version.ID); err != nil {
+			return err
+		}
+	}
 
-	return p.Client.SubmitApp(ctx, version.ID)
+	return nil
 }
 
-func (p *Pipe) updateVersionDetails(ctx *context.Context, config config.App, app *asc.App, version *asc.AppStoreVersion) error {
+// updateAppDetails updates the details shared by the versions of the app on every platform. Some of them, such
+// as the age rating declaration, are reached through one of the versions.
+func (p *Pipe) updateAppDetails(ctx *context.Context, config config.App, app *asc.App, version *asc.AppStoreVersion) error {
 	appInfo, err := p.Client.GetAppInfo(ctx, app.ID)
 	if err != nil {
 		return err
@@ -132,38 +167,75 @@ func (p *Pipe) updateVersionDetails(ctx *context.Context, config config.App, app
 		return err
 	}
 
+	if len(config.SubscriptionGroups) > 0 {
+		ctx.Log.Infof("updating %d subscription groups", len(config.SubscriptionGroups))
+
+		if err := p.Client.UpdateSubscriptionGroups(ctx, app.ID, config.SubscriptionGroups); err != nil {
+			return err
+		}
+	}
+
 	ctx.Log.Infof("updating %d app localizations", len(config.Localizations))
 
 	if err := p.Client.UpdateAppLocalizations(ctx, app.ID, config.Localizations); err != nil {
 		return err
 	}
 
-	ctx.Log.Infof("updating %d app store version localizations", len(config.Versions.Localizations))
+	if len(config.CustomProductPages) > 0 {
+		ctx.Log.Infof("updating %d custom product pages", len(config.CustomProductPages))
+
+		if err := p.Client.UpdateCustomProductPages(ctx, app.ID, config.CustomProductPages); err != nil {
+			return err
+		}
+	}
+
+	if len(config.InAppEvents) > 0 {
+		ctx.Log.Infof("updating %d in-app events", len(config.InAppEvents))
 
-	if err := p.Client.UpdateVersionLocalizations(ctx, version.ID, config.Versions.Localizations); err != nil {
+		if err := p.Client.UpdateInAppEvents(ctx, app.ID, config.InAppEvents); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateVersionDetails updates the details of the version of the app on one platform.
+func (p *Pipe) updateVersionDetails(ctx *context.Context, config config.App, versionConfig config.Version, app *asc.App, version *asc.AppStoreVersion) error {
+	ctx.Log.Infof("updating %d app store version localizations", len(versionConfig.Localizations))
+
+	if err := p.Client.UpdateVersionLocalizations(ctx, version.ID, versionConfig.Localizations); err != nil {
 		return err
 	}
 
-	if config.Versions.IDFADeclaration != nil {
+	if versionConfig.IDFADeclaration != nil {
 		ctx.Log.Info("updating IDFA declaration")
 
-		if err := p.Client.UpdateIDFADeclaration(ctx, version.ID, *config.Versions.IDFADeclaration); err != nil {
+		if err := p.Client.UpdateIDFADeclaration(ctx, version.ID, *versionConfig.IDFADeclaration); err != nil {
 			return err
 		}
 	}
 
-	if config.Versions.RoutingCoverage != nil {
+	if versionConfig.RoutingCoverage != nil {
 		ctx.Log.Info("uploading routing coverage asset")
 
-		if err := p.Client.UploadRoutingCoverage(ctx, version.ID, *config.Versions.RoutingCoverage); err != nil {
+		if err := p.Client.UploadRoutingCoverage(ctx, version.ID, *versionConfig.RoutingCoverage); err != nil {
 			return err
 		}
 	}
 
-	if config.Versions.ReviewDetails != nil {
+	if versionConfig.ReviewDetails != nil {
 		ctx.Log.Info("updating review details")
 
-		if err := p.Client.UpdateReviewDetails(ctx, version.ID, *config.Versions.ReviewDetails); err != nil {
+		if err := p.Client.UpdateReviewDetails(ctx, version.ID, *versionConfig.ReviewDetails); err != nil {
+			return err
+		}
+	}
+
+	if len(config.Experiments) > 0 {
+		ctx.Log.Infof("updating %d product page optimization experiments", len(config.Experiments))
+
+		if err := p.Client.UpdateExperiments(ctx, app.ID, versionConfig.Platform, config.Experiments); err != nil {
 			return err
 		}
 	}
@@ -58,6 +58,25 @@ func TestStore_Happy(t *testing.T) {
 					},
 				},
 			},
+			SubscriptionGroups: config.SubscriptionGroups{
+				"TEST": {
+					Subscriptions: config.Subscriptions{
+						"com.test.TEST.monthly": {Duration: config.SubscriptionDurationOneMonth},
+					},
+				},
+			},
+			CustomProductPages: config.CustomProductPages{
+				"TEST": {},
+			},
+			Experiments: config.Experiments{
+				"TEST": {TrafficProportion: 50},
+			},
+			InAppEvents: config.InAppEvents{
+				"TEST": {Badge: config.InAppEventBadgeLiveEvent},
+			},
+			InAppPurchases: config.InAppPurchases{
+				"com.test.TEST.coins": {ReferenceName: "Coins", Type: config.InAppPurchaseTypeConsumable},
+			},
 		},
 	})
 	ctx.AppsToRelease = []string{"TEST"}
@@ -71,6 +90,35 @@ func TestStore_Happy(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestStore_Happy_Platforms(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Versions: config.Version{
+				Localizations: config.VersionLocalizations{
+					"en-US": {Description: "TEST"},
+				},
+				Platforms: config.VersionPlatforms{
+					config.PlatformiOS:   {},
+					config.PlatformMacOS: {},
+				},
+			},
+			Experiments: config.Experiments{
+				"TEST": {TrafficProportion: 50},
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
 func TestStore_Happy_Skips(t *testing.T) {
 	t.Parallel()
 
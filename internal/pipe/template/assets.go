@@ -0,0 +1,145 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// screenshotExtensions are the extensions of the files a directory in a screenshot set expands to.
+// nolint: gochecknoglobals
+var screenshotExtensions = []string{".png", ".jpg", ".jpeg"}
+
+// previewExtensions are the extensions of the files a directory in a preview set expands to.
+// nolint: gochecknoglobals
+var previewExtensions = []string{".mov", ".m4v", ".mp4"}
+
+var errNoAssetsFound = errors.New("no assets found")
+
+// expandAssetPath expands the path of an asset in a preview or screenshot set to the paths of the files it refers
+// to, in natural order. Glob patterns expand to the files they match, and directories expand to the files in them
+// with one of the given extensions. Any other path refers to a single file, and is returned as is.
+func expandAssetPath(path string, extensions []string) ([]string, error) {
+	if strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w matching %s", errNoAssetsFound, path)
+		}
+
+		sortNatural(matches)
+
+		return matches, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !hasExtension(entry.Name(), extensions) {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(path, entry.Name()))
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w in %s with the extension %s", errNoAssetsFound, path, strings.Join(extensions, ", "))
+	}
+
+	sortNatural(paths)
+
+	return paths, nil
+}
+
+func hasExtension(name string, extensions []string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortNatural sorts paths so that runs of digits are compared by their value, putting screenshot2.png before
+// screenshot10.png.
+func sortNatural(paths []string) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		return naturalLess(paths[i], paths[j])
+	})
+}
+
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+
+		if aDigits == "" || bDigits == "" {
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+
+			a, b = a[1:], b[1:]
+
+			continue
+		}
+
+		aValue, bValue := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+		if len(aValue) != len(bValue) {
+			return len(aValue) < len(bValue)
+		} else if aValue != bValue {
+			return aValue < bValue
+		} else if len(aDigits) != len(bDigits) {
+			return len(aDigits) < len(bDigits)
+		}
+
+		a, b = a[len(aDigits):], b[len(bDigits):]
+	}
+
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return s[:i]
+}
@@ -0,0 +1,223 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package template
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandAssetPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"10.png", "2.png", "1.jpg", "notes.txt", ".DS_Store"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "nested.png"), 0700))
+
+	paths, err := expandAssetPath(filepath.Join(dir, "*.png"), screenshotExtensions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "2.png"),
+		filepath.Join(dir, "10.png"),
+		filepath.Join(dir, "nested.png"),
+	}, paths)
+
+	paths, err = expandAssetPath(dir, screenshotExtensions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "1.jpg"),
+		filepath.Join(dir, "2.png"),
+		filepath.Join(dir, "10.png"),
+	}, paths)
+
+	paths, err = expandAssetPath(filepath.Join(dir, "missing.png"), screenshotExtensions)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "missing.png")}, paths)
+
+	_, err = expandAssetPath(filepath.Join(dir, "*.mp4"), previewExtensions)
+	assert.True(t, errors.Is(err, errNoAssetsFound))
+
+	_, err = expandAssetPath(dir, previewExtensions)
+	assert.True(t, errors.Is(err, errNoAssetsFound))
+
+	_, err = expandAssetPath("[", screenshotExtensions)
+	assert.Error(t, err)
+}
+
+func TestSortNatural(t *testing.T) {
+	t.Parallel()
+
+	paths := []string{
+		"en-US/iphone65/screenshot10.png",
+		"en-US/iphone65/screenshot2.png",
+		"en-US/iphone65/screenshot02.png",
+		"en-US/iphone65/screenshot1.png",
+		"en-GB/iphone65/screenshot3.png",
+		"en-US/iphone65/screenshot",
+	}
+	sortNatural(paths)
+
+	assert.Equal(t, []string{
+		"en-GB/iphone65/screenshot3.png",
+		"en-US/iphone65/screenshot",
+		"en-US/iphone65/screenshot1.png",
+		"en-US/iphone65/screenshot2.png",
+		"en-US/iphone65/screenshot02.png",
+		"en-US/iphone65/screenshot10.png",
+	}, paths)
+}
+
+func TestTemplateExpandsAssets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"en-US/iphone65/1.png", "en-US/iphone65/2.png", "en-US/preview.mp4", "ja/iphone65/1.png"} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, os.WriteFile(path, []byte{}, 0600))
+	}
+
+	ctx := context.New(config.Project{
+		"My App": {
+			Versions: config.Version{
+				Localizations: config.VersionLocalizations{
+					"en-US": {
+						PreviewSets: config.PreviewSets{
+							config.PreviewTypeiPhone65: {
+								{File: config.File{Path: dir + "/{{ .locale }}/*.mp4"}, PreviewFrameTimeCode: "00:00:01"},
+							},
+						},
+						ScreenshotSets: config.ScreenshotSets{
+							config.ScreenshotTypeiPhone65: {{Path: dir + "/{{ .locale }}/iphone65"}},
+						},
+					},
+				},
+				Platforms: config.VersionPlatforms{
+					config.PlatformiOS: {
+						Localizations: config.VersionLocalizations{
+							"ja": {
+								ScreenshotSets: config.ScreenshotSets{
+									config.ScreenshotTypeiPhone65: {{Path: dir + "/{{ .locale }}/iphone65/*.png"}},
+									config.ScreenshotTypeiPhone55: {{Path: dir + "/{{ .locale }}/iphone55/*.png"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	err := Pipe{}.Run(ctx)
+	assert.True(t, errors.Is(err, errNoAssetsFound))
+
+	version := ctx.Config["My App"].Versions
+	assert.Equal(t, []config.Preview{
+		{File: config.File{Path: filepath.Join(dir, "en-US/preview.mp4")}, PreviewFrameTimeCode: "00:00:01"},
+	}, version.Localizations["en-US"].PreviewSets[config.PreviewTypeiPhone65])
+	assert.Equal(t, []config.File{
+		{Path: filepath.Join(dir, "en-US/iphone65/1.png")},
+		{Path: filepath.Join(dir, "en-US/iphone65/2.png")},
+	}, version.Localizations["en-US"].ScreenshotSets[config.ScreenshotTypeiPhone65])
+	assert.Equal(t, []config.File{
+		{Path: filepath.Join(dir, "ja/iphone65/1.png")},
+	}, version.Platforms[config.PlatformiOS].Localizations["ja"].ScreenshotSets[config.ScreenshotTypeiPhone65])
+
+	// The locale is only available to the paths of assets
+	ctx = context.New(config.Project{
+		"My App": {
+			Versions: config.Version{
+				Localizations: config.VersionLocalizations{
+					"en-US": {Description: "{{ .locale }}"},
+				},
+			},
+		},
+	})
+	assert.Error(t, Pipe{}.Run(ctx))
+}
+
+func TestTemplateExpandsProductPageAssets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"summer/en-US/1.png", "summer/en-US/10.png", "summer/en-US/2.png", "dark/ja/1.png"} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, os.WriteFile(path, []byte{}, 0600))
+	}
+
+	ctx := context.New(config.Project{
+		"My App": {
+			CustomProductPages: config.CustomProductPages{
+				"Summer Campaign": {
+					Localizations: config.CustomProductPageLocalizations{
+						"en-US": {
+							ScreenshotSets: config.ScreenshotSets{
+								config.ScreenshotTypeiPhone65: {{Path: dir + "/summer/{{ .locale }}"}},
+							},
+						},
+					},
+				},
+			},
+			Experiments: config.Experiments{
+				"Icon Test": {
+					Treatments: config.ExperimentTreatments{
+						"Dark Icon": {
+							Localizations: config.ExperimentTreatmentLocalizations{
+								"ja": {
+									ScreenshotSets: config.ScreenshotSets{
+										config.ScreenshotTypeiPhone65: {{Path: dir + "/dark/{{ .locale }}/*.png"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	err := Pipe{}.Run(ctx)
+	assert.NoError(t, err)
+
+	app := ctx.Config["My App"]
+	assert.Equal(t, []config.File{
+		{Path: filepath.Join(dir, "summer/en-US/1.png")},
+		{Path: filepath.Join(dir, "summer/en-US/2.png")},
+		{Path: filepath.Join(dir, "summer/en-US/10.png")},
+	}, app.CustomProductPages["Summer Campaign"].Localizations["en-US"].ScreenshotSets[config.ScreenshotTypeiPhone65])
+	assert.Equal(t, []config.File{
+		{Path: filepath.Join(dir, "dark/ja/1.png")},
+	}, app.Experiments["Icon Test"].Treatments["Dark Icon"].Localizations["ja"].ScreenshotSets[config.ScreenshotTypeiPhone65])
+
+	// The raw configuration keeps the paths as they were written
+	raw := ctx.RawConfig["My App"]
+	assert.Equal(t, dir+"/summer/{{ .locale }}", raw.CustomProductPages["Summer Campaign"].Localizations["en-US"].ScreenshotSets[config.ScreenshotTypeiPhone65][0].Path)
+}
@@ -22,12 +22,56 @@ along with Cider.  If not, see <http://www.gnu.org/licenses/>.
 package template
 
 import (
+	"fmt"
+
 	"github.com/cidertool/cider/internal/template"
 	"github.com/cidertool/cider/pkg/config"
 	"github.com/cidertool/cider/pkg/context"
 	"github.com/hashicorp/go-multierror"
 )
 
+// templater applies templates, attributing errors to the position of the templated value in the configuration file.
+type templater struct {
+	*template.Template
+	source *config.Source
+	ctx    *context.Context
+	// path of the value being templated in the configuration file, such as `My App.versions.copyright`.
+	path string
+}
+
+// at returns a templater for the value at keys below the current path.
+func (t *templater) at(keys ...string) *templater {
+	path := t.path
+
+	for _, key := range keys {
+		if path != "" {
+			path += "."
+		}
+
+		path += key
+	}
+
+	return &templater{Template: t.Template, source: t.source, ctx: t.ctx, path: path}
+}
+
+// index returns a templater for the i-th item of the sequence at the current path.
+func (t *templater) index(i int) *templater {
+	return &templater{Template: t.Template, source: t.source, ctx: t.ctx, path: fmt.Sprintf("%s[%d]", t.path, i)}
+}
+
+// localeKey is the template field holding the locale of the localization whose assets are being templated.
+const localeKey = "locale"
+
+// forLocale returns a templater that also provides locale to templates as {{ .locale }}.
+func (t *templater) forLocale(locale string) *templater {
+	return &templater{
+		Template: template.New(t.ctx).WithFields(template.Fields{localeKey: locale}),
+		source:   t.source,
+		ctx:      t.ctx,
+		path:     t.path,
+	}
+}
+
 // Pipe is a global hook pipe.
 type Pipe struct{}
 
@@ -38,7 +82,11 @@ func (Pipe) String() string {
 
 // Run executes the hooks.
 func (p Pipe) Run(ctx *context.Context) error {
-	var template = template.New(ctx)
+	var tmpl = &templater{
+		Template: template.New(ctx),
+		source:   ctx.ConfigSource,
+		ctx:      ctx,
+	}
 
 	project, err := ctx.RawConfig.Copy()
 
@@ -50,7 +98,7 @@ func (p Pipe) Run(ctx *context.Context) error {
 
 	for appName := range project {
 		app := project[appName]
-		if err := updateApp(&app, template); err != nil {
+		if err := updateApp(&app, tmpl.at(appName)); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
@@ -62,81 +110,155 @@ func (p Pipe) Run(ctx *context.Context) error {
 	return errors.ErrorOrNil()
 }
 
-func updateApp(app *config.App, tmpl *template.Template) error {
+func updateApp(app *config.App, tmpl *templater) error {
 	var errors error
 
 	for locName := range app.Localizations {
 		loc := app.Localizations[locName]
-		if err := updateAppLocalization(&loc, tmpl); err != nil {
+		if err := updateAppLocalization(&loc, tmpl.at("localizations", locName)); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
 		app.Localizations[locName] = loc
 	}
 
-	if err := updateAppTestflight(&app.Testflight, tmpl); err != nil {
+	if err := updateAppTestflight(&app.Testflight, tmpl.at("testflight")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := updateAppVersions(&app.Versions, tmpl); err != nil {
+	if err := updateAppVersions(&app.Versions, tmpl.at("versions")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
+	for pageName := range app.CustomProductPages {
+		page := app.CustomProductPages[pageName]
+		if err := updateCustomProductPage(&page, tmpl.at("customProductPages", pageName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		app.CustomProductPages[pageName] = page
+	}
+
+	for experimentName := range app.Experiments {
+		experiment := app.Experiments[experimentName]
+		if err := updateExperiment(&experiment, tmpl.at("experiments", experimentName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		app.Experiments[experimentName] = experiment
+	}
+
+	for eventName := range app.InAppEvents {
+		event := app.InAppEvents[eventName]
+		if err := updateInAppEvent(&event, tmpl.at("inAppEvents", eventName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		app.InAppEvents[eventName] = event
+	}
+
 	return errors
 }
 
-func updateAppLocalization(loc *config.AppLocalization, tmpl *template.Template) error {
+func updateAppLocalization(loc *config.AppLocalization, tmpl *templater) error {
 	var errors error
-	if err := applyTemplateVar(&loc.Name, loc.Name, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.Name, loc.Name, tmpl.at("name")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.PrivacyPolicyText, loc.PrivacyPolicyText, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.PrivacyPolicyText, loc.PrivacyPolicyText, tmpl.at("privacyPolicyText")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.PrivacyPolicyURL, loc.PrivacyPolicyURL, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.PrivacyPolicyURL, loc.PrivacyPolicyURL, tmpl.at("privacyPolicyURL")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.Subtitle, loc.Subtitle, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.Subtitle, loc.Subtitle, tmpl.at("subtitle")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	return errors
 }
 
-func updateAppTestflight(tf *config.Testflight, tmpl *template.Template) error {
+func updateAppTestflight(tf *config.Testflight, tmpl *templater) error {
 	var errors error
-	if err := applyTemplateVar(&tf.LicenseAgreement, tf.LicenseAgreement, tmpl); err != nil {
+	if err := applyTemplateVar(&tf.LicenseAgreement, tf.LicenseAgreement, tmpl.at("licenseAgreement")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	for locName := range tf.Localizations {
 		loc := tf.Localizations[locName]
-		if err := updateTestflightLocalization(&loc, tmpl); err != nil {
+		if err := updateTestflightLocalization(&loc, tmpl.at("localizations", locName)); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
 		tf.Localizations[locName] = loc
 	}
 
-	if err := updateReviewDetails(tf.ReviewDetails, tmpl); err != nil {
+	if tf.WhatToTest != nil {
+		if err := applyTemplateVar(&tf.WhatToTest.File, tf.WhatToTest.File, tmpl.at("whatToTest", "file")); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		if err := applyTemplateVar(&tf.WhatToTest.Command, tf.WhatToTest.Command, tmpl.at("whatToTest", "command")); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if err := updateReviewDetails(tf.ReviewDetails, tmpl.at("reviewDetails")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	return errors
+}
+
+func updateAppVersions(version *config.Version, tmpl *templater) error {
+	var errors error
+	if err := applyTemplateVar(&version.Copyright, version.Copyright, tmpl.at("copyright")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	for locName := range version.Localizations {
+		loc := version.Localizations[locName]
+		if err := updateVersionLocalization(&loc, locName, tmpl.at("localizations", locName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		version.Localizations[locName] = loc
+	}
+
+	if version.RoutingCoverage != nil {
+		if err := applyTemplateVar(&version.RoutingCoverage.Path, version.RoutingCoverage.Path, tmpl.at("routingCoverage", "path")); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if err := updateReviewDetails(version.ReviewDetails, tmpl.at("reviewDetails")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
+	for platform := range version.Platforms {
+		platformVersion := version.Platforms[platform]
+		if err := updatePlatformVersion(&platformVersion, tmpl.at("platforms", string(platform))); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		version.Platforms[platform] = platformVersion
+	}
+
 	return errors
 }
 
-func updateAppVersions(version *config.Version, tmpl *template.Template) error {
+func updatePlatformVersion(version *config.PlatformVersion, tmpl *templater) error {
 	var errors error
-	if err := applyTemplateVar(&version.Copyright, version.Copyright, tmpl); err != nil {
+	if err := applyTemplateVar(&version.Copyright, version.Copyright, tmpl.at("copyright")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	for locName := range version.Localizations {
 		loc := version.Localizations[locName]
-		if err := updateVersionLocalization(&loc, tmpl); err != nil {
+		if err := updateVersionLocalization(&loc, locName, tmpl.at("localizations", locName)); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
@@ -144,105 +266,222 @@ func updateAppVersions(version *config.Version, tmpl *template.Template) error {
 	}
 
 	if version.RoutingCoverage != nil {
-		if err := applyTemplateVar(&version.RoutingCoverage.Path, version.RoutingCoverage.Path, tmpl); err != nil {
+		if err := applyTemplateVar(&version.RoutingCoverage.Path, version.RoutingCoverage.Path, tmpl.at("routingCoverage", "path")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 	}
 
-	if err := updateReviewDetails(version.ReviewDetails, tmpl); err != nil {
+	if err := updateReviewDetails(version.ReviewDetails, tmpl.at("reviewDetails")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	return errors
 }
 
-func updateTestflightLocalization(loc *config.TestflightLocalization, tmpl *template.Template) error {
+func updateTestflightLocalization(loc *config.TestflightLocalization, tmpl *templater) error {
 	var errors error
-	if err := applyTemplateVar(&loc.Description, loc.Description, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.Description, loc.Description, tmpl.at("description")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.FeedbackEmail, loc.FeedbackEmail, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.FeedbackEmail, loc.FeedbackEmail, tmpl.at("feedbackEmail")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.MarketingURL, loc.MarketingURL, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.MarketingURL, loc.MarketingURL, tmpl.at("marketingURL")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.PrivacyPolicyURL, loc.PrivacyPolicyURL, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.PrivacyPolicyURL, loc.PrivacyPolicyURL, tmpl.at("privacyPolicyURL")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.TVOSPrivacyPolicy, loc.TVOSPrivacyPolicy, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.TVOSPrivacyPolicy, loc.TVOSPrivacyPolicy, tmpl.at("tvOSPrivacyPolicy")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.WhatsNew, loc.WhatsNew, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.WhatsNew, loc.WhatsNew, tmpl.at("whatsNew")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	return errors
 }
 
-func updateVersionLocalization(loc *config.VersionLocalization, tmpl *template.Template) error {
+func updateVersionLocalization(loc *config.VersionLocalization, locale string, tmpl *templater) error {
 	var errors error
-	if err := applyTemplateVar(&loc.Description, loc.Description, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.Description, loc.Description, tmpl.at("description")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.Keywords, loc.Keywords, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.Keywords, loc.Keywords, tmpl.at("keywords")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.MarketingURL, loc.MarketingURL, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.MarketingURL, loc.MarketingURL, tmpl.at("marketingURL")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.PromotionalText, loc.PromotionalText, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.PromotionalText, loc.PromotionalText, tmpl.at("promotionalText")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.SupportURL, loc.SupportURL, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.SupportURL, loc.SupportURL, tmpl.at("supportURL")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := applyTemplateVar(&loc.WhatsNewText, loc.WhatsNewText, tmpl); err != nil {
+	if err := applyTemplateVar(&loc.WhatsNewText, loc.WhatsNewText, tmpl.at("whatsNew")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	for previewType, set := range loc.PreviewSets {
-		var previews = make([]config.Preview, len(set))
+	if err := updateLocalizationAssets(loc.PreviewSets, loc.ScreenshotSets, locale, tmpl); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	return errors
+}
+
+// updateLocalizationAssets templates the asset paths of the preview and screenshot sets of a localization, and
+// expands each to the paths of the files it refers to.
+func updateLocalizationAssets(previewSets config.PreviewSets, screenshotSets config.ScreenshotSets, locale string, tmpl *templater) error {
+	var errors error
+
+	assetTmpl := tmpl.forLocale(locale)
+
+	for previewType, set := range previewSets {
+		var previews = make([]config.Preview, 0, len(set))
 
 		for i, preview := range set {
-			if err := applyTemplateVar(&preview.Path, preview.Path, tmpl); err != nil {
+			pathTmpl := assetTmpl.at("previewSets", string(previewType)).index(i).at("path")
+
+			paths, err := applyAssetTemplate(preview.Path, previewExtensions, pathTmpl)
+			if err != nil {
 				errors = multierror.Append(errors, err)
 			}
 
-			previews[i] = preview
+			for _, path := range paths {
+				preview.Path = path
+				previews = append(previews, preview)
+			}
 		}
 
-		loc.PreviewSets[previewType] = previews
+		previewSets[previewType] = previews
 	}
 
-	for screenshotType, set := range loc.ScreenshotSets {
-		var screenshots = make([]config.File, len(set))
+	for screenshotType, set := range screenshotSets {
+		var screenshots = make([]config.File, 0, len(set))
 
 		for i, screenshot := range set {
-			if err := applyTemplateVar(&screenshot.Path, screenshot.Path, tmpl); err != nil {
+			pathTmpl := assetTmpl.at("screenshotSets", string(screenshotType)).index(i).at("path")
+
+			paths, err := applyAssetTemplate(screenshot.Path, screenshotExtensions, pathTmpl)
+			if err != nil {
+				errors = multierror.Append(errors, err)
+			}
+
+			for _, path := range paths {
+				screenshots = append(screenshots, config.File{Path: path})
+			}
+		}
+
+		screenshotSets[screenshotType] = screenshots
+	}
+
+	return errors
+}
+
+func updateCustomProductPage(page *config.CustomProductPage, tmpl *templater) error {
+	var errors error
+
+	for locName, loc := range page.Localizations {
+		if err := updateLocalizationAssets(loc.PreviewSets, loc.ScreenshotSets, locName, tmpl.at("localizations", locName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+func updateExperiment(experiment *config.Experiment, tmpl *templater) error {
+	var errors error
+
+	for treatmentName, treatment := range experiment.Treatments {
+		for locName, loc := range treatment.Localizations {
+			locTmpl := tmpl.at("treatments", treatmentName, "localizations", locName)
+			if err := updateLocalizationAssets(loc.PreviewSets, loc.ScreenshotSets, locName, locTmpl); err != nil {
 				errors = multierror.Append(errors, err)
 			}
+		}
+	}
+
+	return errors
+}
+
+func updateInAppEvent(event *config.InAppEvent, tmpl *templater) error {
+	var errors error
+	if err := applyTemplateVar(&event.DeepLink, event.DeepLink, tmpl.at("deepLink")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := applyTemplateVar(&event.PublishStart, event.PublishStart, tmpl.at("publishStart")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := applyTemplateVar(&event.EventStart, event.EventStart, tmpl.at("eventStart")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := applyTemplateVar(&event.EventEnd, event.EventEnd, tmpl.at("eventEnd")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	for locName := range event.Localizations {
+		loc := event.Localizations[locName]
+		if err := updateInAppEventLocalization(&loc, tmpl.at("localizations", locName)); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+
+		event.Localizations[locName] = loc
+	}
+
+	return errors
+}
+
+func updateInAppEventLocalization(loc *config.InAppEventLocalization, tmpl *templater) error {
+	var errors error
+	if err := applyTemplateVar(&loc.Name, loc.Name, tmpl.at("name")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := applyTemplateVar(&loc.ShortDescription, loc.ShortDescription, tmpl.at("shortDescription")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if err := applyTemplateVar(&loc.LongDescription, loc.LongDescription, tmpl.at("longDescription")); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	for key, media := range map[string]*config.InAppEventMedia{"eventCard": loc.EventCard, "eventDetailsPage": loc.EventDetailsPage} {
+		if media == nil {
+			continue
+		}
 
-			screenshots[i] = screenshot
+		if media.Image != nil {
+			if err := applyTemplateVar(&media.Image.Path, media.Image.Path, tmpl.at(key, "image", "path")); err != nil {
+				errors = multierror.Append(errors, err)
+			}
 		}
 
-		loc.ScreenshotSets[screenshotType] = screenshots
+		if media.Video != nil {
+			if err := applyTemplateVar(&media.Video.Path, media.Video.Path, tmpl.at(key, "video", "path")); err != nil {
+				errors = multierror.Append(errors, err)
+			}
+		}
 	}
 
 	return errors
 }
 
-func updateReviewDetails(details *config.ReviewDetails, tmpl *template.Template) error {
+func updateReviewDetails(details *config.ReviewDetails, tmpl *templater) error {
 	var errors error
 
 	if details == nil {
@@ -250,41 +489,41 @@ func updateReviewDetails(details *config.ReviewDetails, tmpl *template.Template)
 	}
 
 	if details.Contact != nil {
-		if err := applyTemplateVar(&details.Contact.Email, details.Contact.Email, tmpl); err != nil {
+		if err := applyTemplateVar(&details.Contact.Email, details.Contact.Email, tmpl.at("contact", "email")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
-		if err := applyTemplateVar(&details.Contact.FirstName, details.Contact.FirstName, tmpl); err != nil {
+		if err := applyTemplateVar(&details.Contact.FirstName, details.Contact.FirstName, tmpl.at("contact", "firstName")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
-		if err := applyTemplateVar(&details.Contact.LastName, details.Contact.LastName, tmpl); err != nil {
+		if err := applyTemplateVar(&details.Contact.LastName, details.Contact.LastName, tmpl.at("contact", "lastName")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
-		if err := applyTemplateVar(&details.Contact.Phone, details.Contact.Phone, tmpl); err != nil {
+		if err := applyTemplateVar(&details.Contact.Phone, details.Contact.Phone, tmpl.at("contact", "phone")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 	}
 
 	if details.DemoAccount != nil {
-		if err := applyTemplateVar(&details.DemoAccount.Name, details.DemoAccount.Name, tmpl); err != nil {
+		if err := applyTemplateVar(&details.DemoAccount.Name, details.DemoAccount.Name, tmpl.at("demoAccount", "name")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
-		if err := applyTemplateVar(&details.DemoAccount.Password, details.DemoAccount.Password, tmpl); err != nil {
+		if err := applyTemplateVar(&details.DemoAccount.Password, details.DemoAccount.Password, tmpl.at("demoAccount", "password")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 	}
 
-	if err := applyTemplateVar(&details.Notes, details.Notes, tmpl); err != nil {
+	if err := applyTemplateVar(&details.Notes, details.Notes, tmpl.at("notes")); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
 	var attachments = make([]config.File, len(details.Attachments))
 
 	for i, attachment := range details.Attachments {
-		if err := applyTemplateVar(&attachment.Path, attachment.Path, tmpl); err != nil {
+		if err := applyTemplateVar(&attachment.Path, attachment.Path, tmpl.at("attachments").index(i).at("path")); err != nil {
 			errors = multierror.Append(errors, err)
 		}
 
@@ -296,10 +535,26 @@ func updateReviewDetails(details *config.ReviewDetails, tmpl *template.Template)
 	return errors
 }
 
-func applyTemplateVar(v *string, s string, tmpl *template.Template) error {
+// applyAssetTemplate applies the template of the path of an asset in a preview or screenshot set, and expands it
+// to the paths of the files it refers to.
+func applyAssetTemplate(s string, extensions []string, tmpl *templater) ([]string, error) {
+	var path string
+	if err := applyTemplateVar(&path, s, tmpl); err != nil {
+		return []string{s}, err
+	}
+
+	paths, err := expandAssetPath(path, extensions)
+	if err != nil {
+		return []string{path}, tmpl.source.WrapError(tmpl.path, err)
+	}
+
+	return paths, nil
+}
+
+func applyTemplateVar(v *string, s string, tmpl *templater) error {
 	applied, err := tmpl.Apply(s)
 	if err != nil {
-		return err
+		return tmpl.source.WrapError(tmpl.path, err)
 	}
 
 	*v = applied
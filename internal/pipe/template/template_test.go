@@ -22,6 +22,7 @@ package template
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -86,6 +87,10 @@ func TestTemplateFullyDefinedProjectWithGoodTemplatesPasses(t *testing.T) {
 			assert.Equal(t, expected, loc.WhatsNew)
 		}
 
+		assert.Equal(t, expected, app.Testflight.WhatToTest.File)
+		assert.Equal(t, expected, app.Testflight.WhatToTest.Command)
+		assert.Equal(t, "{{ .notes }}", app.Testflight.WhatToTest.Template)
+
 		assert.Equal(t, expected, app.Testflight.ReviewDetails.Contact.Email)
 		assert.Equal(t, expected, app.Testflight.ReviewDetails.Contact.FirstName)
 		assert.Equal(t, expected, app.Testflight.ReviewDetails.Contact.LastName)
@@ -134,6 +139,30 @@ func TestTemplateFullyDefinedProjectWithGoodTemplatesPasses(t *testing.T) {
 		}
 
 		assert.Equal(t, expected, app.Versions.RoutingCoverage.Path)
+
+		for _, version := range app.Versions.Platforms {
+			assert.Equal(t, expected, version.Copyright)
+
+			for _, loc := range version.Localizations {
+				assert.Equal(t, expected, loc.Description)
+			}
+		}
+
+		for _, event := range app.InAppEvents {
+			assert.Equal(t, expected, event.DeepLink)
+			assert.Equal(t, expected, event.PublishStart)
+			assert.Equal(t, expected, event.EventStart)
+			assert.Equal(t, expected, event.EventEnd)
+
+			for _, loc := range event.Localizations {
+				assert.Equal(t, expected, loc.Name)
+				assert.Equal(t, expected, loc.ShortDescription)
+				assert.Equal(t, expected, loc.LongDescription)
+				assert.Equal(t, expected, loc.EventCard.Image.Path)
+				assert.Equal(t, expected, loc.EventCard.Video.Path)
+				assert.Equal(t, expected, loc.EventDetailsPage.Image.Path)
+			}
+		}
 	}
 }
 
@@ -150,7 +179,79 @@ func TestTemplateWithBadPatterns(t *testing.T) {
 	ok := errors.As(err, &merr)
 	assert.True(t, ok)
 	assert.NotNil(t, merr)
-	assert.Equal(t, 55, merr.Len())
+	assert.Equal(t, 69, merr.Len())
+}
+
+func TestTemplateErrorsHavePositions(t *testing.T) {
+	t.Parallel()
+
+	source, err := config.ParseSource(".cider.yml", []byte(`My App:
+  id: com.app
+  localizations:
+    en-US:
+      name: My App
+      subtitle: "{{ .version "
+`))
+	assert.NoError(t, err)
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+
+	ctx := context.New(proj)
+	ctx.ConfigSource = source
+	pipe := Pipe{}
+	err = pipe.Run(ctx)
+
+	var verr config.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, ".cider.yml", verr.File)
+	assert.Equal(t, 6, verr.Line)
+	assert.Equal(t, 17, verr.Column)
+	assert.Equal(t, "My App.localizations.en-US.subtitle", verr.Path)
+}
+
+func TestTemplateErrorsHavePositions_RepeatedValues(t *testing.T) {
+	t.Parallel()
+
+	source, err := config.ParseSource(".cider.yml", []byte(`My App:
+  id: com.app
+  localizations:
+    en-US:
+      name: My App
+      subtitle: "{{ .version "
+  versions:
+    localizations:
+      en-US:
+        description: "{{ .version "
+        screenshotSets:
+          iphone65:
+            - path: "{{ .version "
+`))
+	assert.NoError(t, err)
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+
+	ctx := context.New(proj)
+	ctx.ConfigSource = source
+	pipe := Pipe{}
+	err = pipe.Run(ctx)
+
+	var merr *multierror.Error
+	assert.ErrorAs(t, err, &merr)
+
+	var paths = make([]string, 0, merr.Len())
+
+	for _, err := range merr.Errors {
+		var verr config.ValidationError
+		assert.ErrorAs(t, err, &verr)
+
+		paths = append(paths, fmt.Sprintf("%d:%d %s", verr.Line, verr.Column, verr.Path))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"6:17 My App.localizations.en-US.subtitle",
+		"10:22 My App.versions.localizations.en-US.description",
+		"13:21 My App.versions.localizations.en-US.screenshotSets.iphone65[0].path",
+	}, paths)
 }
 
 func fullyPopulatedProject(good bool) config.Project {
@@ -263,6 +364,16 @@ func fullyPopulatedProject(good bool) config.Project {
 				RoutingCoverage: &config.File{
 					Path: pattern,
 				},
+				Platforms: config.VersionPlatforms{
+					config.PlatformMacOS: {
+						Copyright: pattern,
+						Localizations: config.VersionLocalizations{
+							"en-US": {
+								Description: pattern,
+							},
+						},
+					},
+				},
 				ReviewDetails: &config.ReviewDetails{
 					Contact: &config.ContactPerson{
 						Email:     pattern,
@@ -304,6 +415,11 @@ func fullyPopulatedProject(good bool) config.Project {
 						WhatsNew:          pattern,
 					},
 				},
+				WhatToTest: &config.WhatToTest{
+					File:     pattern,
+					Command:  pattern,
+					Template: "{{ .notes }}",
+				},
 				BetaGroups: []config.BetaGroup{
 					{
 						Name:                  "Jeff's Team",
@@ -347,6 +463,29 @@ func fullyPopulatedProject(good bool) config.Project {
 					},
 				},
 			},
+			InAppEvents: config.InAppEvents{
+				"Summer Challenge": {
+					Badge:        config.InAppEventBadgeChallenge,
+					DeepLink:     pattern,
+					PublishStart: pattern,
+					EventStart:   pattern,
+					EventEnd:     pattern,
+					Localizations: config.InAppEventLocalizations{
+						"en-US": {
+							Name:             pattern,
+							ShortDescription: pattern,
+							LongDescription:  pattern,
+							EventCard: &config.InAppEventMedia{
+								Image: &config.File{Path: pattern},
+								Video: &config.Preview{File: config.File{Path: pattern}},
+							},
+							EventDetailsPage: &config.InAppEventMedia{
+								Image: &config.File{Path: pattern},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
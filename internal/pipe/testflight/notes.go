@@ -0,0 +1,185 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testflight
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/git"
+	"github.com/cidertool/cider/internal/shell"
+	"github.com/cidertool/cider/internal/template"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+const defaultWhatToTestTemplate = "{{ .notes }}"
+
+// ErrWhatToTestSource happens when whatToTest does not set exactly one source for the notes.
+var ErrWhatToTestSource = errors.New("whatToTest must set exactly one of file, command or commits")
+
+// commitLinePattern matches the line identifying the commit of a build at the end of its notes.
+var commitLinePattern = regexp.MustCompile(`(?m)^Commit: ([0-9a-f]{7,40})\s*$`)
+
+type errWhatToTestCommand struct {
+	Command string
+	Stderr  string
+}
+
+func (e errWhatToTestCommand) Error() string {
+	return fmt.Sprintf("whatToTest command `%s` failed: %s", e.Command, e.Stderr)
+}
+
+// whatToTest returns the "What to Test" notes of the build, rendered with the configured template.
+// The notes of the previous build are read from the first of locales that has notes.
+func (p *Pipe) whatToTest(ctx *context.Context, cfg config.WhatToTest, locales []string, app *asc.App, build *asc.Build) (string, error) {
+	sources := 0
+
+	for _, set := range []bool{cfg.File != "", cfg.Command != "", cfg.Commits} {
+		if set {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return "", ErrWhatToTestSource
+	}
+
+	if p.Shell == nil {
+		p.Shell = shell.New(ctx)
+	}
+
+	var notes string
+
+	var err error
+
+	switch {
+	case cfg.File != "":
+		notes, err = whatToTestFromFile(ctx, cfg.File)
+	case cfg.Command != "":
+		notes, err = p.whatToTestFromCommand(cfg.Command)
+	case cfg.Commits:
+		notes, err = p.whatToTestFromCommits(ctx, locales, app, build)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	tmpl := cfg.Template
+	if tmpl == "" {
+		tmpl = defaultWhatToTestTemplate
+	}
+
+	notes, err = template.New(ctx).
+		WithFields(template.Fields{"notes": strings.TrimSpace(notes)}).
+		Apply(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.Commits && ctx.Git.FullCommit != "" {
+		notes = fmt.Sprintf("%s\n\nCommit: %s", strings.TrimSpace(notes), ctx.Git.FullCommit)
+	}
+
+	return notes, nil
+}
+
+func whatToTestFromFile(ctx *context.Context, path string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(ctx.CurrentDirectory, filepath.Clean(path)))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (p *Pipe) whatToTestFromCommand(command string) (string, error) {
+	cmd := p.Shell.NewCommand("sh")
+	// Arguments given to NewCommand are quoted, which would turn the command into a single word.
+	cmd.Args = append(cmd.Args, "-c", command)
+
+	proc, err := p.Shell.Exec(cmd)
+	if err != nil {
+		var stderr string
+		if proc != nil {
+			stderr = proc.Stderr
+		}
+
+		return "", errWhatToTestCommand{Command: command, Stderr: stderr}
+	}
+
+	return proc.Stdout, nil
+}
+
+func (p *Pipe) whatToTestFromCommits(ctx *context.Context, locales []string, app *asc.App, build *asc.Build) (string, error) {
+	previous, err := p.Client.GetPreviousBuildWhatsNew(ctx, app.ID, build.ID, locales)
+	if err != nil {
+		return "", err
+	}
+
+	client := &git.Git{Shell: p.Shell}
+	args := []string{"log", "--no-merges", "--format=%s"}
+
+	if match := commitLinePattern.FindStringSubmatch(previous); match != nil {
+		ctx.Log.WithField("commit", match[1]).Debug("found commit of previous build")
+
+		args = append(args, match[1]+"..HEAD")
+	} else {
+		ctx.Log.Warn("commit of previous build not found, listing the current commit only")
+
+		args = append(args, "-n", "1")
+	}
+
+	proc, err := client.Run(args...)
+	if err != nil {
+		_, err = client.SanitizeProcess(proc, err)
+
+		return "", err
+	}
+
+	var lines []string
+
+	for _, subject := range strings.Split(proc.Stdout, "\n") {
+		if subject = strings.TrimSpace(subject); subject != "" {
+			lines = append(lines, "- "+subject)
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// withWhatsNew returns a copy of the localizations with the whatsNew of each set to notes.
+func withWhatsNew(localizations config.TestflightLocalizations, notes string) config.TestflightLocalizations {
+	updated := make(config.TestflightLocalizations, len(localizations))
+
+	for locale, loc := range localizations {
+		loc.WhatsNew = notes
+		updated[locale] = loc
+	}
+
+	return updated
+}
@@ -0,0 +1,146 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testflight
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/internal/shell/shelltest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNotesTestPipe(t *testing.T, ctx *context.Context, commands ...shelltest.Command) *Pipe {
+	t.Helper()
+
+	return &Pipe{
+		Client: &clienttest.Client{},
+		Shell: &shelltest.Shell{
+			T:        t,
+			Context:  ctx,
+			Commands: commands,
+		},
+	}
+}
+
+func TestWhatToTest_File(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.Version = "1.0"
+	ctx.CurrentDirectory = t.TempDir()
+
+	err := os.WriteFile(filepath.Join(ctx.CurrentDirectory, "notes.txt"), []byte("Try the new editor\n"), 0600)
+	assert.NoError(t, err)
+
+	p := newNotesTestPipe(t, ctx)
+
+	notes, err := p.whatToTest(ctx, config.WhatToTest{
+		File:     "notes.txt",
+		Template: "Version {{ .version }}: {{ .notes }}",
+	}, nil, &asc.App{}, &asc.Build{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Version 1.0: Try the new editor", notes)
+
+	_, err = p.whatToTest(ctx, config.WhatToTest{File: "missing.txt"}, nil, &asc.App{}, &asc.Build{})
+	assert.Error(t, err)
+}
+
+func TestWhatToTest_Command(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	p := newNotesTestPipe(t, ctx,
+		shelltest.Command{Stdout: "Generated notes"},
+		shelltest.Command{ReturnCode: 1, Stderr: "not found"},
+	)
+
+	notes, err := p.whatToTest(ctx, config.WhatToTest{Command: "./notes.sh --since yesterday"}, nil, &asc.App{}, &asc.Build{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Generated notes", notes)
+
+	_, err = p.whatToTest(ctx, config.WhatToTest{Command: "./notes.sh"}, nil, &asc.App{}, &asc.Build{})
+	assert.EqualError(t, err, errWhatToTestCommand{Command: "./notes.sh", Stderr: "not found"}.Error())
+}
+
+func TestWhatToTest_Commits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.Git.FullCommit = "1234567890abcdef1234567890abcdef12345678"
+
+	p := newNotesTestPipe(t, ctx,
+		shelltest.Command{Stdout: "Add the new editor\nFix a crash on launch"},
+		shelltest.Command{ReturnCode: 128, Stderr: "bad revision"},
+		shelltest.Command{Stdout: "Add the new editor"},
+	)
+
+	notes, err := p.whatToTest(ctx, config.WhatToTest{Commits: true}, []string{"en-US"}, &asc.App{}, &asc.Build{})
+	assert.NoError(t, err)
+	assert.Equal(t, `- Add the new editor
+- Fix a crash on launch
+
+Commit: 1234567890abcdef1234567890abcdef12345678`, notes)
+
+	_, err = p.whatToTest(ctx, config.WhatToTest{Commits: true}, []string{"en-US"}, &asc.App{}, &asc.Build{})
+	assert.EqualError(t, err, "bad revision")
+
+	ctx.Git.FullCommit = ""
+
+	notes, err = p.whatToTest(ctx, config.WhatToTest{Commits: true}, []string{"en-US"}, &asc.App{}, &asc.Build{})
+	assert.NoError(t, err)
+	assert.Equal(t, "- Add the new editor", notes)
+}
+
+func TestWhatToTest_ErrSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	p := newNotesTestPipe(t, ctx, shelltest.Command{Stdout: "Generated notes"})
+
+	_, err := p.whatToTest(ctx, config.WhatToTest{}, nil, &asc.App{}, &asc.Build{})
+	assert.ErrorIs(t, err, ErrWhatToTestSource)
+
+	_, err = p.whatToTest(ctx, config.WhatToTest{File: "notes.txt", Commits: true}, nil, &asc.App{}, &asc.Build{})
+	assert.ErrorIs(t, err, ErrWhatToTestSource)
+
+	_, err = p.whatToTest(ctx, config.WhatToTest{Command: "true", Template: "{{ .missing }}"}, nil, &asc.App{}, &asc.Build{})
+	assert.Error(t, err)
+}
+
+func TestWithWhatsNew(t *testing.T) {
+	t.Parallel()
+
+	localizations := config.TestflightLocalizations{
+		"en-US": {Description: "App", WhatsNew: "Old"},
+	}
+
+	updated := withWhatsNew(localizations, "New")
+	assert.Equal(t, config.TestflightLocalizations{
+		"en-US": {Description: "App", WhatsNew: "New"},
+	}, updated)
+	assert.Equal(t, "Old", localizations["en-US"].WhatsNew)
+}
@@ -0,0 +1,85 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testflight
+
+import (
+	"fmt"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/log"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+)
+
+type errBetaReviewRejected struct {
+	Build string
+}
+
+func (e errBetaReviewRejected) Error() string {
+	return fmt.Sprintf("beta app review of build %s was rejected, testers were not notified", e.Build)
+}
+
+// notifyTesters notifies the beta testers of the build that it is available, if enabled. If the build was
+// submitted for review and is not approved yet, it logs how to notify testers once it is, rather than waiting.
+func (p *Pipe) notifyTesters(ctx *context.Context, name string, cfg config.App, build *asc.Build, buildVersionLog string, reviewed bool) error {
+	if !cfg.Testflight.NotifyTesters {
+		return nil
+	}
+
+	if cfg.Testflight.EnableAutoNotify {
+		ctx.Log.Debug("skipping notifying testers, App Store Connect notifies them automatically")
+
+		return nil
+	}
+
+	if reviewed {
+		state, err := p.Client.GetBetaReviewState(ctx, build.ID)
+		if err != nil {
+			return err
+		}
+
+		switch state {
+		case asc.BetaReviewStateApproved:
+		case asc.BetaReviewStateRejected:
+			return errBetaReviewRejected{Build: buildVersionLog}
+		default:
+			var buildNumber string
+			if build.Attributes != nil && build.Attributes.Version != nil {
+				buildNumber = *build.Attributes.Version
+			}
+
+			ctx.Log.
+				WithFields(log.Fields{
+					"build": buildVersionLog,
+					"state": state,
+				}).
+				Warnf("build is waiting for beta app review, run `cider testflight notify --app %s --build %s` to notify testers once it is approved", name, buildNumber)
+
+			return nil
+		}
+	}
+
+	ctx.Log.
+		WithField("build", buildVersionLog).
+		Info("notifying testers")
+
+	return p.Client.NotifyBetaTesters(ctx, build.ID)
+}
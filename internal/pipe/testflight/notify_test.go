@@ -0,0 +1,146 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testflight
+
+import (
+	"testing"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/cidertool/cider/internal/client/clienttest"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/cidertool/cider/pkg/context"
+	"github.com/stretchr/testify/assert"
+)
+
+// reviewClient is a client whose builds go through the given Beta App Review states.
+type reviewClient struct {
+	clienttest.Client
+	states   []asc.BetaReviewState
+	notified bool
+}
+
+func (c *reviewClient) GetBetaReviewState(ctx *context.Context, buildID string) (asc.BetaReviewState, error) {
+	state := c.states[0]
+	if len(c.states) > 1 {
+		c.states = c.states[1:]
+	}
+
+	return state, nil
+}
+
+func (c *reviewClient) NotifyBetaTesters(ctx *context.Context, buildID string) error {
+	c.notified = true
+
+	return nil
+}
+
+func newNotifyTestContext(testflight config.Testflight) *context.Context {
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID:   "com.test.TEST",
+			Testflight: testflight,
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+	ctx.Version = "1.0"
+	ctx.SkipUpdateMetadata = true
+
+	return ctx
+}
+
+func TestNotifyTesters_Happy(t *testing.T) {
+	t.Parallel()
+
+	ctx := newNotifyTestContext(config.Testflight{NotifyTesters: true})
+	client := &reviewClient{
+		states: []asc.BetaReviewState{asc.BetaReviewStateApproved},
+	}
+
+	p := Pipe{Client: client}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+	assert.True(t, client.notified)
+}
+
+func TestNotifyTesters_Happy_WaitingForReview(t *testing.T) {
+	t.Parallel()
+
+	ctx := newNotifyTestContext(config.Testflight{NotifyTesters: true})
+	client := &reviewClient{
+		states: []asc.BetaReviewState{asc.BetaReviewStateWaitingForReview, asc.BetaReviewStateApproved},
+	}
+
+	p := Pipe{Client: client}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+	assert.False(t, client.notified)
+	assert.Len(t, client.states, 1)
+}
+
+func TestNotifyTesters_Happy_InternalOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := newNotifyTestContext(config.Testflight{
+		NotifyTesters: true,
+		BetaGroups:    []config.BetaGroup{{Name: "Team", IsInternal: true}},
+	})
+	client := &reviewClient{
+		states: []asc.BetaReviewState{asc.BetaReviewStateRejected},
+	}
+
+	p := Pipe{Client: client}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+	assert.True(t, client.notified)
+}
+
+func TestNotifyTesters_Happy_AutoNotify(t *testing.T) {
+	t.Parallel()
+
+	ctx := newNotifyTestContext(config.Testflight{NotifyTesters: true, EnableAutoNotify: true})
+	client := &reviewClient{
+		states: []asc.BetaReviewState{asc.BetaReviewStateRejected},
+	}
+
+	p := Pipe{Client: client}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+	assert.False(t, client.notified)
+}
+
+func TestNotifyTesters_ErrRejected(t *testing.T) {
+	t.Parallel()
+
+	ctx := newNotifyTestContext(config.Testflight{NotifyTesters: true})
+	client := &reviewClient{
+		states: []asc.BetaReviewState{asc.BetaReviewStateRejected},
+	}
+
+	p := Pipe{Client: client}
+
+	err := p.Publish(ctx)
+	assert.EqualError(t, err, errBetaReviewRejected{Build: "1.0 (99)"}.Error())
+	assert.False(t, client.notified)
+}
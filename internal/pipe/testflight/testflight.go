@@ -23,11 +23,14 @@ package testflight
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cidertool/asc-go/asc"
 	"github.com/cidertool/cider/internal/client"
+	"github.com/cidertool/cider/internal/keys"
 	"github.com/cidertool/cider/internal/log"
 	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/internal/shell"
 	"github.com/cidertool/cider/pkg/config"
 	"github.com/cidertool/cider/pkg/context"
 )
@@ -35,6 +38,7 @@ import (
 // Pipe is a global hook pipe.
 type Pipe struct {
 	Client client.Client
+	Shell  shell.Shell
 }
 
 // String is the name of this pipe.
@@ -56,7 +60,7 @@ func (p *Pipe) Publish(ctx *context.Context) error {
 
 		ctx.Log.WithField("name", name).Info("preparing")
 
-		err := p.doRelease(ctx, app)
+		err := p.doRelease(ctx, name, app)
 		if err != nil {
 			return err
 		}
@@ -65,7 +69,7 @@ func (p *Pipe) Publish(ctx *context.Context) error {
 	return nil
 }
 
-func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
+func (p *Pipe) doRelease(ctx *context.Context, name string, config config.App) error {
 	app, err := p.Client.GetAppForBundleID(ctx, config.BundleID)
 	if err != nil {
 		return err
@@ -86,6 +90,13 @@ func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
 	if ctx.SkipUpdateMetadata {
 		ctx.Log.Warn("skipping updating metdata")
 	} else {
+		if config.Testflight.WhatToTest != nil {
+			// Make the links of existing groups available to the notes template
+			if err := p.loadPublicLinks(ctx, name, app); err != nil {
+				return err
+			}
+		}
+
 		ctx.Log.Info("updating metadata")
 		if err := p.updateBetaDetails(ctx, config, app, build); err != nil {
 			return err
@@ -96,6 +107,20 @@ func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
 		if err := p.updateBetaGroups(ctx, config, app, build); err != nil {
 			return err
 		}
+
+		// Read the links back, so groups created or made public by this release are included
+		if err := p.loadPublicLinks(ctx, name, app); err != nil {
+			return err
+		}
+
+		for _, group := range keys.Sorted(ctx.PublicLinks[name]) {
+			ctx.Log.
+				WithFields(log.Fields{
+					"group": group,
+					"link":  ctx.PublicLinks[name][group],
+				}).
+				Info("public link")
+		}
 	}
 
 	if !ctx.SkipUpdateMetadata || ctx.OverrideBetaTesters {
@@ -104,15 +129,33 @@ func (p *Pipe) doRelease(ctx *context.Context, config config.App) error {
 		}
 	}
 
+	if !ctx.SkipUpdateMetadata && config.Testflight.ExpireBuilds != nil {
+		if err := p.expireBuilds(ctx, config, app, build); err != nil {
+			return err
+		}
+	}
+
 	if ctx.SkipSubmit {
 		return pipe.ErrSkipSubmitEnabled
 	}
 
+	if onlyInternalGroups(config.Testflight) {
+		ctx.Log.
+			WithField("build", buildVersionLog).
+			Info("skipping beta app review, the build is only distributed to internal beta groups")
+
+		return p.notifyTesters(ctx, name, config, build, buildVersionLog, false)
+	}
+
 	ctx.Log.
 		WithField("build", buildVersionLog).
 		Info("submitting to testflight")
 
-	return p.Client.SubmitBetaApp(ctx, build.ID)
+	if err := p.Client.SubmitBetaApp(ctx, build.ID); err != nil {
+		return err
+	}
+
+	return p.notifyTesters(ctx, name, config, build, buildVersionLog, true)
 }
 
 func (p *Pipe) updateBetaDetails(ctx *context.Context, config config.App, app *asc.App, build *asc.Build) error {
@@ -128,9 +171,27 @@ func (p *Pipe) updateBetaDetails(ctx *context.Context, config config.App, app *a
 		return err
 	}
 
-	ctx.Log.Infof("updating %d beta build localizations", len(config.Testflight.Localizations))
+	localizations := config.Testflight.Localizations
+
+	if config.Testflight.WhatToTest != nil {
+		locales := make([]string, 0, len(localizations))
+		for locale := range localizations {
+			locales = append(locales, locale)
+		}
 
-	if err := p.Client.UpdateBetaBuildLocalizations(ctx, build.ID, config.Testflight.Localizations); err != nil {
+		sort.Strings(locales)
+
+		notes, err := p.whatToTest(ctx, *config.Testflight.WhatToTest, locales, app, build)
+		if err != nil {
+			return err
+		}
+
+		localizations = withWhatsNew(localizations, notes)
+	}
+
+	ctx.Log.Infof("updating %d beta build localizations", len(localizations))
+
+	if err := p.Client.UpdateBetaBuildLocalizations(ctx, build.ID, localizations); err != nil {
 		return err
 	}
 
@@ -151,14 +212,101 @@ func (p *Pipe) updateBetaDetails(ctx *context.Context, config config.App, app *a
 	return nil
 }
 
-func (p *Pipe) updateBetaGroups(ctx *context.Context, config config.App, app *asc.App, build *asc.Build) error {
+func (p *Pipe) updateBetaGroups(ctx *context.Context, cfg config.App, app *asc.App, build *asc.Build) error {
 	ctx.Log.Info("updating build beta groups")
 
-	return p.Client.AssignBetaGroups(ctx, app.ID, build.ID, config.Testflight.BetaGroups)
+	groups := make([]config.BetaGroup, len(cfg.Testflight.BetaGroups))
+
+	for i, group := range cfg.Testflight.BetaGroups {
+		testers, err := group.ResolveTesters(ctx.CurrentDirectory)
+		if err != nil {
+			return err
+		}
+
+		group.Testers = testers
+		groups[i] = group
+	}
+
+	if err := p.Client.AssignBetaGroups(ctx, app.ID, build.ID, groups); err != nil {
+		return err
+	}
+
+	if cfg.Testflight.RemovePreviousBuilds {
+		ctx.Log.Info("removing previous builds from beta groups")
+
+		if err := p.Client.RemovePreviousBuildsFromBetaGroups(ctx, app.ID, build.ID, groups); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Testflight.Prune {
+		ctx.Log.Info("pruning unlisted beta groups")
+
+		pruned, err := p.Client.PruneBetaGroups(ctx, app.ID, groups, cfg.Testflight.PrunePattern)
+		if err != nil {
+			return err
+		}
+
+		ctx.Log.WithField("dryRun", ctx.PruneDryRun).Infof("pruned %d beta groups", len(pruned))
+	}
+
+	return nil
+}
+
+// loadPublicLinks reads the public links of the app's beta groups into ctx.PublicLinks, replacing any links read
+// earlier in this release.
+func (p *Pipe) loadPublicLinks(ctx *context.Context, name string, app *asc.App) error {
+	links, err := p.Client.ListBetaGroupPublicLinks(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.PublicLinks[name] = links
+
+	return nil
 }
 
-func (p *Pipe) updateBetaTesters(ctx *context.Context, config config.App, app *asc.App, build *asc.Build) error {
+func (p *Pipe) updateBetaTesters(ctx *context.Context, cfg config.App, app *asc.App, build *asc.Build) error {
 	ctx.Log.Info("updating build beta testers")
 
-	return p.Client.AssignBetaTesters(ctx, app.ID, build.ID, config.Testflight.BetaTesters)
+	if err := p.Client.AssignBetaTesters(ctx, app.ID, build.ID, cfg.Testflight.BetaTesters); err != nil {
+		return err
+	}
+
+	if cfg.Testflight.BetaTestersSync == config.SyncModeExact {
+		ctx.Log.Info("removing unlisted beta testers")
+
+		return p.Client.RemoveUnlistedBetaTesters(ctx, app.ID, cfg.Testflight.BetaTesters)
+	}
+
+	return nil
+}
+
+func (p *Pipe) expireBuilds(ctx *context.Context, cfg config.App, app *asc.App, build *asc.Build) error {
+	ctx.Log.Info("expiring old builds")
+
+	expired, err := p.Client.ExpireBuilds(ctx, app.ID, build.ID, *cfg.Testflight.ExpireBuilds)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log.Infof("expired %d builds", expired)
+
+	return nil
+}
+
+// onlyInternalGroups returns true if builds are only distributed to internal beta groups, which do not need
+// Beta App Review.
+func onlyInternalGroups(cfg config.Testflight) bool {
+	if len(cfg.BetaGroups) == 0 || len(cfg.BetaTesters) > 0 {
+		return false
+	}
+
+	for _, group := range cfg.BetaGroups {
+		if !group.IsInternal {
+			return false
+		}
+	}
+
+	return true
 }
@@ -23,8 +23,10 @@ package testflight
 import (
 	"testing"
 
+	"github.com/cidertool/asc-go/asc"
 	"github.com/cidertool/cider/internal/client/clienttest"
 	"github.com/cidertool/cider/internal/pipe"
+	"github.com/cidertool/cider/internal/shell/shelltest"
 	"github.com/cidertool/cider/pkg/config"
 	"github.com/cidertool/cider/pkg/context"
 	"github.com/stretchr/testify/assert"
@@ -64,6 +66,170 @@ func TestTestflight_Happy(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTestflight_Happy_ExactSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Testflight: config.Testflight{
+				BetaGroups: []config.BetaGroup{
+					{Name: "TEST", Sync: config.SyncModeExact},
+				},
+				BetaTesters: []config.BetaTester{
+					{Email: "test@example.com"},
+				},
+				BetaTestersSync: config.SyncModeExact,
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestTestflight_Happy_Prune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Testflight: config.Testflight{
+				BetaGroups: []config.BetaGroup{
+					{Name: "Sprint 42"},
+				},
+				Prune:        true,
+				PrunePattern: "Sprint *",
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+	ctx.PruneDryRun = true
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestTestflight_Happy_WhatToTest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Testflight: config.Testflight{
+				Localizations: config.TestflightLocalizations{
+					"en-US": {Description: "TEST"},
+				},
+				WhatToTest: &config.WhatToTest{
+					Commits: true,
+				},
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+	p.Shell = &shelltest.Shell{
+		T:        t,
+		Context:  ctx,
+		Commands: []shelltest.Command{{Stdout: "Fix a crash on launch"}},
+	}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestLoadPublicLinks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.loadPublicLinks(ctx, "TEST", &asc.App{ID: "TEST"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TEST": "https://testflight.apple.com/join/TEST"}, ctx.PublicLinks["TEST"])
+
+	ctx.PublicLinks["TEST"] = map[string]string{}
+
+	err = p.loadPublicLinks(ctx, "TEST", &asc.App{ID: "TEST"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"TEST": "https://testflight.apple.com/join/TEST"}, ctx.PublicLinks["TEST"])
+}
+
+func TestTestflight_Happy_Builds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Testflight: config.Testflight{
+				BetaGroups: []config.BetaGroup{
+					{Name: "TEST"},
+				},
+				RemovePreviousBuilds: true,
+				ExpireBuilds: &config.ExpireBuilds{
+					KeepLatest:    3,
+					OlderThanDays: 30,
+				},
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestTestflight_Happy_InternalOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{
+		"TEST": {
+			BundleID: "com.test.TEST",
+			Testflight: config.Testflight{
+				BetaGroups: []config.BetaGroup{
+					{Name: "Team", IsInternal: true},
+				},
+			},
+		},
+	})
+	ctx.AppsToRelease = []string{"TEST"}
+
+	p := Pipe{}
+	p.Client = &clienttest.Client{}
+
+	err := p.Publish(ctx)
+	assert.NoError(t, err)
+}
+
+func TestOnlyInternalGroups(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, onlyInternalGroups(config.Testflight{}))
+	assert.True(t, onlyInternalGroups(config.Testflight{
+		BetaGroups: []config.BetaGroup{{Name: "Team", IsInternal: true}},
+	}))
+	assert.False(t, onlyInternalGroups(config.Testflight{
+		BetaGroups: []config.BetaGroup{{Name: "Team", IsInternal: true}, {Name: "Public"}},
+	}))
+	assert.False(t, onlyInternalGroups(config.Testflight{
+		BetaGroups:  []config.BetaGroup{{Name: "Team", IsInternal: true}},
+		BetaTesters: []config.BetaTester{{Email: "test@example.com"}},
+	}))
+}
+
 func TestTestflight_Happy_Skips(t *testing.T) {
 	t.Parallel()
 
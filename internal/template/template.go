@@ -36,6 +36,8 @@ const (
 	envKey       = "env"
 	dateKey      = "date"
 	timestampKey = "timestamp"
+	profileKey   = "profile"
+	linksKey     = "publicLinks"
 )
 
 // Template is used to apply text templates to strings to dynamically configure API values. See the documentation of
@@ -55,6 +57,8 @@ func New(ctx *context.Context) *Template {
 			envKey:       ctx.Env,
 			dateKey:      ctx.Date.UTC().Format(time.RFC3339),
 			timestampKey: ctx.Date.UTC().Unix(),
+			profileKey:   ctx.Profile,
+			linksKey:     ctx.PublicLinks,
 		},
 	}
 }
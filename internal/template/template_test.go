@@ -59,3 +59,25 @@ func TestEmptyTemplate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, tmpl)
 }
+
+func TestProfileTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.Profile = "staging"
+
+	tmpl, err := New(ctx).Apply(`My App{{ if eq .profile "staging" }} Beta{{ end }}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "My App Beta", tmpl)
+}
+
+func TestPublicLinksTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.New(config.Project{})
+	ctx.PublicLinks["My App"] = map[string]string{"Public": "https://testflight.apple.com/join/TEST"}
+
+	tmpl, err := New(ctx).Apply(`Join at {{ index .publicLinks "My App" "Public" }}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Join at https://testflight.apple.com/join/TEST", tmpl)
+}
@@ -0,0 +1,135 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"github.com/cidertool/asc-go/asc"
+)
+
+// platformTypes maps each Platform to the platform App Store Connect knows it as. Apple Watch apps are
+// released as part of their iOS app, so watchOS is not a platform of its own.
+// nolint: gochecknoglobals
+var platformTypes = []struct {
+	config Platform
+	api    asc.Platform
+}{
+	{PlatformiOS, asc.PlatformIOS},
+	{PlatformMacOS, asc.PlatformMACOS},
+	{PlatformTvOS, asc.PlatformTVOS},
+	{PlatformVisionOS, asc.Platform("VISION_OS")},
+}
+
+// previewTypes maps each preview type to the preview type App Store Connect knows it as. Preview types that
+// App Store Connect doesn't tell apart, such as iPhone 6.7 and 6.9, share a value, and the first of them is
+// preferred when looking up a configured preview set from an API value.
+// nolint: gochecknoglobals
+var previewTypes = []struct {
+	config previewType
+	api    asc.PreviewType
+}{
+	{PreviewTypeAppleTV, asc.PreviewTypeAppleTV},
+	{PreviewTypeAppleTV4K, asc.PreviewTypeAppleTV},
+	{PreviewTypeAppleVisionPro, asc.PreviewType("APPLE_VISION_PRO")},
+	{PreviewTypeDesktop, asc.PreviewTypeDesktop},
+	{PreviewTypeiPad105, asc.PreviewTypeiPad105},
+	{PreviewTypeiPad97, asc.PreviewTypeiPad97},
+	{PreviewTypeiPadPro129, asc.PreviewTypeiPadPro129},
+	{PreviewTypeiPadPro3Gen11, asc.PreviewTypeiPadPro3Gen11},
+	{PreviewTypeiPadPro3Gen129, asc.PreviewTypeiPadPro3Gen129},
+	{PreviewTypeiPadPro13, asc.PreviewTypeiPadPro3Gen129},
+	{PreviewTypeiPhone35, asc.PreviewTypeiPhone35},
+	{PreviewTypeiPhone40, asc.PreviewTypeiPhone40},
+	{PreviewTypeiPhone47, asc.PreviewTypeiPhone47},
+	{PreviewTypeiPhone55, asc.PreviewTypeiPhone55},
+	{PreviewTypeiPhone58, asc.PreviewTypeiPhone58},
+	{PreviewTypeiPhone61, asc.PreviewType("IPHONE_61")},
+	{PreviewTypeiPhone65, asc.PreviewTypeiPhone65},
+	{PreviewTypeiPhone67, asc.PreviewType("IPHONE_67")},
+	{PreviewTypeiPhone69, asc.PreviewType("IPHONE_67")},
+	{PreviewTypeWatchSeries3, asc.PreviewTypeWatchSeries3},
+	{PreviewTypeWatchSeries4, asc.PreviewTypeWatchSeries4},
+}
+
+// screenshotTypes maps each screenshot type to the display type App Store Connect knows it as, like
+// previewTypes.
+// nolint: gochecknoglobals
+var screenshotTypes = []struct {
+	config screenshotType
+	api    asc.ScreenshotDisplayType
+}{
+	{ScreenshotTypeAppleTV, asc.ScreenshotDisplayTypeAppAppleTV},
+	{ScreenshotTypeAppleTV4K, asc.ScreenshotDisplayTypeAppAppleTV},
+	{ScreenshotTypeAppleVisionPro, asc.ScreenshotDisplayType("APP_APPLE_VISION_PRO")},
+	{ScreenshotTypeDesktop, asc.ScreenshotDisplayTypeAppDesktop},
+	{ScreenshotTypeiPad105, asc.ScreenshotDisplayTypeAppiPad105},
+	{ScreenshotTypeiPad97, asc.ScreenshotDisplayTypeAppiPad97},
+	{ScreenshotTypeiPadPro129, asc.ScreenshotDisplayTypeAppiPadPro129},
+	{ScreenshotTypeiPadPro3Gen11, asc.ScreenshotDisplayTypeAppiPadPro3Gen11},
+	{ScreenshotTypeiPadPro3Gen129, asc.ScreenshotDisplayTypeAppiPadPro3Gen129},
+	{ScreenshotTypeiPadPro13, asc.ScreenshotDisplayTypeAppiPadPro3Gen129},
+	{ScreenshotTypeiPhone35, asc.ScreenshotDisplayTypeAppiPhone35},
+	{ScreenshotTypeiPhone40, asc.ScreenshotDisplayTypeAppiPhone40},
+	{ScreenshotTypeiPhone47, asc.ScreenshotDisplayTypeAppiPhone47},
+	{ScreenshotTypeiPhone55, asc.ScreenshotDisplayTypeAppiPhone55},
+	{ScreenshotTypeiPhone58, asc.ScreenshotDisplayTypeAppiPhone58},
+	{ScreenshotTypeiPhone61, asc.ScreenshotDisplayType("APP_IPHONE_61")},
+	{ScreenshotTypeiPhone65, asc.ScreenshotDisplayTypeAppiPhone65},
+	{ScreenshotTypeiPhone67, asc.ScreenshotDisplayType("APP_IPHONE_67")},
+	{ScreenshotTypeiPhone69, asc.ScreenshotDisplayType("APP_IPHONE_67")},
+	{ScreenshotTypeWatchSeries3, asc.ScreenshotDisplayTypeAppWatchSeries3},
+	{ScreenshotTypeWatchSeries4, asc.ScreenshotDisplayTypeAppWatchSeries4},
+	{ScreenshotTypeWatchSeries7, asc.ScreenshotDisplayType("APP_WATCH_SERIES_7")},
+	{ScreenshotTypeWatchSeries10, asc.ScreenshotDisplayType("APP_WATCH_SERIES_10")},
+	{ScreenshotTypeWatchUltra, asc.ScreenshotDisplayType("APP_WATCH_ULTRA")},
+	{ScreenshotTypeiMessageiPad105, asc.ScreenshotDisplayTypeiMessageAppIPad105},
+	{ScreenshotTypeiMessageiPad97, asc.ScreenshotDisplayTypeiMessageAppIPad97},
+	{ScreenshotTypeiMessageiPadPro129, asc.ScreenshotDisplayTypeiMessageAppIPadPro129},
+	{ScreenshotTypeiMessageiPadPro3Gen11, asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen11},
+	{ScreenshotTypeiMessageiPadPro3Gen129, asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen129},
+	{ScreenshotTypeiMessageiPhone40, asc.ScreenshotDisplayTypeiMessageAppIPhone40},
+	{ScreenshotTypeiMessageiPhone47, asc.ScreenshotDisplayTypeiMessageAppIPhone47},
+	{ScreenshotTypeiMessageiPhone55, asc.ScreenshotDisplayTypeiMessageAppIPhone55},
+	{ScreenshotTypeiMessageiPhone58, asc.ScreenshotDisplayTypeiMessageAppIPhone58},
+	{ScreenshotTypeiMessageiPhone61, asc.ScreenshotDisplayType("IMESSAGE_APP_IPHONE_61")},
+	{ScreenshotTypeiMessageiPhone65, asc.ScreenshotDisplayTypeiMessageAppIPhone65},
+	{ScreenshotTypeiMessageiPhone67, asc.ScreenshotDisplayType("IMESSAGE_APP_IPHONE_67")},
+}
+
+// requiredScreenshotTypes lists the displays App Store Connect requires screenshots of to submit a version for
+// review on each platform, along with the display types that satisfy each of them.
+// nolint: gochecknoglobals
+var requiredScreenshotTypes = []struct {
+	platform Platform
+	display  string
+	types    []asc.ScreenshotDisplayType
+}{
+	{PlatformiOS, "iPhone", []asc.ScreenshotDisplayType{
+		asc.ScreenshotDisplayType("APP_IPHONE_67"),
+		asc.ScreenshotDisplayTypeAppiPhone65,
+	}},
+	{PlatformiOS, "iPad", []asc.ScreenshotDisplayType{
+		asc.ScreenshotDisplayTypeAppiPadPro3Gen129,
+		asc.ScreenshotDisplayTypeAppiPadPro129,
+	}},
+	{PlatformMacOS, "Mac", []asc.ScreenshotDisplayType{asc.ScreenshotDisplayTypeAppDesktop}},
+	{PlatformTvOS, "Apple TV", []asc.ScreenshotDisplayType{asc.ScreenshotDisplayTypeAppAppleTV}},
+	{PlatformVisionOS, "Apple Vision Pro", []asc.ScreenshotDisplayType{asc.ScreenshotDisplayType("APP_APPLE_VISION_PRO")}},
+}
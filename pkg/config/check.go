@@ -0,0 +1,770 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"fmt"
+	pathpkg "path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cidertool/asc-go/asc"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Check checks constraints between values of the project that the configuration schema cannot express, such as
+// territories with more than one introductory offer or privacy data types declared twice. Problems are returned as
+// ValidationErrors with the path of the offending value.
+func (p Project) Check() error {
+	var result *multierror.Error
+	for _, err := range p.check() {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Check checks constraints between values of config, which was decoded from the document, like Project.Check.
+// Problems are attributed to the position of the offending value, and returned in the order they appear in
+// the document.
+func (s *Source) Check(config Project) error {
+	errs := s.attribute(config.check())
+
+	var result *multierror.Error
+	for _, err := range errs {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// MissingScreenshots reports the localizations of each version that leave out screenshots of a display
+// App Store Connect requires to submit the version for review, taking screenshot fallbacks into account.
+// Localizations without screenshot sets are not reported, as their screenshots may be managed in App Store
+// Connect instead.
+func (p Project) MissingScreenshots() []ValidationError {
+	var errs []ValidationError
+
+	for name, app := range p {
+		versionsPath := joinPath(name, "versions")
+
+		for _, version := range app.Versions.PlatformVersions() {
+			for locale, loc := range version.Localizations {
+				if len(loc.ScreenshotSets) == 0 {
+					continue
+				}
+
+				sets := loc.ScreenshotSets.WithFallbacks(loc.ScreenshotFallbacks)
+
+				for _, required := range requiredScreenshotTypes {
+					if required.platform != version.Platform || sets.hasAny(required.types) {
+						continue
+					}
+
+					locPath := app.Versions.localizationPath(versionsPath, version.Platform, locale, func(loc VersionLocalization) bool {
+						return len(loc.ScreenshotSets) > 0
+					})
+
+					errs = append(errs, ValidationError{
+						Path: joinPath(locPath, "screenshotSets"),
+						Message: fmt.Sprintf("no %s screenshots for %s, expected one of %s",
+							required.display, version.Platform, strings.Join(screenshotTypesOf(required.types), ", ")),
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Path < errs[j].Path
+	})
+
+	return errs
+}
+
+// MissingScreenshots reports missing screenshots of config, which was decoded from the document, like
+// Project.MissingScreenshots, attributed to the position of the offending screenshot sets.
+func (s *Source) MissingScreenshots(config Project) []ValidationError {
+	return s.attribute(config.MissingScreenshots())
+}
+
+// attribute attributes errs to the positions of the values at their paths, and orders them as they appear in
+// the document.
+func (s *Source) attribute(errs []ValidationError) []ValidationError {
+	for i, err := range errs {
+		if verr, ok := s.Locate(err.Path); ok {
+			verr.Message = err.Message
+			errs[i] = verr
+		}
+	}
+
+	sortValidationErrors(errs)
+
+	return errs
+}
+
+func (s ScreenshotSets) hasAny(types []asc.ScreenshotDisplayType) bool {
+	for screenshotType := range s {
+		value := screenshotType.APIValue()
+		if value == nil {
+			continue
+		}
+
+		for _, t := range types {
+			if *value == t {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// screenshotTypesOf returns the screenshot types that are uploaded as any of the display types.
+func screenshotTypesOf(types []asc.ScreenshotDisplayType) []string {
+	var names []string
+
+	for _, t := range types {
+		for _, st := range screenshotTypes {
+			if st.api == t {
+				names = append(names, string(st.config))
+			}
+		}
+	}
+
+	return names
+}
+
+func (p Project) check() []ValidationError {
+	var errs []ValidationError
+
+	for name, app := range p {
+		errs = append(errs, app.checkVersions(name)...)
+		errs = append(errs, app.checkSubscriptions(name)...)
+		errs = append(errs, app.checkPrivacy(name)...)
+		errs = append(errs, app.checkInAppEvents(name)...)
+		errs = append(errs, app.checkPricing(name)...)
+		errs = append(errs, app.checkTestflight(name)...)
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Path < errs[j].Path
+	})
+
+	return errs
+}
+
+func (a App) checkVersions(path string) []ValidationError {
+	var errs []ValidationError
+
+	versionsPath := joinPath(path, "versions")
+
+	if a.Versions.Platform != "" && len(a.Versions.Platforms) > 0 {
+		errs = append(errs, ValidationError{
+			Path:    joinPath(versionsPath, "platform"),
+			Message: "platform cannot be set along with platforms",
+		})
+	}
+
+	for platform := range a.Versions.Platforms {
+		if platform.APIValue() == nil {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(joinPath(versionsPath, "platforms"), string(platform)),
+				Message: fmt.Sprintf("unknown platform %q", platform),
+			})
+		}
+	}
+
+	for locale, loc := range a.Versions.Localizations {
+		errs = append(errs, checkAssetSets(joinPath(joinPath(versionsPath, "localizations"), locale), loc)...)
+	}
+
+	for platform, platformVersion := range a.Versions.Platforms {
+		platformPath := joinPath(joinPath(versionsPath, "platforms"), string(platform))
+		for locale, loc := range platformVersion.Localizations {
+			errs = append(errs, checkAssetSets(joinPath(joinPath(platformPath, "localizations"), locale), loc)...)
+		}
+	}
+
+	errs = append(errs, a.checkScreenshotFallbacks(versionsPath)...)
+
+	for _, version := range a.Versions.PlatformVersions() {
+		for locale, loc := range version.Localizations {
+			if loc.Description != "" {
+				continue
+			}
+
+			// Attribute the missing description to the platform's localization when the locale is only
+			// localized for the platform
+			locPath := joinPath(joinPath(versionsPath, "localizations"), locale)
+			if _, ok := a.Versions.Localizations[locale]; !ok {
+				locPath = joinPath(joinPath(joinPath(joinPath(versionsPath, "platforms"), string(version.Platform)), "localizations"), locale)
+			}
+
+			errs = append(errs, ValidationError{
+				Path:    locPath,
+				Message: fmt.Sprintf("version localization has no description on %s", version.Platform),
+			})
+		}
+	}
+
+	return errs
+}
+
+// checkScreenshotFallbacks reports screenshot fallbacks to screenshot types that have no screenshots on a
+// platform the version is released on.
+func (a App) checkScreenshotFallbacks(versionsPath string) []ValidationError {
+	var errs []ValidationError
+
+	reported := make(map[string]bool)
+
+	for _, version := range a.Versions.PlatformVersions() {
+		for locale, loc := range version.Localizations {
+			for screenshotType, from := range loc.ScreenshotFallbacks {
+				if _, ok := loc.ScreenshotSets[from]; ok {
+					continue
+				}
+
+				locPath := a.Versions.localizationPath(versionsPath, version.Platform, locale, func(loc VersionLocalization) bool {
+					_, ok := loc.ScreenshotFallbacks[screenshotType]
+
+					return ok
+				})
+				fallbackPath := joinPath(joinPath(locPath, "screenshotFallbacks"), string(screenshotType))
+
+				if reported[fallbackPath] {
+					continue
+				}
+
+				reported[fallbackPath] = true
+
+				errs = append(errs, ValidationError{
+					Path:    fallbackPath,
+					Message: fmt.Sprintf("screenshot type %s falls back to %s, which has no screenshots on %s", screenshotType, from, version.Platform),
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+// localizationPath returns the path to the localization of locale that sets a value, checking the localization
+// of platform before the shared one.
+func (v Version) localizationPath(versionsPath string, platform Platform, locale string, sets func(VersionLocalization) bool) string {
+	if loc, ok := v.Platforms[platform].Localizations[locale]; ok && sets(loc) {
+		return joinPath(joinPath(joinPath(joinPath(versionsPath, "platforms"), string(platform)), "localizations"), locale)
+	}
+
+	return joinPath(joinPath(versionsPath, "localizations"), locale)
+}
+
+// checkAssetSets reports preview and screenshot sets of a localization that would be uploaded to the same
+// App Store Connect set, such as iphone67 and iphone69.
+func checkAssetSets(path string, loc VersionLocalization) []ValidationError {
+	var errs []ValidationError
+
+	previewSets := make(map[asc.PreviewType]previewType)
+
+	for _, t := range previewTypes {
+		if _, ok := loc.PreviewSets[t.config]; !ok {
+			continue
+		}
+
+		if other, ok := previewSets[t.api]; ok {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(joinPath(path, "previewSets"), string(t.config)),
+				Message: fmt.Sprintf("preview sets %s and %s are both uploaded as %s", other, t.config, t.api),
+			})
+
+			continue
+		}
+
+		previewSets[t.api] = t.config
+	}
+
+	screenshotSets := make(map[asc.ScreenshotDisplayType]screenshotType)
+
+	for _, t := range screenshotTypes {
+		if _, ok := loc.ScreenshotSets[t.config]; !ok {
+			continue
+		}
+
+		if other, ok := screenshotSets[t.api]; ok {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(joinPath(path, "screenshotSets"), string(t.config)),
+				Message: fmt.Sprintf("screenshot sets %s and %s are both uploaded as %s", other, t.config, t.api),
+			})
+
+			continue
+		}
+
+		screenshotSets[t.api] = t.config
+	}
+
+	return errs
+}
+
+func (a App) checkSubscriptions(path string) []ValidationError {
+	var errs []ValidationError
+
+	// Product IDs are unique across the in-app purchases and subscriptions of an app
+	products := make(map[string][]string)
+	for productID, iap := range a.InAppPurchases {
+		iapPath := joinPath(joinPath(path, "inAppPurchases"), productID)
+		products[productID] = append(products[productID], iapPath)
+
+		if _, err := strconv.ParseFloat(iap.Price, 64); iap.Price != "" && err != nil {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(iapPath, "price"),
+				Message: fmt.Sprintf("price %q is not a number", iap.Price),
+			})
+		}
+	}
+
+	for groupName, group := range a.SubscriptionGroups {
+		groupPath := joinPath(joinPath(path, "subscriptionGroups"), groupName)
+
+		for productID, subscription := range group.Subscriptions {
+			subPath := joinPath(joinPath(groupPath, "subscriptions"), productID)
+			products[productID] = append(products[productID], subPath)
+
+			errs = append(errs, subscription.check(subPath)...)
+		}
+	}
+
+	for productID, paths := range products {
+		sort.Strings(paths)
+
+		for _, duplicate := range paths[1:] {
+			errs = append(errs, ValidationError{
+				Path:    duplicate,
+				Message: fmt.Sprintf("product ID %s is already used by %s", productID, paths[0]),
+			})
+		}
+	}
+
+	return errs
+}
+
+func (s Subscription) check(path string) []ValidationError {
+	var errs []ValidationError
+
+	errs = append(errs, checkPrices(joinPath(path, "prices"), s.Prices)...)
+
+	offered := make(map[string]bool)
+
+	for i, offer := range s.IntroductoryOffers {
+		offerPath := fmt.Sprintf("%s[%d]", joinPath(path, "introductoryOffers"), i)
+		territories := offer.AvailableTerritories(s)
+
+		if len(territories) == 0 {
+			errs = append(errs, ValidationError{
+				Path:    offerPath,
+				Message: "introductory offer is not available in any territory, set its territories or the prices of the subscription",
+			})
+		}
+
+		for _, territory := range territories {
+			if offered[territory] {
+				errs = append(errs, ValidationError{
+					Path:    offerPath,
+					Message: fmt.Sprintf("only one introductory offer is allowed per territory, but %s has several", territory),
+				})
+			}
+
+			offered[territory] = true
+
+			if _, ok := s.Prices[territory]; !ok && len(s.Prices) > 0 {
+				errs = append(errs, ValidationError{
+					Path:    joinPath(offerPath, "territories"),
+					Message: fmt.Sprintf("territory %s has no price for the subscription", territory),
+				})
+			}
+
+			if _, ok := offer.Prices[territory]; !ok && offer.Mode != OfferModeFreeTrial {
+				errs = append(errs, ValidationError{
+					Path:    offerPath,
+					Message: fmt.Sprintf("%s offer has no price for territory %s", offer.Mode, territory),
+				})
+			}
+		}
+
+		if offer.Mode == OfferModeFreeTrial && len(offer.Prices) > 0 {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(offerPath, "prices"),
+				Message: "free trials cannot have prices",
+			})
+		}
+
+		if offer.Mode != OfferModePayAsYouGo && offer.Periods > 1 {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(offerPath, "periods"),
+				Message: fmt.Sprintf("periods only apply to %s offers", OfferModePayAsYouGo),
+			})
+		}
+
+		if offer.StartDate != nil && offer.EndDate != nil && offer.EndDate.Before(*offer.StartDate) {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(offerPath, "endDate"),
+				Message: "introductory offer ends before it starts",
+			})
+		}
+
+		errs = append(errs, checkPrices(joinPath(offerPath, "prices"), offer.Prices)...)
+	}
+
+	return errs
+}
+
+func (a App) checkPrivacy(path string) []ValidationError {
+	if a.Privacy == nil {
+		return nil
+	}
+
+	var errs []ValidationError
+
+	declared := make(map[privacyDataType]bool)
+
+	for i, dataType := range a.Privacy.DataTypes {
+		dataTypePath := fmt.Sprintf("%s[%d]", joinPath(joinPath(path, "privacy"), "dataTypes"), i)
+
+		if dataType.Type.Category() == "" {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(dataTypePath, "type"),
+				Message: fmt.Sprintf("unknown data type %q", dataType.Type),
+			})
+		} else if declared[dataType.Type] {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(dataTypePath, "type"),
+				Message: fmt.Sprintf("data type %s is declared more than once", dataType.Type),
+			})
+		}
+
+		declared[dataType.Type] = true
+
+		if len(dataType.Purposes) == 0 {
+			errs = append(errs, ValidationError{
+				Path:    dataTypePath,
+				Message: fmt.Sprintf("data type %s must be used for at least one purpose", dataType.Type),
+			})
+		}
+
+		purposes := make(map[privacyPurpose]bool)
+
+		for j, purpose := range dataType.Purposes {
+			purposePath := fmt.Sprintf("%s[%d]", joinPath(dataTypePath, "purposes"), j)
+
+			if _, ok := privacyPurposeNames[purpose]; !ok {
+				errs = append(errs, ValidationError{
+					Path:    purposePath,
+					Message: fmt.Sprintf("unknown purpose %q", purpose),
+				})
+			} else if purposes[purpose] {
+				errs = append(errs, ValidationError{
+					Path:    purposePath,
+					Message: fmt.Sprintf("purpose %s is listed more than once", purpose),
+				})
+			}
+
+			purposes[purpose] = true
+		}
+	}
+
+	usesIDFA := false
+	for _, version := range a.Versions.PlatformVersions() {
+		usesIDFA = usesIDFA || version.IDFADeclaration != nil
+	}
+
+	if usesIDFA && !a.Privacy.Collects(PrivacyDataTypeDeviceID) {
+		errs = append(errs, ValidationError{
+			Path:    joinPath(path, "privacy"),
+			Message: fmt.Sprintf("versions.idfaDeclaration declares use of the advertising identifier, but %s is not collected", PrivacyDataTypeDeviceID),
+		})
+	}
+
+	// App Store Connect links the App Privacy details to the privacy policy of the app
+	if len(a.Privacy.DataTypes) > 0 {
+		for locale, loc := range a.Localizations {
+			if loc.PrivacyPolicyURL == "" {
+				errs = append(errs, ValidationError{
+					Path:    joinPath(joinPath(path, "localizations"), locale),
+					Message: "apps that collect data need a privacyPolicyURL in every localization",
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+func (a App) checkInAppEvents(path string) []ValidationError {
+	var errs []ValidationError
+
+	for name, event := range a.InAppEvents {
+		eventPath := joinPath(joinPath(path, "inAppEvents"), name)
+
+		if len(event.Territories) == 0 {
+			errs = append(errs, ValidationError{
+				Path:    eventPath,
+				Message: "in-app event is not shown in any territory",
+			})
+		}
+
+		errs = append(errs, event.checkSchedule(eventPath)...)
+
+		for locale, loc := range event.Localizations {
+			locPath := joinPath(joinPath(eventPath, "localizations"), locale)
+
+			errs = append(errs, checkLength(joinPath(locPath, "name"), loc.Name, inAppEventNameLimit)...)
+			errs = append(errs, checkLength(joinPath(locPath, "shortDescription"), loc.ShortDescription, inAppEventShortDescriptionLimit)...)
+			errs = append(errs, checkLength(joinPath(locPath, "longDescription"), loc.LongDescription, inAppEventLongDescriptionLimit)...)
+		}
+	}
+
+	return errs
+}
+
+// checkSchedule checks the dates of the event that are not templated, as templated dates are only known when
+// releasing.
+func (e InAppEvent) checkSchedule(path string) []ValidationError {
+	var errs []ValidationError
+
+	dates := make(map[string]time.Time)
+
+	for key, value := range map[string]string{
+		"publishStart": e.PublishStart,
+		"eventStart":   e.EventStart,
+		"eventEnd":     e.EventEnd,
+	} {
+		if value == "" || isTemplated(value) {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, key),
+				Message: fmt.Sprintf("date %q is not an RFC 3339 timestamp", value),
+			})
+
+			continue
+		}
+
+		dates[key] = date
+	}
+
+	start, hasStart := dates["eventStart"]
+	if !hasStart {
+		return errs
+	}
+
+	if end, ok := dates["eventEnd"]; ok {
+		if !end.After(start) {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, "eventEnd"),
+				Message: "in-app event ends before it starts",
+			})
+		} else if end.Sub(start) > inAppEventMaxDuration {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, "eventEnd"),
+				Message: "in-app events cannot last more than 31 days",
+			})
+		}
+	}
+
+	if publish, ok := dates["publishStart"]; ok {
+		if publish.After(start) {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, "publishStart"),
+				Message: "in-app event is published after it starts",
+			})
+		} else if start.Sub(publish) > inAppEventMaxPublishLead {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, "publishStart"),
+				Message: "in-app events cannot be published more than 14 days before they start",
+			})
+		}
+	}
+
+	return errs
+}
+
+// checkPricing checks that the prices of the app are numbers, that the prices of each territory don't overlap,
+// and that the base territory has a price that takes effect immediately.
+func (a App) checkPricing(path string) []ValidationError {
+	if a.Availability == nil || len(a.Availability.Prices) == 0 {
+		return nil
+	}
+
+	var errs []ValidationError
+
+	availability := *a.Availability
+	pricesPath := joinPath(joinPath(path, "availability"), "prices")
+
+	if len(availability.Pricing) > 0 {
+		errs = append(errs, ValidationError{
+			Path:    pricesPath,
+			Message: "prices cannot be set along with priceTiers",
+		})
+	}
+
+	var hasBasePrice bool
+
+	for i, price := range availability.Prices {
+		pricePath := fmt.Sprintf("%s[%d]", pricesPath, i)
+		territory := availability.priceTerritory(price)
+
+		if _, err := strconv.ParseFloat(price.Price, 64); err != nil {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(pricePath, "price"),
+				Message: fmt.Sprintf("price %q is not a number", price.Price),
+			})
+		}
+
+		if price.StartDate != nil && price.EndDate != nil && !price.EndDate.After(*price.StartDate) {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(pricePath, "endDate"),
+				Message: "price ends before it takes effect",
+			})
+		}
+
+		if territory == availability.PriceBaseTerritory() && price.StartDate == nil {
+			hasBasePrice = true
+		}
+
+		for j, other := range availability.Prices[:i] {
+			if availability.priceTerritory(other) != territory || !price.overlaps(other) {
+				continue
+			}
+
+			errs = append(errs, ValidationError{
+				Path:    pricePath,
+				Message: fmt.Sprintf("price in %s overlaps with the price at %s[%d]", territory, pricesPath, j),
+			})
+		}
+	}
+
+	if !hasBasePrice {
+		errs = append(errs, ValidationError{
+			Path:    pricesPath,
+			Message: fmt.Sprintf("prices need a price in the base territory %s that takes effect immediately", availability.PriceBaseTerritory()),
+		})
+	}
+
+	return errs
+}
+
+// checkTestflight checks that apps that prune beta groups set a valid pattern of the groups to prune.
+func (a App) checkTestflight(path string) []ValidationError {
+	var errs []ValidationError
+
+	testflightPath := joinPath(path, "testflight")
+
+	if a.Testflight.Prune && a.Testflight.PrunePattern == "" {
+		errs = append(errs, ValidationError{
+			Path:    joinPath(testflightPath, "prune"),
+			Message: "prunePattern must be set to prune beta groups",
+		})
+	} else if _, err := pathpkg.Match(a.Testflight.PrunePattern, ""); err != nil {
+		errs = append(errs, ValidationError{
+			Path:    joinPath(testflightPath, "prunePattern"),
+			Message: fmt.Sprintf("invalid pattern %q", a.Testflight.PrunePattern),
+		})
+	}
+
+	if a.Testflight.WhatToTest != nil {
+		errs = append(errs, checkWhatToTest(joinPath(testflightPath, "whatToTest"), *a.Testflight.WhatToTest)...)
+	}
+
+	return errs
+}
+
+// checkWhatToTest checks that exactly one source of the "What to Test" notes is set.
+func checkWhatToTest(path string, whatToTest WhatToTest) []ValidationError {
+	sources := 0
+
+	for _, set := range []bool{whatToTest.File != "", whatToTest.Command != "", whatToTest.Commits} {
+		if set {
+			sources++
+		}
+	}
+
+	if sources != 1 {
+		return []ValidationError{{
+			Path:    path,
+			Message: "must set exactly one of file, command or commits",
+		}}
+	}
+
+	return nil
+}
+
+// checkLength checks that text that is not templated fits in limit characters.
+func checkLength(path string, text string, limit int) []ValidationError {
+	if isTemplated(text) {
+		return nil
+	}
+
+	if length := utf8.RuneCountInString(text); length > limit {
+		return []ValidationError{{
+			Path:    path,
+			Message: fmt.Sprintf("text is %d characters long, but cannot be longer than %d characters", length, limit),
+		}}
+	}
+
+	return nil
+}
+
+func checkPrices(path string, prices map[string]string) []ValidationError {
+	var errs []ValidationError
+
+	for territory, price := range prices {
+		if _, err := strconv.ParseFloat(price, 64); err != nil {
+			errs = append(errs, ValidationError{
+				Path:    joinPath(path, territory),
+				Message: fmt.Sprintf("price %q is not a number", price),
+			})
+		}
+	}
+
+	return errs
+}
+
+// AvailableTerritories returns the territories the offer is available in, which default to the territories
+// the subscription has a price in.
+func (o IntroductoryOffer) AvailableTerritories(subscription Subscription) []string {
+	if len(o.Territories) > 0 {
+		return o.Territories
+	}
+
+	territories := make([]string, 0, len(subscription.Prices))
+	for territory := range subscription.Prices {
+		territories = append(territories, territory)
+	}
+
+	sort.Strings(territories)
+
+	return territories
+}
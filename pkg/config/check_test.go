@@ -0,0 +1,624 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProject_Check(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	proj := Project{
+		"My App": App{
+			SubscriptionGroups: SubscriptionGroups{
+				"Premium": SubscriptionGroup{
+					Subscriptions: Subscriptions{
+						"com.app.premium": Subscription{
+							Duration: SubscriptionDurationOneMonth,
+							Prices:   map[string]string{"USA": "4.99", "GBR": "4.49"},
+							IntroductoryOffers: []IntroductoryOffer{
+								{Mode: OfferModeFreeTrial, Duration: OfferDurationOneWeek, Territories: []string{"USA"}},
+								{
+									Mode:        OfferModePayAsYouGo,
+									Duration:    OfferDurationOneMonth,
+									Periods:     3,
+									Territories: []string{"GBR"},
+									Prices:      map[string]string{"GBR": "0.99"},
+									StartDate:   &start,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			InAppPurchases: InAppPurchases{
+				"com.app.premium": InAppPurchase{Type: InAppPurchaseTypeNonConsumable},
+				"com.app.coins":   InAppPurchase{Type: InAppPurchaseTypeConsumable, Price: "cheap"},
+			},
+			SubscriptionGroups: SubscriptionGroups{
+				"Premium": SubscriptionGroup{
+					Subscriptions: Subscriptions{
+						"com.app.premium": Subscription{
+							Duration: SubscriptionDurationOneMonth,
+							Prices:   map[string]string{"USA": "free"},
+							IntroductoryOffers: []IntroductoryOffer{
+								{Mode: OfferModeFreeTrial, Duration: OfferDurationOneWeek, Prices: map[string]string{"USA": "0"}},
+								{Mode: OfferModePayUpFront, Duration: OfferDurationOneMonth, Periods: 2, Territories: []string{"USA", "GBR"}},
+								{Mode: OfferModeFreeTrial, Duration: OfferDurationOneMonth, Territories: []string{"FRA"}, StartDate: &start, EndDate: &time.Time{}},
+							},
+						},
+						"com.app.empty": Subscription{
+							Duration:           SubscriptionDurationOneYear,
+							IntroductoryOffers: []IntroductoryOffer{{Mode: OfferModeFreeTrial, Duration: OfferDurationOneWeek}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.inAppPurchases.com.app.coins.price: price \"cheap\" is not a number",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.empty.introductoryOffers[0]: introductory offer is not available in any territory, set its territories or the prices of the subscription",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium: product ID com.app.premium is already used by My App.inAppPurchases.com.app.premium",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[0].prices: free trials cannot have prices",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1]: only one introductory offer is allowed per territory, but USA has several",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1]: payUpFront offer has no price for territory USA",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1]: payUpFront offer has no price for territory GBR",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1].periods: periods only apply to payAsYouGo offers",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1].territories: territory GBR has no price for the subscription",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[2].endDate: introductory offer ends before it starts",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[2].territories: territory FRA has no price for the subscription",
+		"My App.subscriptionGroups.Premium.subscriptions.com.app.premium.prices.USA: price \"free\" is not a number",
+	}, messages)
+}
+
+func TestSource_Check(t *testing.T) {
+	t.Parallel()
+
+	source, err := ParseSource("cider.yml", []byte(`My App:
+  id: com.app
+  subscriptionGroups:
+    Premium:
+      localizations:
+        en-US:
+          name: Premium
+      subscriptions:
+        com.app.premium:
+          referenceName: Premium
+          duration: oneMonth
+          prices:
+            USA: "4.99"
+          introductoryOffers:
+            - mode: freeTrial
+              duration: oneWeek
+            - mode: freeTrial
+              duration: oneMonth
+          localizations:
+            en-US:
+              name: Premium
+`))
+	assert.NoError(t, err)
+
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+
+	err = source.Check(proj)
+	assert.EqualError(t, errors.Unwrap(err), "cider.yml:17:15: My App.subscriptionGroups.Premium.subscriptions.com.app.premium.introductoryOffers[1]: only one introductory offer is allowed per territory, but USA has several")
+
+	var verr ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, "            - mode: freeTrial", verr.Snippet)
+
+	proj["My App"].SubscriptionGroups["Premium"].Subscriptions["com.app.premium"] = Subscription{Duration: SubscriptionDurationOneMonth}
+	assert.NoError(t, source.Check(proj))
+}
+
+func TestProject_Check_Privacy(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Localizations: AppLocalizations{
+				"en-US": {PrivacyPolicyURL: "https://example.com/privacy"},
+			},
+			Versions: Version{IDFADeclaration: &IDFADeclaration{ServesAds: true}},
+			Privacy: &Privacy{
+				DataTypes: []PrivacyDataType{
+					{Type: PrivacyDataTypeDeviceID, Purposes: []privacyPurpose{PrivacyPurposeThirdPartyAdvertising}, Tracking: true},
+					{Type: PrivacyDataTypeCrashData, Purposes: []privacyPurpose{PrivacyPurposeAnalytics}},
+				},
+			},
+		},
+		"Other App": App{Privacy: &Privacy{}},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			Localizations: AppLocalizations{
+				"en-US": {},
+			},
+			Versions: Version{IDFADeclaration: &IDFADeclaration{ServesAds: true}},
+			Privacy: &Privacy{
+				DataTypes: []PrivacyDataType{
+					{Type: PrivacyDataTypeCrashData, Purposes: []privacyPurpose{PrivacyPurposeAnalytics, "marketing", PrivacyPurposeAnalytics}},
+					{Type: PrivacyDataTypeCrashData},
+					{Type: "shoeSize", Purposes: []privacyPurpose{PrivacyPurposeOther}},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.localizations.en-US: apps that collect data need a privacyPolicyURL in every localization",
+		"My App.privacy: versions.idfaDeclaration declares use of the advertising identifier, but deviceID is not collected",
+		"My App.privacy.dataTypes[0].purposes[1]: unknown purpose \"marketing\"",
+		"My App.privacy.dataTypes[0].purposes[2]: purpose analytics is listed more than once",
+		"My App.privacy.dataTypes[1]: data type crashData must be used for at least one purpose",
+		"My App.privacy.dataTypes[1].type: data type crashData is declared more than once",
+		"My App.privacy.dataTypes[2].type: unknown data type \"shoeSize\"",
+	}, messages)
+}
+
+func TestProject_Check_InAppEvents(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			InAppEvents: InAppEvents{
+				"Summer": {
+					Territories:  []string{"USA"},
+					PublishStart: "2021-06-01T00:00:00Z",
+					EventStart:   "2021-06-07T00:00:00Z",
+					EventEnd:     "2021-06-21T00:00:00Z",
+					Localizations: InAppEventLocalizations{
+						"en-US": {Name: "Summer Challenge", ShortDescription: "Race all summer"},
+					},
+				},
+				"Templated": {
+					Territories: []string{"USA"},
+					EventStart:  "{{ .env.EVENT_START }}",
+					EventEnd:    "{{ .env.EVENT_END }}",
+					Localizations: InAppEventLocalizations{
+						"en-US": {Name: "{{ .env.EVENT_NAME }} {{ .env.EVENT_NAME }} {{ .env.EVENT_NAME }}"},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			InAppEvents: InAppEvents{
+				"Summer": {
+					PublishStart: "2021-05-01T00:00:00Z",
+					EventStart:   "2021-06-07T00:00:00Z",
+					EventEnd:     "2021-08-01T00:00:00Z",
+					Localizations: InAppEventLocalizations{
+						"en-US": {Name: "The Great Summer Racing Challenge"},
+					},
+				},
+				"Winter": {
+					Territories:  []string{"USA"},
+					PublishStart: "2021-12-10T00:00:00Z",
+					EventStart:   "2021-12-07T00:00:00Z",
+					EventEnd:     "2021-12-01",
+				},
+				"Spring": {
+					Territories: []string{"USA"},
+					EventStart:  "2021-04-07T00:00:00Z",
+					EventEnd:    "2021-04-01T00:00:00Z",
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.inAppEvents.Spring.eventEnd: in-app event ends before it starts",
+		"My App.inAppEvents.Summer: in-app event is not shown in any territory",
+		"My App.inAppEvents.Summer.eventEnd: in-app events cannot last more than 31 days",
+		"My App.inAppEvents.Summer.localizations.en-US.name: text is 33 characters long, but cannot be longer than 30 characters",
+		"My App.inAppEvents.Summer.publishStart: in-app events cannot be published more than 14 days before they start",
+		"My App.inAppEvents.Winter.eventEnd: date \"2021-12-01\" is not an RFC 3339 timestamp",
+		"My App.inAppEvents.Winter.publishStart: in-app event is published after it starts",
+	}, messages)
+}
+
+func TestProject_Check_Pricing(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	proj := Project{
+		"My App": App{
+			Availability: &Availability{
+				Prices: []AppPrice{
+					{Price: "0.99"},
+					{Price: "1.99", StartDate: &jan, EndDate: &feb},
+					{Price: "2.99", StartDate: &feb},
+					{Territory: "GBR", Price: "1.49", StartDate: &mar},
+				},
+			},
+		},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			Availability: &Availability{
+				BaseTerritory: "GBR",
+				Pricing:       []PriceSchedule{{Tier: "1"}},
+				Prices: []AppPrice{
+					{Territory: "GBR", Price: "free", StartDate: &jan},
+					{Territory: "GBR", Price: "1.49", StartDate: &feb, EndDate: &jan},
+					{Territory: "USA", Price: "0.99", EndDate: &mar},
+					{Territory: "USA", Price: "1.99", StartDate: &feb},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.availability.prices: prices cannot be set along with priceTiers",
+		"My App.availability.prices: prices need a price in the base territory GBR that takes effect immediately",
+		"My App.availability.prices[0].price: price \"free\" is not a number",
+		"My App.availability.prices[1].endDate: price ends before it takes effect",
+		"My App.availability.prices[3]: price in USA overlaps with the price at My App.availability.prices[2]",
+	}, messages)
+}
+
+func TestProject_Check_Testflight(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Testflight: Testflight{Prune: true, PrunePattern: "Sprint *"},
+		},
+		"Other App": App{
+			Testflight: Testflight{WhatToTest: &WhatToTest{Commits: true}},
+		},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			Testflight: Testflight{Prune: true},
+		},
+		"Other App": App{
+			Testflight: Testflight{
+				PrunePattern: "Sprint [",
+				WhatToTest:   &WhatToTest{File: "notes.txt", Commits: true},
+			},
+		},
+		"Third App": App{
+			Testflight: Testflight{WhatToTest: &WhatToTest{}},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.testflight.prune: prunePattern must be set to prune beta groups",
+		"Other App.testflight.prunePattern: invalid pattern \"Sprint [\"",
+		"Other App.testflight.whatToTest: must set exactly one of file, command or commits",
+		"Third App.testflight.whatToTest: must set exactly one of file, command or commits",
+	}, messages)
+}
+
+func TestProject_Check_Versions(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Versions: Version{
+				Platforms: VersionPlatforms{PlatformiOS: {}, PlatformMacOS: {}},
+			},
+		},
+	}
+	assert.NoError(t, proj.Check())
+
+	proj = Project{
+		"My App": App{
+			Versions: Version{
+				Platform: PlatformiOS,
+				Localizations: VersionLocalizations{
+					"en-US": {Keywords: "Apps"},
+				},
+				Platforms: VersionPlatforms{
+					PlatformMacOS: {
+						Localizations: VersionLocalizations{
+							"en-US": {Description: "My App for Mac"},
+							"fr-FR": {Keywords: "Applications"},
+						},
+					},
+					"watchOS": {},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.versions.localizations.en-US: version localization has no description on watchOS",
+		"My App.versions.platform: platform cannot be set along with platforms",
+		"My App.versions.platforms.macOS.localizations.fr-FR: version localization has no description on macOS",
+		"My App.versions.platforms.watchOS: unknown platform \"watchOS\"",
+	}, messages)
+}
+
+func TestProject_Check_AssetSets(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Versions: Version{
+				Localizations: VersionLocalizations{
+					"en-US": {
+						Description: "My App",
+						PreviewSets: PreviewSets{
+							PreviewTypeiPhone67: {},
+						},
+						ScreenshotSets: ScreenshotSets{
+							ScreenshotTypeiPhone67:   {},
+							ScreenshotTypeiPhone69:   {},
+							ScreenshotTypeiPadPro13:  {},
+							ScreenshotTypeWatchUltra: {},
+						},
+					},
+				},
+				Platforms: VersionPlatforms{
+					PlatformiOS: {},
+					PlatformVisionOS: {
+						Localizations: VersionLocalizations{
+							"en-US": {
+								PreviewSets: PreviewSets{
+									PreviewTypeAppleVisionPro: {},
+									PreviewTypeAppleTV:        {},
+									PreviewTypeAppleTV4K:      {},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.versions.localizations.en-US.screenshotSets.iphone69: screenshot sets iphone67 and iphone69 are both uploaded as APP_IPHONE_67",
+		"My App.versions.platforms.visionOS.localizations.en-US.previewSets.appleTV4K: preview sets appleTV and appleTV4K are both uploaded as APPLE_TV",
+	}, messages)
+}
+
+func TestProject_Check_ScreenshotFallbacks(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Versions: Version{
+				Localizations: VersionLocalizations{
+					"en-US": {
+						Description: "My App",
+						ScreenshotSets: ScreenshotSets{
+							ScreenshotTypeiPhone67: {{Path: "iphone67.png"}},
+						},
+						ScreenshotFallbacks: ScreenshotFallbacks{
+							ScreenshotTypeiPhone65: ScreenshotTypeiPhone67,
+							ScreenshotTypeiPhone55: ScreenshotTypeiPhone65,
+						},
+					},
+				},
+				Platforms: VersionPlatforms{
+					PlatformiOS: {},
+					PlatformTvOS: {
+						Localizations: VersionLocalizations{
+							"en-US": {
+								ScreenshotFallbacks: ScreenshotFallbacks{
+									ScreenshotTypeAppleTV: ScreenshotTypeAppleTV4K,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := proj.Check()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	messages := make([]string, len(merr.Errors))
+	for i, err := range merr.Errors {
+		messages[i] = err.Error()
+	}
+
+	assert.Equal(t, []string{
+		"My App.versions.localizations.en-US.screenshotFallbacks.iphone55: screenshot type iphone55 falls back to iphone65, which has no screenshots on iOS",
+		"My App.versions.platforms.tvOS.localizations.en-US.screenshotFallbacks.appleTV: screenshot type appleTV falls back to appleTV4K, which has no screenshots on tvOS",
+	}, messages)
+}
+
+func TestProject_MissingScreenshots(t *testing.T) {
+	t.Parallel()
+
+	proj := Project{
+		"My App": App{
+			Versions: Version{
+				Localizations: VersionLocalizations{
+					"en-US": {
+						ScreenshotSets: ScreenshotSets{
+							ScreenshotTypeiPhone69:   {{Path: "iphone69.png"}},
+							ScreenshotTypeWatchUltra: {{Path: "watchUltra.png"}},
+						},
+						ScreenshotFallbacks: ScreenshotFallbacks{
+							ScreenshotTypeiPadPro13: ScreenshotTypeiPhone69,
+						},
+					},
+					"fr-FR": {
+						ScreenshotSets: ScreenshotSets{
+							ScreenshotTypeiPhone55: {{Path: "iphone55.png"}},
+						},
+					},
+					"de-DE": {},
+				},
+				Platforms: VersionPlatforms{
+					PlatformiOS: {},
+					PlatformVisionOS: {
+						Localizations: VersionLocalizations{
+							"en-US": {
+								ScreenshotSets: ScreenshotSets{
+									ScreenshotTypeAppleVisionPro: {{Path: "visionPro.png"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	messages := make([]string, 0)
+	for _, err := range proj.MissingScreenshots() {
+		messages = append(messages, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		"My App.versions.localizations.fr-FR.screenshotSets: no iPhone screenshots for iOS, expected one of iphone67, iphone69, iphone65",
+		"My App.versions.localizations.fr-FR.screenshotSets: no iPad screenshots for iOS, expected one of ipadPro3Gen129, ipadPro13, ipadPro129",
+		"My App.versions.localizations.fr-FR.screenshotSets: no Apple Vision Pro screenshots for visionOS, expected one of appleVisionPro",
+	}, messages)
+}
+
+func TestSource_MissingScreenshots(t *testing.T) {
+	t.Parallel()
+
+	source, err := ParseSource("cider.yml", []byte(`My App:
+  id: com.app
+  versions:
+    platform: tvOS
+    localizations:
+      en-US:
+        description: My App
+        screenshotSets:
+          appleTV4K:
+            - path: appleTV.png
+      fr-FR:
+        description: Mon App
+        screenshotSets:
+          iphone65:
+            - path: iphone65.png
+`))
+	assert.NoError(t, err)
+
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+
+	missing := source.MissingScreenshots(proj)
+	assert.Len(t, missing, 1)
+	assert.Equal(t, "cider.yml:14:11: My App.versions.localizations.fr-FR.screenshotSets: no Apple TV screenshots for tvOS, expected one of appleTV, appleTV4K", missing[0].Error())
+}
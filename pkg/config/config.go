@@ -23,12 +23,9 @@ package config
 import (
 	"encoding/json"
 	"io"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/cidertool/asc-go/asc"
-	"github.com/cidertool/cider/internal/closer"
 	"gopkg.in/yaml.v2"
 )
 
@@ -42,6 +39,8 @@ const (
 	PlatformMacOS Platform = "macOS"
 	// PlatformTvOS refers to the tvOS platform.
 	PlatformTvOS Platform = "tvOS"
+	// PlatformVisionOS refers to the visionOS platform.
+	PlatformVisionOS Platform = "visionOS"
 )
 
 type contentIntensity string
@@ -77,6 +76,202 @@ const (
 	ReleaseTypeScheduled releaseType = "scheduled"
 )
 
+type syncMode string
+
+const (
+	// SyncModeAdditive refers to adding listed testers and leaving any other testers in place.
+	SyncModeAdditive syncMode = "additive"
+	// SyncModeExact refers to adding listed testers and removing any testers that are not listed.
+	SyncModeExact syncMode = "exact"
+)
+
+type inAppPurchaseType string
+
+const (
+	// InAppPurchaseTypeConsumable refers to a consumable in-app purchase.
+	InAppPurchaseTypeConsumable inAppPurchaseType = "consumable"
+	// InAppPurchaseTypeNonConsumable refers to a non-consumable in-app purchase.
+	InAppPurchaseTypeNonConsumable inAppPurchaseType = "nonConsumable"
+	// InAppPurchaseTypeNonRenewingSubscription refers to a non-renewing subscription.
+	InAppPurchaseTypeNonRenewingSubscription inAppPurchaseType = "nonRenewingSubscription"
+)
+
+type subscriptionDuration string
+
+const (
+	// SubscriptionDurationOneWeek refers to a subscription renewed every week.
+	SubscriptionDurationOneWeek subscriptionDuration = "oneWeek"
+	// SubscriptionDurationOneMonth refers to a subscription renewed every month.
+	SubscriptionDurationOneMonth subscriptionDuration = "oneMonth"
+	// SubscriptionDurationTwoMonths refers to a subscription renewed every two months.
+	SubscriptionDurationTwoMonths subscriptionDuration = "twoMonths"
+	// SubscriptionDurationThreeMonths refers to a subscription renewed every three months.
+	SubscriptionDurationThreeMonths subscriptionDuration = "threeMonths"
+	// SubscriptionDurationSixMonths refers to a subscription renewed every six months.
+	SubscriptionDurationSixMonths subscriptionDuration = "sixMonths"
+	// SubscriptionDurationOneYear refers to a subscription renewed every year.
+	SubscriptionDurationOneYear subscriptionDuration = "oneYear"
+)
+
+type offerMode string
+
+const (
+	// OfferModeFreeTrial refers to an introductory offer that is free for its duration.
+	OfferModeFreeTrial offerMode = "freeTrial"
+	// OfferModePayAsYouGo refers to an introductory offer paid at a discount for a number of periods.
+	OfferModePayAsYouGo offerMode = "payAsYouGo"
+	// OfferModePayUpFront refers to an introductory offer paid once at a discount for its duration.
+	OfferModePayUpFront offerMode = "payUpFront"
+)
+
+type offerDuration string
+
+const (
+	// OfferDurationThreeDays refers to an offer period of three days.
+	OfferDurationThreeDays offerDuration = "threeDays"
+	// OfferDurationOneWeek refers to an offer period of one week.
+	OfferDurationOneWeek offerDuration = "oneWeek"
+	// OfferDurationTwoWeeks refers to an offer period of two weeks.
+	OfferDurationTwoWeeks offerDuration = "twoWeeks"
+	// OfferDurationOneMonth refers to an offer period of one month.
+	OfferDurationOneMonth offerDuration = "oneMonth"
+	// OfferDurationTwoMonths refers to an offer period of two months.
+	OfferDurationTwoMonths offerDuration = "twoMonths"
+	// OfferDurationThreeMonths refers to an offer period of three months.
+	OfferDurationThreeMonths offerDuration = "threeMonths"
+	// OfferDurationSixMonths refers to an offer period of six months.
+	OfferDurationSixMonths offerDuration = "sixMonths"
+	// OfferDurationOneYear refers to an offer period of one year.
+	OfferDurationOneYear offerDuration = "oneYear"
+)
+
+type privacyDataType string
+
+const (
+	// PrivacyDataTypeName refers to name collected from the app.
+	PrivacyDataTypeName privacyDataType = "name"
+	// PrivacyDataTypeEmailAddress refers to email address collected from the app.
+	PrivacyDataTypeEmailAddress privacyDataType = "emailAddress"
+	// PrivacyDataTypePhoneNumber refers to phone number collected from the app.
+	PrivacyDataTypePhoneNumber privacyDataType = "phoneNumber"
+	// PrivacyDataTypePhysicalAddress refers to physical address collected from the app.
+	PrivacyDataTypePhysicalAddress privacyDataType = "physicalAddress"
+	// PrivacyDataTypeOtherContactInfo refers to other contact info collected from the app.
+	PrivacyDataTypeOtherContactInfo privacyDataType = "otherContactInfo"
+	// PrivacyDataTypeHealth refers to health data collected from the app.
+	PrivacyDataTypeHealth privacyDataType = "health"
+	// PrivacyDataTypeFitness refers to fitness data collected from the app.
+	PrivacyDataTypeFitness privacyDataType = "fitness"
+	// PrivacyDataTypePaymentInfo refers to payment info collected from the app.
+	PrivacyDataTypePaymentInfo privacyDataType = "paymentInfo"
+	// PrivacyDataTypeCreditInfo refers to credit info collected from the app.
+	PrivacyDataTypeCreditInfo privacyDataType = "creditInfo"
+	// PrivacyDataTypeOtherFinancialInfo refers to other financial info collected from the app.
+	PrivacyDataTypeOtherFinancialInfo privacyDataType = "otherFinancialInfo"
+	// PrivacyDataTypePreciseLocation refers to precise location collected from the app.
+	PrivacyDataTypePreciseLocation privacyDataType = "preciseLocation"
+	// PrivacyDataTypeCoarseLocation refers to coarse location collected from the app.
+	PrivacyDataTypeCoarseLocation privacyDataType = "coarseLocation"
+	// PrivacyDataTypeSensitiveInfo refers to sensitive info collected from the app.
+	PrivacyDataTypeSensitiveInfo privacyDataType = "sensitiveInfo"
+	// PrivacyDataTypeContacts refers to contacts collected from the app.
+	PrivacyDataTypeContacts privacyDataType = "contacts"
+	// PrivacyDataTypeEmailsOrTextMessages refers to emails or text messages collected from the app.
+	PrivacyDataTypeEmailsOrTextMessages privacyDataType = "emailsOrTextMessages"
+	// PrivacyDataTypePhotosOrVideos refers to photos or videos collected from the app.
+	PrivacyDataTypePhotosOrVideos privacyDataType = "photosOrVideos"
+	// PrivacyDataTypeAudioData refers to audio data collected from the app.
+	PrivacyDataTypeAudioData privacyDataType = "audioData"
+	// PrivacyDataTypeGameplayContent refers to gameplay content collected from the app.
+	PrivacyDataTypeGameplayContent privacyDataType = "gameplayContent"
+	// PrivacyDataTypeCustomerSupport refers to customer support collected from the app.
+	PrivacyDataTypeCustomerSupport privacyDataType = "customerSupport"
+	// PrivacyDataTypeOtherUserContent refers to other user content collected from the app.
+	PrivacyDataTypeOtherUserContent privacyDataType = "otherUserContent"
+	// PrivacyDataTypeBrowsingHistory refers to browsing history collected from the app.
+	PrivacyDataTypeBrowsingHistory privacyDataType = "browsingHistory"
+	// PrivacyDataTypeSearchHistory refers to search history collected from the app.
+	PrivacyDataTypeSearchHistory privacyDataType = "searchHistory"
+	// PrivacyDataTypeUserID refers to user ID collected from the app.
+	PrivacyDataTypeUserID privacyDataType = "userID"
+	// PrivacyDataTypeDeviceID refers to device ID collected from the app.
+	PrivacyDataTypeDeviceID privacyDataType = "deviceID"
+	// PrivacyDataTypePurchaseHistory refers to purchase history collected from the app.
+	PrivacyDataTypePurchaseHistory privacyDataType = "purchaseHistory"
+	// PrivacyDataTypeProductInteraction refers to product interaction collected from the app.
+	PrivacyDataTypeProductInteraction privacyDataType = "productInteraction"
+	// PrivacyDataTypeAdvertisingData refers to advertising data collected from the app.
+	PrivacyDataTypeAdvertisingData privacyDataType = "advertisingData"
+	// PrivacyDataTypeOtherUsageData refers to other usage data collected from the app.
+	PrivacyDataTypeOtherUsageData privacyDataType = "otherUsageData"
+	// PrivacyDataTypeCrashData refers to crash data collected from the app.
+	PrivacyDataTypeCrashData privacyDataType = "crashData"
+	// PrivacyDataTypePerformanceData refers to performance data collected from the app.
+	PrivacyDataTypePerformanceData privacyDataType = "performanceData"
+	// PrivacyDataTypeOtherDiagnosticData refers to other diagnostic data collected from the app.
+	PrivacyDataTypeOtherDiagnosticData privacyDataType = "otherDiagnosticData"
+	// PrivacyDataTypeOtherDataTypes refers to other data types collected from the app.
+	PrivacyDataTypeOtherDataTypes privacyDataType = "otherDataTypes"
+)
+
+type privacyPurpose string
+
+const (
+	// PrivacyPurposeThirdPartyAdvertising refers to data used for third-party advertising.
+	PrivacyPurposeThirdPartyAdvertising privacyPurpose = "thirdPartyAdvertising"
+	// PrivacyPurposeDeveloperAdvertising refers to data used for the developer's advertising or marketing.
+	PrivacyPurposeDeveloperAdvertising privacyPurpose = "developerAdvertising"
+	// PrivacyPurposeAnalytics refers to data used for analytics.
+	PrivacyPurposeAnalytics privacyPurpose = "analytics"
+	// PrivacyPurposeProductPersonalization refers to data used for product personalization.
+	PrivacyPurposeProductPersonalization privacyPurpose = "productPersonalization"
+	// PrivacyPurposeAppFunctionality refers to data used for app functionality.
+	PrivacyPurposeAppFunctionality privacyPurpose = "appFunctionality"
+	// PrivacyPurposeOther refers to data used for other purposes.
+	PrivacyPurposeOther privacyPurpose = "other"
+)
+
+type inAppEventBadge string
+
+const (
+	// InAppEventBadgeLiveEvent refers to a real-time event experienced in the app.
+	InAppEventBadgeLiveEvent inAppEventBadge = "liveEvent"
+	// InAppEventBadgePremiere refers to the first availability of new content, such as a film or a level.
+	InAppEventBadgePremiere inAppEventBadge = "premiere"
+	// InAppEventBadgeChallenge refers to an activity that encourages customers to reach a goal.
+	InAppEventBadgeChallenge inAppEventBadge = "challenge"
+	// InAppEventBadgeCompetition refers to an activity where customers compete against each other.
+	InAppEventBadgeCompetition inAppEventBadge = "competition"
+	// InAppEventBadgeNewSeason refers to a new season of content or gameplay.
+	InAppEventBadgeNewSeason inAppEventBadge = "newSeason"
+	// InAppEventBadgeMajorUpdate refers to the introduction of significant new features or content.
+	InAppEventBadgeMajorUpdate inAppEventBadge = "majorUpdate"
+	// InAppEventBadgeSpecialEvent refers to an event that does not fit the other badges.
+	InAppEventBadgeSpecialEvent inAppEventBadge = "specialEvent"
+)
+
+type inAppEventPurpose string
+
+const (
+	// InAppEventPurposeAppropriateForAllUsers refers to an event suggested to all customers.
+	InAppEventPurposeAppropriateForAllUsers inAppEventPurpose = "appropriateForAllUsers"
+	// InAppEventPurposeAttractNewUsers refers to an event suggested to customers who have not downloaded the app.
+	InAppEventPurposeAttractNewUsers inAppEventPurpose = "attractNewUsers"
+	// InAppEventPurposeKeepActiveUsersInformed refers to an event suggested to customers who use the app.
+	InAppEventPurposeKeepActiveUsersInformed inAppEventPurpose = "keepActiveUsersInformed"
+	// InAppEventPurposeBringBackLapsedUsers refers to an event suggested to customers who stopped using the app.
+	InAppEventPurposeBringBackLapsedUsers inAppEventPurpose = "bringBackLapsedUsers"
+)
+
+type inAppEventPriority string
+
+const (
+	// InAppEventPriorityHigh refers to an event that is featured before the other events of the app.
+	InAppEventPriorityHigh inAppEventPriority = "high"
+	// InAppEventPriorityNormal refers to an event that is ordered by its start date among the other events of the app.
+	InAppEventPriorityNormal inAppEventPriority = "normal"
+)
+
 // File refers to a file on disk by name.
 type File struct {
 	// Path to a file on-disk. Templated.
@@ -98,6 +293,10 @@ type previewType string
 const (
 	// PreviewTypeAppleTV is a preview type for Apple TV.
 	PreviewTypeAppleTV previewType = "appleTV"
+	// PreviewTypeAppleTV4K is a preview type for Apple TV 4K, uploaded as an Apple TV preview.
+	PreviewTypeAppleTV4K previewType = "appleTV4K"
+	// PreviewTypeAppleVisionPro is a preview type for Apple Vision Pro.
+	PreviewTypeAppleVisionPro previewType = "appleVisionPro"
 	// PreviewTypeDesktop is a preview type for Desktop.
 	PreviewTypeDesktop previewType = "desktop"
 	// PreviewTypeiPad105 is a preview type for iPad 10.5.
@@ -110,6 +309,8 @@ const (
 	PreviewTypeiPadPro3Gen11 previewType = "ipadPro3Gen11"
 	// PreviewTypeiPadPro3Gen129 is a preview type for third-generation iPad Pro 12.9.
 	PreviewTypeiPadPro3Gen129 previewType = "ipadPro3Gen129"
+	// PreviewTypeiPadPro13 is a preview type for iPad Pro 13, uploaded as a third-generation iPad Pro 12.9 preview.
+	PreviewTypeiPadPro13 previewType = "ipadPro13"
 	// PreviewTypeiPhone35 is a preview type for iPhone 3.5.
 	PreviewTypeiPhone35 previewType = "iphone35"
 	// PreviewTypeiPhone40 is a preview type for iPhone 4.0.
@@ -120,8 +321,14 @@ const (
 	PreviewTypeiPhone55 previewType = "iphone55"
 	// PreviewTypeiPhone58 is a preview type for iPhone 5.8.
 	PreviewTypeiPhone58 previewType = "iphone58"
+	// PreviewTypeiPhone61 is a preview type for iPhone 6.1.
+	PreviewTypeiPhone61 previewType = "iphone61"
 	// PreviewTypeiPhone65 is a preview type for iPhone 6.5.
 	PreviewTypeiPhone65 previewType = "iphone65"
+	// PreviewTypeiPhone67 is a preview type for iPhone 6.7.
+	PreviewTypeiPhone67 previewType = "iphone67"
+	// PreviewTypeiPhone69 is a preview type for iPhone 6.9, uploaded as an iPhone 6.7 preview.
+	PreviewTypeiPhone69 previewType = "iphone69"
 	// PreviewTypeWatchSeries3 is a preview type for Watch Series 3.
 	PreviewTypeWatchSeries3 previewType = "watchSeries3"
 	// PreviewTypeWatchSeries4 is a preview type for Watch Series 4.
@@ -133,6 +340,10 @@ type screenshotType string
 const (
 	// ScreenshotTypeAppleTV is a screenshot type for Apple TV.
 	ScreenshotTypeAppleTV screenshotType = "appleTV"
+	// ScreenshotTypeAppleTV4K is a screenshot type for Apple TV 4K, uploaded as an Apple TV screenshot.
+	ScreenshotTypeAppleTV4K screenshotType = "appleTV4K"
+	// ScreenshotTypeAppleVisionPro is a screenshot type for Apple Vision Pro.
+	ScreenshotTypeAppleVisionPro screenshotType = "appleVisionPro"
 	// ScreenshotTypeDesktop is a screenshot type for Desktop.
 	ScreenshotTypeDesktop screenshotType = "desktop"
 	// ScreenshotTypeiPad105 is a screenshot type for iPad 10.5.
@@ -145,6 +356,9 @@ const (
 	ScreenshotTypeiPadPro3Gen11 screenshotType = "ipadPro3Gen11"
 	// ScreenshotTypeiPadPro3Gen129 is a screenshot type for third-generation iPad Pro 12.9.
 	ScreenshotTypeiPadPro3Gen129 screenshotType = "ipadPro3Gen129"
+	// ScreenshotTypeiPadPro13 is a screenshot type for iPad Pro 13, uploaded as a third-generation iPad Pro 12.9
+	// screenshot.
+	ScreenshotTypeiPadPro13 screenshotType = "ipadPro13"
 	// ScreenshotTypeiPhone35 is a screenshot type for iPhone 3.5.
 	ScreenshotTypeiPhone35 screenshotType = "iphone35"
 	// ScreenshotTypeiPhone40 is a screenshot type for iPhone 4.0.
@@ -155,12 +369,24 @@ const (
 	ScreenshotTypeiPhone55 screenshotType = "iphone55"
 	// ScreenshotTypeiPhone58 is a screenshot type for iPhone 5.8.
 	ScreenshotTypeiPhone58 screenshotType = "iphone58"
+	// ScreenshotTypeiPhone61 is a screenshot type for iPhone 6.1.
+	ScreenshotTypeiPhone61 screenshotType = "iphone61"
 	// ScreenshotTypeiPhone65 is a screenshot type for iPhone 6.5.
 	ScreenshotTypeiPhone65 screenshotType = "iphone65"
+	// ScreenshotTypeiPhone67 is a screenshot type for iPhone 6.7.
+	ScreenshotTypeiPhone67 screenshotType = "iphone67"
+	// ScreenshotTypeiPhone69 is a screenshot type for iPhone 6.9, uploaded as an iPhone 6.7 screenshot.
+	ScreenshotTypeiPhone69 screenshotType = "iphone69"
 	// ScreenshotTypeWatchSeries3 is a screenshot type for Watch Series 3.
 	ScreenshotTypeWatchSeries3 screenshotType = "watchSeries3"
 	// ScreenshotTypeWatchSeries4 is a screenshot type for Watch Series 4.
 	ScreenshotTypeWatchSeries4 screenshotType = "watchSeries4"
+	// ScreenshotTypeWatchSeries7 is a screenshot type for Watch Series 7.
+	ScreenshotTypeWatchSeries7 screenshotType = "watchSeries7"
+	// ScreenshotTypeWatchSeries10 is a screenshot type for Watch Series 10.
+	ScreenshotTypeWatchSeries10 screenshotType = "watchSeries10"
+	// ScreenshotTypeWatchUltra is a screenshot type for Apple Watch Ultra.
+	ScreenshotTypeWatchUltra screenshotType = "watchUltra"
 	// ScreenshotTypeiMessageiPad105 is a screenshot type for iMessage apps on iPad 10.5.
 	ScreenshotTypeiMessageiPad105 screenshotType = "ipad105imessage"
 	// ScreenshotTypeiMessageiPad97 is a screenshot type for iMessage apps on iPad 9.7.
@@ -179,8 +405,12 @@ const (
 	ScreenshotTypeiMessageiPhone55 screenshotType = "iphone55imessage"
 	// ScreenshotTypeiMessageiPhone58 is a screenshot type for iMessage apps on iPhone 5.8.
 	ScreenshotTypeiMessageiPhone58 screenshotType = "iphone58imessage"
+	// ScreenshotTypeiMessageiPhone61 is a screenshot type for iMessage apps on iPhone 6.1.
+	ScreenshotTypeiMessageiPhone61 screenshotType = "iphone61imessage"
 	// ScreenshotTypeiMessageiPhone65 is a screenshot type for iMessage apps on iPhone 6.5.
 	ScreenshotTypeiMessageiPhone65 screenshotType = "iphone65imessage"
+	// ScreenshotTypeiMessageiPhone67 is a screenshot type for iMessage apps on iPhone 6.7.
+	ScreenshotTypeiMessageiPhone67 screenshotType = "iphone67imessage"
 )
 
 /*
@@ -224,6 +454,20 @@ type App struct {
 	Versions Version `yaml:"versions"`
 	// Metadata to configure new Testflight beta releases.
 	Testflight Testflight `yaml:"testflight"`
+	// Map of product IDs to [InAppPurchase](#inapppurchase) objects for the in-app purchases of the app.
+	InAppPurchases InAppPurchases `yaml:"inAppPurchases,omitempty"`
+	// Map of reference names to [SubscriptionGroup](#subscriptiongroup) objects for the auto-renewable
+	// subscriptions of the app.
+	SubscriptionGroups SubscriptionGroups `yaml:"subscriptionGroups,omitempty"`
+	// App Privacy details declaring the data collected from the app.
+	Privacy *Privacy `yaml:"privacy,omitempty"`
+	// Map of names to [CustomProductPage](#customproductpage) objects for alternate versions of the App Store
+	// product page of the app.
+	CustomProductPages CustomProductPages `yaml:"customProductPages,omitempty"`
+	// Map of reference names to [Experiment](#experiment) objects for product page optimization tests of the app.
+	Experiments Experiments `yaml:"experiments,omitempty"`
+	// Map of reference names to [InAppEvent](#inappevent) objects for the in-app events of the app.
+	InAppEvents InAppEvents `yaml:"inAppEvents,omitempty"`
 }
 
 /*
@@ -309,24 +553,56 @@ For example:
 
 ```yaml
 availability:
-  pricing:
-    - tier: '0'
+  baseTerritory: USA
+  prices:
+    - price: '4.99'
+    - price: '2.99'
+      startDate: 2026-11-27T00:00:00Z
+      endDate: 2026-12-01T00:00:00Z
+    - price: '4.99'
+      startDate: 2026-12-01T00:00:00Z
+    - territory: JPN
+      price: '800'
   availableInNewTerritories: false
   territories:
     - USA
+    - JPN
 ```
-.
+
+Pricing is left as it is in App Store Connect when releasing with `--skip-update-pricing`. Use
+[`cider pricing show`](./commands/cider_pricing_show.md) to review the price in each territory over time.
 */
 type Availability struct {
 	// Indicates whether or not the app should be made automaticaly available
 	// in new App Store territories, as Apple makes new ones available.
 	AvailableInNewTerritories *bool `yaml:"availableInNewTerritories,omitempty"`
-	// List of PriceSchedules that describe the pricing details of your app.
+	// List of PriceSchedules that describe the pricing details of your app by price tier. Cannot be set along
+	// with `prices`.
 	Pricing []PriceSchedule `yaml:"priceTiers,omitempty"`
+	// ISO 3166-1 Alpha-3 country code of the territory whose prices App Store Connect uses to set the price in
+	// territories without prices of their own. Defaults to USA.
+	BaseTerritory string `yaml:"baseTerritory,omitempty"`
+	// List of [AppPrice](#appprice)s that set the price of your app in the base territory, and in the
+	// territories that should not follow it.
+	Prices []AppPrice `yaml:"prices,omitempty"`
 	// Array of ISO 3166-1 Alpha-3 country codes corresponding to territories to make your app available in.
 	Territories []string `yaml:"territories,omitempty"`
 }
 
+// AppPrice sets the price of the app in a territory for a period of time. The periods of the prices in a
+// territory cannot overlap, and the base territory needs a price that takes effect immediately.
+type AppPrice struct {
+	// ISO 3166-1 Alpha-3 country code of the territory the price is for. Defaults to the base territory.
+	Territory string `yaml:"territory,omitempty"`
+	// Customer price in the currency of the territory, such as "4.99". Free is "0".
+	Price string `yaml:"price"`
+	// Date the price takes effect. Takes effect immediately if not set.
+	StartDate *time.Time `yaml:"startDate,omitempty"`
+	// Date the price stops being in effect. Stays in effect until the next price in the territory takes
+	// effect if not set.
+	EndDate *time.Time `yaml:"endDate,omitempty"`
+}
+
 // PriceSchedule represents pricing availability information that an app should be immediately
 // configured to.
 type PriceSchedule struct {
@@ -386,11 +662,30 @@ versions:
   localizations: ...
   reviewDetails: ...
 ```
+
+Apps released on several platforms, such as universal purchase apps, can release a version for each platform at
+once by listing them under `platforms` instead of setting `platform`. The other details are shared by every
+platform, unless a platform overrides them:
+
+```yaml
+versions:
+  copyright: 2020 App
+  localizations: ...
+  platforms:
+    iOS: {}
+    macOS:
+      localizations:
+        en-US:
+          screenshotSets: ...
+```
 .
 */
 type Version struct {
-	// Platform the app is to be released on.
-	Platform Platform `yaml:"platform"`
+	// Platform the app is to be released on. Required unless `platforms` is set.
+	Platform Platform `yaml:"platform,omitempty"`
+	// Map of platforms to [PlatformVersion](#platformversion) objects, to release a version for each platform
+	// in the same run.
+	Platforms VersionPlatforms `yaml:"platforms,omitempty"`
 	// Map of locale codes to [VersionLocalization](#versionlocalization) objects for App Store version information.
 	Localizations VersionLocalizations `yaml:"localizations"`
 	// Copyright information to display on the listing. Templated.
@@ -411,6 +706,32 @@ type Version struct {
 	ReviewDetails *ReviewDetails `yaml:"reviewDetails,omitempty"`
 }
 
+// VersionPlatforms is a map of platforms to [PlatformVersion](#platformversion) objects.
+type VersionPlatforms map[Platform]PlatformVersion
+
+// PlatformVersion overrides the details of a [Version](#version) shared by every platform for one platform.
+// Localizations are merged with the shared localizations of the same locale, field by field, and the other
+// details replace the shared ones when they are set.
+type PlatformVersion struct {
+	// Map of locale codes to [VersionLocalization](#versionlocalization) objects overriding the shared
+	// localizations.
+	Localizations VersionLocalizations `yaml:"localizations,omitempty"`
+	// Copyright information to display on the listing. Templated.
+	Copyright string `yaml:"copyright,omitempty"`
+	// Earliest release date, in Go's RFC3339 format.
+	EarliestReleaseDate *time.Time `yaml:"earliestReleaseDate,omitempty"`
+	// Release type.
+	ReleaseType releaseType `yaml:"releaseType,omitempty"`
+	// Indicates whether phased release should be enabled for updates.
+	PhasedReleaseEnabled *bool `yaml:"enablePhasedRelease,omitempty"`
+	// Information about an app's IDFA declaration.
+	IDFADeclaration *IDFADeclaration `yaml:"idfaDeclaration,omitempty"`
+	// Routing coverage resource.
+	RoutingCoverage *File `yaml:"routingCoverage,omitempty"`
+	// Details about an app to share with the App Store reviewer.
+	ReviewDetails *ReviewDetails `yaml:"reviewDetails,omitempty"`
+}
+
 /*
 VersionLocalizations is a map of [locale codes](#locales) to [VersionLocalization](#versionlocalization) objects.
 
@@ -429,8 +750,9 @@ type VersionLocalizations map[string]VersionLocalization
 
 // VersionLocalization contains localized details for the listing of a specific version on the App Store.
 type VersionLocalization struct {
-	// App description in this locale. Templated.
-	Description string `yaml:"description"`
+	// App description in this locale. Templated. Required, but can be left out of the localizations of
+	// [PlatformVersion](#platformversion)s to share the description of the version.
+	Description string `yaml:"description,omitempty"`
 	// App keywords in this locale. Templated.
 	Keywords string `yaml:"keywords,omitempty"`
 	// Marketing URL to use in this locale. Templated.
@@ -445,6 +767,9 @@ type VersionLocalization struct {
 	PreviewSets PreviewSets `yaml:"previewSets,omitempty"`
 	// Map of screenshot types to arrays of app screenshot assets.
 	ScreenshotSets ScreenshotSets `yaml:"screenshotSets,omitempty"`
+	// Map of screenshot types to the screenshot types whose screenshots they reuse when they have no
+	// screenshot set of their own.
+	ScreenshotFallbacks ScreenshotFallbacks `yaml:"screenshotFallbacks,omitempty"`
 }
 
 /*
@@ -461,6 +786,10 @@ previewSets:
     - file: assets/ipadPro129/preview1.mp4
 ```
 
+The path of a preview can also be a glob pattern, or a directory to take every .mov, .m4v and .mp4
+file from, in natural order so that preview2.mp4 comes before preview10.mp4. Paths are templated with
+the locale of the localization available as `{{ .locale }}`.
+
 For more information, see [App preview specifications](https://help.apple.com/app-store-connect/#/dev4e413fcb8).
 */
 type PreviewSets map[previewType][]Preview
@@ -484,12 +813,43 @@ screenshotSets:
     - file: assets/ipadPro129/screenshot3.jpg
 ```
 
+The path of a screenshot can also be a glob pattern, or a directory to take every .png, .jpg and .jpeg
+file from, in natural order so that screenshot2.png comes before screenshot10.png. Paths are templated
+with the locale of the localization available as `{{ .locale }}`, so screenshots written by snapshot
+tooling to a directory per locale and device can be listed once:
+
+```yaml
+screenshotSets:
+  iphone65:
+    - path: screenshots/{{ .locale }}/iPhone 11 Pro Max
+  ipadPro3Gen129:
+    - path: screenshots/{{ .locale }}/iPad Pro (12.9-inch) (3rd generation)/*.png
+```
+
 Some screenshot sizes are required in order to submit your app for review. You’ll get an error at
 submission time if you don’t provide all of the required assets. For information about screenshot
 requirements, see [Screenshot specifications](https://help.apple.com/app-store-connect/#/devd274dd925).
 */
 type ScreenshotSets map[screenshotType][]File
 
+/*
+ScreenshotFallbacks is a map of screenshot types to the screenshot types to take their screenshots from,
+so the same screenshots don't need to be listed once for each display they are uploaded to. A screenshot
+type listed in [ScreenshotSets](#screenshotsets) always uses its own screenshots.
+
+For example:
+
+```yaml
+screenshotSets:
+  iphone67:
+    - path: assets/iphone67/screenshot1.jpg
+screenshotFallbacks:
+  iphone65: iphone67
+  iphone55: iphone67
+```
+*/
+type ScreenshotFallbacks map[screenshotType]screenshotType
+
 /*
 IDFADeclaration outlines regulatory information for Apple to use to handle your apps' use
 of tracking identifiers. Implicitly enables `usesIdfa` when creating an app store version.
@@ -579,16 +939,45 @@ type DemoAccount struct {
 type Testflight struct {
 	// Indicates whether to auto-notify existing beta testers of a new Testflight update.
 	EnableAutoNotify bool `yaml:"enableAutoNotify"`
+	// Indicates whether to notify beta testers that the build is available once it passes Beta App Review.
+	// Testers are notified right away if the build is already approved, or if it is only distributed to
+	// internal beta groups. Otherwise the release does not wait for the review, and logs the
+	// `cider testflight notify` command to run once it is approved. Has no effect when enableAutoNotify is
+	// set, as App Store Connect notifies testers itself.
+	NotifyTesters bool `yaml:"notifyTesters,omitempty"`
 	// Beta license agreement content. Templated.
 	LicenseAgreement string `yaml:"licenseAgreement"`
 	// Map of locale codes to localization configurations for beta app and beta build information.
 	Localizations TestflightLocalizations `yaml:"localizations"`
+	// Source of the "What to Test" notes of each build, which replace whatsNew in every localization.
+	WhatToTest *WhatToTest `yaml:"whatToTest,omitempty"`
 	// Array of beta group names. If you want to refer to beta groups defined in this configuration
 	// file, use the value provided for the group field on the corresponding beta group. Beta groups
 	// to add or update in App Store Connect.
 	BetaGroups []BetaGroup `yaml:"betaGroups,omitempty"`
 	// Individual beta testers to add or update in App Store Connect.
 	BetaTesters []BetaTester `yaml:"betaTesters,omitempty"`
+	// How individual beta testers are synced with App Store Connect. With `exact`, testers of the app
+	// that are not listed in betaTesters and do not belong to any of the app's beta groups lose access
+	// to the app. Defaults to `additive`, which never removes testers. Removals are capped by the
+	// `--max-tester-removals` flag of `cider release`.
+	BetaTestersSync syncMode `yaml:"betaTestersSync,omitempty"`
+	// Indicates whether to delete beta groups of the app in App Store Connect that are not listed in betaGroups
+	// and whose names match prunePattern. Internal beta groups are never deleted. Use
+	// `cider testflight prune --dry-run`, or the `--prune-dry-run` flag of `cider release`, to list the groups
+	// that would be deleted without deleting them.
+	Prune bool `yaml:"prune,omitempty"`
+	// Glob pattern of the names of beta groups to prune, such as `Sprint *`. Required when prune is enabled.
+	// `*` matches any run of characters other than `/`, `?` matches any single character other than `/`, and
+	// `[...]` matches a character class. Use `*` to prune every beta group that is not listed and has no `/`
+	// in its name.
+	PrunePattern string `yaml:"prunePattern,omitempty"`
+	// Indicates whether to remove builds other than the build being released from the beta groups in
+	// betaGroups, so testers in those groups only see the latest build. Only builds for the platform
+	// being released are removed.
+	RemovePreviousBuilds bool `yaml:"removePreviousBuilds,omitempty"`
+	// Expires old builds of the app in TestFlight, so testers can no longer install them.
+	ExpireBuilds *ExpireBuilds `yaml:"expireBuilds,omitempty"`
 	// Details about an app to share with the App Store reviewer.
 	ReviewDetails *ReviewDetails `yaml:"reviewDetails,omitempty"`
 }
@@ -625,11 +1014,35 @@ type TestflightLocalization struct {
 	WhatsNew string `yaml:"whatsNew,omitempty"`
 }
 
+// WhatToTest describes where the "What to Test" notes of each build come from. Exactly one of file, command or
+// commits must be set.
+type WhatToTest struct {
+	// Path to a file containing the notes. Templated.
+	File string `yaml:"file,omitempty"`
+	// Shell command whose output is used as the notes. It is run with `sh -c` in the project directory. Templated.
+	Command string `yaml:"command,omitempty"`
+	// Indicates whether to list the subjects of the Git commits made since the build previously uploaded to
+	// TestFlight for the same platform. Cider ends the notes of each build with a `Commit:` line, which is used to
+	// find the commit of the previous build in the notes of the first configured locale, sorted by name. If it
+	// cannot be found, only the subject of the current commit is listed.
+	Commits bool `yaml:"commits,omitempty"`
+	// Template to format the notes with. The notes are available as `{{ .notes }}`, along with the usual template
+	// fields. Defaults to `{{ .notes }}`.
+	Template string `yaml:"template,omitempty"`
+}
+
 // BetaGroup describes a beta group in Testflight that should be kept in sync and used with this app.
 type BetaGroup struct {
 	// Name of the beta group.
 	Name string `yaml:"group"`
-	// Indicates whether to enable the public link.
+	// Indicates whether the beta group is an internal group of App Store Connect users. Builds distributed
+	// only to internal groups are not submitted for Beta App Review. A group cannot change between
+	// internal and external once it exists in App Store Connect.
+	IsInternal bool `yaml:"isInternal,omitempty"`
+	// Indicates whether the internal beta group has access to all builds of the app, in which case builds
+	// are not added to it explicitly. Only applies to internal groups.
+	HasAccessToAllBuilds bool `yaml:"hasAccessToAllBuilds,omitempty"`
+	// Indicates whether to enable the public link. Not available for internal groups.
 	EnablePublicLink bool `yaml:"publicLinkEnabled,omitempty"`
 	// Indicates whether a limit on the number of testers who can use the public link
 	// is enabled.
@@ -640,6 +1053,16 @@ type BetaGroup struct {
 	PublicLinkLimit int `yaml:"publicLinkLimit,omitempty"`
 	// Array of beta testers to explicitly assign to the beta group.
 	Testers []BetaTester `yaml:"testers"`
+	// Path to a CSV file of beta testers to assign to the beta group in addition to testers. Each row has an
+	// email, first name, last name, and the names of the groups the tester belongs to, separated by semicolons.
+	// Rows are only assigned to the groups they name, and rows that name no groups are skipped. A header row
+	// naming the columns is optional. Relative paths are resolved against the project directory.
+	// `cider testers export` writes the testers of each beta group in this format.
+	TestersFile string `yaml:"testersFile,omitempty"`
+	// How testers are synced with the beta group. With `exact`, testers in the group that are not
+	// listed in testers are removed from it. Defaults to `additive`, which never removes testers.
+	// Removals are capped by the `--max-tester-removals` flag of `cider release`.
+	Sync syncMode `yaml:"sync,omitempty"`
 }
 
 // BetaTester describes an individual beta tester that should have access to this app.
@@ -652,28 +1075,429 @@ type BetaTester struct {
 	LastName string `yaml:"lastName,omitempty"`
 }
 
-// Load config file.
-func Load(file string) (config Project, err error) {
-	f, err := os.Open(filepath.Clean(file))
-	if err != nil {
-		return
-	}
+// ExpireBuilds describes which builds of an app to expire in TestFlight. Only builds for the platform
+// being released are considered, and builds matching either field are expired. Builds of the version
+// being released are never expired.
+type ExpireBuilds struct {
+	// Number of versions to keep builds of, counting from the version with the most recently uploaded
+	// build. Builds of older versions are expired.
+	KeepLatest int `yaml:"keepLatest,omitempty"`
+	// Expires builds uploaded more than this many days ago.
+	OlderThanDays int `yaml:"olderThanDays,omitempty"`
+}
+
+/*
+InAppPurchases is a map of product IDs to [InAppPurchase](#inapppurchase) objects. In-app purchases are created
+in App Store Connect if they do not exist yet, and updated otherwise. When submitting to the App Store, in-app
+purchases that are ready to submit are submitted for review along with the version.
+
+Unlike `priceTiers` in [Availability](#availability), in-app purchases are priced by customer price rather than by
+price tier. App Store Connect replaced price tiers with price points for in-app purchases, and does not number
+them by tier, so the price is matched against the price points of the base territory instead.
+
+For example:
+
+```yaml
+inAppPurchases:
+  com.app.coins.100:
+    referenceName: 100 Coins
+    type: consumable
+    price: "0.99"
+    localizations:
+      en-US:
+        name: 100 Coins
+        description: A small pile of coins
+    reviewScreenshot:
+      path: assets/review/coins.png
+```
+.
+*/
+type InAppPurchases map[string]InAppPurchase
+
+// InAppPurchase describes an in-app purchase of an app.
+type InAppPurchase struct {
+	// Name of the in-app purchase in App Store Connect, which is not shown to customers.
+	ReferenceName string `yaml:"referenceName"`
+	// Type of the in-app purchase. The type of an existing in-app purchase cannot be changed.
+	Type inAppPurchaseType `yaml:"type"`
+	// Customer price of the in-app purchase in the currency of the base territory, such as "0.99", which App
+	// Store Connect uses to set prices in other territories. It must match a price point App Store Connect
+	// offers in the base territory.
+	Price string `yaml:"price,omitempty"`
+	// ISO 3166-1 Alpha-3 country code of the territory the price is for. Defaults to USA.
+	BaseTerritory string `yaml:"baseTerritory,omitempty"`
+	// Map of locale codes to [InAppPurchaseLocalization](#inapppurchaselocalization) objects.
+	Localizations InAppPurchaseLocalizations `yaml:"localizations"`
+	// Screenshot of the in-app purchase for App Review.
+	ReviewScreenshot *File `yaml:"reviewScreenshot,omitempty"`
+	// Notes for App Review about the in-app purchase.
+	ReviewNotes string `yaml:"reviewNotes,omitempty"`
+}
+
+// InAppPurchaseLocalizations is a map of [locale codes](#locales) to [InAppPurchaseLocalization](#inapppurchaselocalization) objects.
+type InAppPurchaseLocalizations map[string]InAppPurchaseLocalization
+
+// InAppPurchaseLocalization contains the localized details of an in-app purchase shown to customers.
+type InAppPurchaseLocalization struct {
+	// Display name of the in-app purchase in this locale.
+	Name string `yaml:"name"`
+	// Description of the in-app purchase in this locale.
+	Description string `yaml:"description,omitempty"`
+}
+
+/*
+SubscriptionGroups is a map of reference names to [SubscriptionGroup](#subscriptiongroup) objects. Subscription
+groups and their subscriptions are created in App Store Connect if they do not exist yet, and updated otherwise,
+when submitting to the App Store.
+
+For example:
+
+```yaml
+subscriptionGroups:
+  Premium:
+    localizations:
+      en-US:
+        name: Premium
+    subscriptions:
+      com.app.premium.monthly:
+        referenceName: Premium Monthly
+        duration: oneMonth
+        groupLevel: 1
+        prices:
+          USA: "4.99"
+          GBR: "4.49"
+        introductoryOffers:
+          - mode: freeTrial
+            duration: oneWeek
+        localizations:
+          en-US:
+            name: Premium
+            description: Everything, every month
+```
+.
+*/
+type SubscriptionGroups map[string]SubscriptionGroup
+
+// SubscriptionGroup describes a group of auto-renewable subscriptions. Customers can only be subscribed to one
+// subscription of a group at a time.
+type SubscriptionGroup struct {
+	// Map of [locale codes](#locales) to [SubscriptionGroupLocalization](#subscriptiongrouplocalization) objects.
+	Localizations SubscriptionGroupLocalizations `yaml:"localizations"`
+	// Map of product IDs to [Subscription](#subscription) objects for the subscriptions in the group.
+	Subscriptions Subscriptions `yaml:"subscriptions"`
+}
 
-	defer closer.Close(f)
+// SubscriptionGroupLocalizations is a map of [locale codes](#locales) to
+// [SubscriptionGroupLocalization](#subscriptiongrouplocalization) objects.
+type SubscriptionGroupLocalizations map[string]SubscriptionGroupLocalization
 
-	return LoadReader(f)
+// SubscriptionGroupLocalization contains the localized details of a subscription group shown to customers.
+type SubscriptionGroupLocalization struct {
+	// Display name of the subscription group in this locale.
+	Name string `yaml:"name"`
+	// Name of the app shown with the subscriptions of the group in this locale, if not the app's name.
+	CustomAppName string `yaml:"customAppName,omitempty"`
+}
+
+// Subscriptions is a map of product IDs to [Subscription](#subscription) objects.
+type Subscriptions map[string]Subscription
+
+// Subscription describes an auto-renewable subscription.
+type Subscription struct {
+	// Name of the subscription in App Store Connect, which is not shown to customers.
+	ReferenceName string `yaml:"referenceName"`
+	// Length of each period of the subscription.
+	Duration subscriptionDuration `yaml:"duration"`
+	// Level of the subscription in its group, starting at 1 for the subscription that offers the most.
+	GroupLevel int `yaml:"groupLevel,omitempty"`
+	// Map of ISO 3166-1 Alpha-3 territory codes to the price customers pay in the currency of the territory,
+	// such as "4.99". Each price must match a price point App Store Connect offers in the territory.
+	Prices map[string]string `yaml:"prices,omitempty"`
+	// Introductory offers for customers new to the subscription group. Each territory can have at most one
+	// introductory offer.
+	IntroductoryOffers []IntroductoryOffer `yaml:"introductoryOffers,omitempty"`
+	// Map of [locale codes](#locales) to [SubscriptionLocalization](#subscriptionlocalization) objects.
+	Localizations SubscriptionLocalizations `yaml:"localizations"`
+	// Screenshot of the subscription for App Review.
+	ReviewScreenshot *File `yaml:"reviewScreenshot,omitempty"`
+	// Notes for App Review about the subscription.
+	ReviewNotes string `yaml:"reviewNotes,omitempty"`
 }
 
-// LoadReader config via io.Reader.
+// SubscriptionLocalizations is a map of [locale codes](#locales) to
+// [SubscriptionLocalization](#subscriptionlocalization) objects.
+type SubscriptionLocalizations map[string]SubscriptionLocalization
+
+// SubscriptionLocalization contains the localized details of a subscription shown to customers.
+type SubscriptionLocalization struct {
+	// Display name of the subscription in this locale.
+	Name string `yaml:"name"`
+	// Description of the subscription in this locale.
+	Description string `yaml:"description,omitempty"`
+}
+
+// IntroductoryOffer describes a discounted or free introductory offer of a subscription.
+type IntroductoryOffer struct {
+	// ISO 3166-1 Alpha-3 codes of the territories the offer is available in. Defaults to every territory the
+	// subscription has a price in.
+	Territories []string `yaml:"territories,omitempty"`
+	// How customers pay for the offer.
+	Mode offerMode `yaml:"mode"`
+	// Length of the offer, or of each of its periods when paid as you go.
+	Duration offerDuration `yaml:"duration"`
+	// Number of periods of the offer when paid as you go.
+	Periods int `yaml:"periods,omitempty"`
+	// Map of ISO 3166-1 Alpha-3 territory codes to the discounted price customers pay in the currency of the
+	// territory. Required for each territory of offers that are not free trials.
+	Prices map[string]string `yaml:"prices,omitempty"`
+	// Date the offer starts being available. Defaults to immediately.
+	StartDate *time.Time `yaml:"startDate,omitempty"`
+	// Date the offer stops being available. Defaults to never.
+	EndDate *time.Time `yaml:"endDate,omitempty"`
+}
+
+/*
+Privacy declares the data collected from an app by its developer and third-party partners, which App Store
+Connect shows as the App Privacy details of the app. The App Store Connect API cannot publish App Privacy details,
+so they are checked by `cider check`, summarized for entering in App Store Connect by `cider privacy summary`, and
+compared with the privacy details App Store Connect does publish by `cider privacy drift`.
+
+For example:
+
+```yaml
+privacy:
+  dataTypes:
+    - type: emailAddress
+      purposes:
+        - appFunctionality
+      linked: true
+    - type: crashData
+      purposes:
+        - analytics
+```
+.
+*/
+type Privacy struct {
+	// Data types collected from the app. Leave empty to declare that the app does not collect any data.
+	DataTypes []PrivacyDataType `yaml:"dataTypes,omitempty"`
+}
+
+// PrivacyDataType describes how a type of data collected from the app is used.
+type PrivacyDataType struct {
+	// Type of data collected.
+	Type privacyDataType `yaml:"type"`
+	// Purposes the data is used for.
+	Purposes []privacyPurpose `yaml:"purposes"`
+	// Whether the data is linked to the identity of the user.
+	Linked bool `yaml:"linked,omitempty"`
+	// Whether the data is used to track the user across apps and websites owned by other companies.
+	Tracking bool `yaml:"tracking,omitempty"`
+}
+
+/*
+CustomProductPages is a map of names to [CustomProductPage](#customproductpage) objects. Custom product pages are
+created in App Store Connect if they do not exist yet, and their editable version is updated otherwise, when
+submitting to the App Store. Pages that are waiting for review or in review are left as they are.
+
+For example:
+
+```yaml
+customProductPages:
+  Summer Campaign:
+    localizations:
+      en-US:
+        promotionalText: Get ready for summer
+        screenshotSets:
+          iphone65:
+            - path: assets/summer/iphone65/1.png
+```
+.
+*/
+type CustomProductPages map[string]CustomProductPage
+
+// CustomProductPage describes an alternate version of the App Store product page of an app, which can be shared
+// through its own URL.
+type CustomProductPage struct {
+	// Map of [locale codes](#locales) to [CustomProductPageLocalization](#customproductpagelocalization) objects.
+	Localizations CustomProductPageLocalizations `yaml:"localizations"`
+}
+
+// CustomProductPageLocalizations is a map of [locale codes](#locales) to
+// [CustomProductPageLocalization](#customproductpagelocalization) objects.
+type CustomProductPageLocalizations map[string]CustomProductPageLocalization
+
+// CustomProductPageLocalization contains the localized contents of a custom product page.
+type CustomProductPageLocalization struct {
+	// Promotional text shown at the top of the page in this locale.
+	PromotionalText string `yaml:"promotionalText,omitempty"`
+	// Map of preview types to arrays of app preview assets.
+	PreviewSets PreviewSets `yaml:"previewSets,omitempty"`
+	// Map of screenshot types to arrays of app screenshot assets.
+	ScreenshotSets ScreenshotSets `yaml:"screenshotSets,omitempty"`
+}
+
+/*
+Experiments is a map of reference names to [Experiment](#experiment) objects. Product page optimization experiments
+are created in App Store Connect for the platform of the app if they do not exist yet, and updated otherwise, when
+submitting to the App Store. Experiments that have been submitted are left as they are, and experiments have to be
+started in App Store Connect.
+
+For example:
+
+```yaml
+experiments:
+  Icon Test:
+    trafficProportion: 30
+    treatments:
+      Dark Icon:
+        appIconName: AppIconDark
+        localizations:
+          en-US:
+            screenshotSets:
+              iphone65:
+                - path: assets/dark/iphone65/1.png
+```
+.
+*/
+type Experiments map[string]Experiment
+
+// Experiment describes a product page optimization test, which shows treatments of the product page to a share
+// of customers.
+type Experiment struct {
+	// Percentage of customers who see one of the treatments instead of the original product page.
+	TrafficProportion int `yaml:"trafficProportion"`
+	// Map of names to [ExperimentTreatment](#experimenttreatment) objects for the alternate versions of the
+	// product page being tested.
+	Treatments ExperimentTreatments `yaml:"treatments"`
+}
+
+// ExperimentTreatments is a map of names to [ExperimentTreatment](#experimenttreatment) objects.
+type ExperimentTreatments map[string]ExperimentTreatment
+
+// ExperimentTreatment describes an alternate version of the product page tested by an experiment.
+type ExperimentTreatment struct {
+	// Name of an alternate app icon included in the build to show in the treatment.
+	AppIconName string `yaml:"appIconName,omitempty"`
+	// Map of [locale codes](#locales) to [ExperimentTreatmentLocalization](#experimenttreatmentlocalization) objects.
+	Localizations ExperimentTreatmentLocalizations `yaml:"localizations,omitempty"`
+}
+
+// ExperimentTreatmentLocalizations is a map of [locale codes](#locales) to
+// [ExperimentTreatmentLocalization](#experimenttreatmentlocalization) objects.
+type ExperimentTreatmentLocalizations map[string]ExperimentTreatmentLocalization
+
+// ExperimentTreatmentLocalization contains the localized assets of an experiment treatment.
+type ExperimentTreatmentLocalization struct {
+	// Map of preview types to arrays of app preview assets.
+	PreviewSets PreviewSets `yaml:"previewSets,omitempty"`
+	// Map of screenshot types to arrays of app screenshot assets.
+	ScreenshotSets ScreenshotSets `yaml:"screenshotSets,omitempty"`
+}
+
+/*
+InAppEvents is a map of reference names to [InAppEvent](#inappevent) objects. In-app events are created in App
+Store Connect if they do not exist yet, and updated otherwise, when submitting to the App Store. Events that have
+been submitted for review or published are left as they are.
+
+The dates of an event are templated, so that they can be set from the environment of each release, and must be
+[RFC 3339](https://tools.ietf.org/html/rfc3339) timestamps once templates are applied.
+
+For example:
+
+```yaml
+inAppEvents:
+  Summer Challenge:
+    badge: challenge
+    territories:
+      - USA
+      - CAN
+    eventStart: "{{ .env.EVENT_START }}"
+    eventEnd: "{{ .env.EVENT_END }}"
+    localizations:
+      en-US:
+        name: Summer Challenge
+        shortDescription: Race your friends all summer long
+        longDescription: Complete daily races to climb the summer leaderboard and unlock exclusive cars.
+        eventCard:
+          image:
+            path: assets/events/summer/card.png
+        eventDetailsPage:
+          video:
+            path: assets/events/summer/details.mp4
+```
+.
+*/
+type InAppEvents map[string]InAppEvent
+
+// InAppEvent describes a timely event in an app, such as a game competition or a movie premiere, which is shown
+// on the App Store.
+type InAppEvent struct {
+	// Badge describing the kind of event.
+	Badge inAppEventBadge `yaml:"badge"`
+	// Deep link that opens the event in the app. Defaults to opening the app.
+	DeepLink string `yaml:"deepLink,omitempty"`
+	// Customers the App Store suggests the event to. Defaults to all customers.
+	Purpose inAppEventPurpose `yaml:"purpose,omitempty"`
+	// Priority of the event among the other events of the app. Defaults to normal.
+	Priority inAppEventPriority `yaml:"priority,omitempty"`
+	// ISO 3166-1 Alpha-3 codes of the territories the event is shown in.
+	Territories []string `yaml:"territories"`
+	// Date the event is first shown on the App Store, up to 14 days before it starts. Templated. Defaults to
+	// the start of the event.
+	PublishStart string `yaml:"publishStart,omitempty"`
+	// Date the event starts. Templated.
+	EventStart string `yaml:"eventStart"`
+	// Date the event ends, up to 31 days after it starts. Templated.
+	EventEnd string `yaml:"eventEnd"`
+	// Map of [locale codes](#locales) to [InAppEventLocalization](#inappeventlocalization) objects.
+	Localizations InAppEventLocalizations `yaml:"localizations"`
+}
+
+// InAppEventLocalizations is a map of [locale codes](#locales) to
+// [InAppEventLocalization](#inappeventlocalization) objects.
+type InAppEventLocalizations map[string]InAppEventLocalization
+
+// InAppEventLocalization contains the localized details and media of an in-app event.
+type InAppEventLocalization struct {
+	// Name of the event in this locale, up to 30 characters. Templated.
+	Name string `yaml:"name"`
+	// Short description of the event shown on its event card, up to 50 characters. Templated.
+	ShortDescription string `yaml:"shortDescription"`
+	// Long description of the event shown on its details page, up to 120 characters. Templated.
+	LongDescription string `yaml:"longDescription"`
+	// Media shown on the event card in search results and on the product page of the app.
+	EventCard *InAppEventMedia `yaml:"eventCard,omitempty"`
+	// Media shown at the top of the details page of the event.
+	EventDetailsPage *InAppEventMedia `yaml:"eventDetailsPage,omitempty"`
+}
+
+// InAppEventMedia is an image or video shown for an in-app event. App Store Connect does not keep checksums of
+// event media, so the media of an event is only replaced when the name of its file changes.
+type InAppEventMedia struct {
+	// Image asset.
+	Image *File `yaml:"image,omitempty"`
+	// Video asset, which is shown instead of the image where videos are supported.
+	Video *Preview `yaml:"video,omitempty"`
+}
+
+// Load config file. Problems with the contents of the file are reported as ValidationErrors.
+func Load(file string) (config Project, err error) {
+	return LoadWithProfile(file, "")
+}
+
+// LoadReader config via io.Reader. The format of the config is detected from its contents.
 func LoadReader(fd io.Reader) (config Project, err error) {
 	data, err := io.ReadAll(fd)
 	if err != nil {
 		return config, err
 	}
 
-	err = yaml.UnmarshalStrict(data, &config)
+	source, err := ParseSource("", data)
+	if err != nil {
+		return config, err
+	}
 
-	return config, err
+	return source.Decode()
 }
 
 func (p Project) String() (string, error) {
@@ -731,20 +1555,15 @@ func (p *Platform) APIValue() *asc.Platform {
 		return nil
 	}
 
-	var value asc.Platform
+	for _, t := range platformTypes {
+		if t.config == *p {
+			value := t.api
 
-	switch *p {
-	case PlatformiOS:
-		value = asc.PlatformIOS
-	case PlatformMacOS:
-		value = asc.PlatformMACOS
-	case PlatformTvOS:
-		value = asc.PlatformTVOS
-	default:
-		return nil
+			return &value
+		}
 	}
 
-	return &value
+	return nil
 }
 
 func (c *contentIntensity) APIValue() *string {
@@ -810,44 +1629,20 @@ func (t *releaseType) APIValue() *string {
 	return &value
 }
 
-func (t *previewType) APIValue() *asc.PreviewType {
+func (t *inAppPurchaseType) APIValue() *string {
 	if t == nil {
 		return nil
 	}
 
-	var value asc.PreviewType
+	var value string
 
 	switch *t {
-	case PreviewTypeAppleTV:
-		value = asc.PreviewTypeAppleTV
-	case PreviewTypeDesktop:
-		value = asc.PreviewTypeDesktop
-	case PreviewTypeiPad105:
-		value = asc.PreviewTypeiPad105
-	case PreviewTypeiPad97:
-		value = asc.PreviewTypeiPad97
-	case PreviewTypeiPadPro129:
-		value = asc.PreviewTypeiPadPro129
-	case PreviewTypeiPadPro3Gen11:
-		value = asc.PreviewTypeiPadPro3Gen11
-	case PreviewTypeiPadPro3Gen129:
-		value = asc.PreviewTypeiPadPro3Gen129
-	case PreviewTypeiPhone35:
-		value = asc.PreviewTypeiPhone35
-	case PreviewTypeiPhone40:
-		value = asc.PreviewTypeiPhone40
-	case PreviewTypeiPhone47:
-		value = asc.PreviewTypeiPhone47
-	case PreviewTypeiPhone55:
-		value = asc.PreviewTypeiPhone55
-	case PreviewTypeiPhone58:
-		value = asc.PreviewTypeiPhone58
-	case PreviewTypeiPhone65:
-		value = asc.PreviewTypeiPhone65
-	case PreviewTypeWatchSeries3:
-		value = asc.PreviewTypeWatchSeries3
-	case PreviewTypeWatchSeries4:
-		value = asc.PreviewTypeWatchSeries4
+	case InAppPurchaseTypeConsumable:
+		value = "CONSUMABLE"
+	case InAppPurchaseTypeNonConsumable:
+		value = "NON_CONSUMABLE"
+	case InAppPurchaseTypeNonRenewingSubscription:
+		value = "NON_RENEWING_SUBSCRIPTION"
 	default:
 		return nil
 	}
@@ -855,64 +1650,149 @@ func (t *previewType) APIValue() *asc.PreviewType {
 	return &value
 }
 
-func (t *screenshotType) APIValue() *asc.ScreenshotDisplayType {
-	if t == nil {
+func (d *subscriptionDuration) APIValue() *string {
+	if d == nil {
 		return nil
 	}
 
-	var value asc.ScreenshotDisplayType
+	var value string
 
-	switch *t {
-	case ScreenshotTypeAppleTV:
-		value = asc.ScreenshotDisplayTypeAppAppleTV
-	case ScreenshotTypeDesktop:
-		value = asc.ScreenshotDisplayTypeAppDesktop
-	case ScreenshotTypeiPad105:
-		value = asc.ScreenshotDisplayTypeAppiPad105
-	case ScreenshotTypeiPad97:
-		value = asc.ScreenshotDisplayTypeAppiPad97
-	case ScreenshotTypeiPadPro129:
-		value = asc.ScreenshotDisplayTypeAppiPadPro129
-	case ScreenshotTypeiPadPro3Gen11:
-		value = asc.ScreenshotDisplayTypeAppiPadPro3Gen11
-	case ScreenshotTypeiPadPro3Gen129:
-		value = asc.ScreenshotDisplayTypeAppiPadPro3Gen129
-	case ScreenshotTypeiPhone35:
-		value = asc.ScreenshotDisplayTypeAppiPhone35
-	case ScreenshotTypeiPhone40:
-		value = asc.ScreenshotDisplayTypeAppiPhone40
-	case ScreenshotTypeiPhone47:
-		value = asc.ScreenshotDisplayTypeAppiPhone47
-	case ScreenshotTypeiPhone55:
-		value = asc.ScreenshotDisplayTypeAppiPhone55
-	case ScreenshotTypeiPhone58:
-		value = asc.ScreenshotDisplayTypeAppiPhone58
-	case ScreenshotTypeiPhone65:
-		value = asc.ScreenshotDisplayTypeAppiPhone65
-	case ScreenshotTypeWatchSeries3:
-		value = asc.ScreenshotDisplayTypeAppWatchSeries3
-	case ScreenshotTypeWatchSeries4:
-		value = asc.ScreenshotDisplayTypeAppWatchSeries4
-	case ScreenshotTypeiMessageiPad105:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPad105
-	case ScreenshotTypeiMessageiPad97:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPad97
-	case ScreenshotTypeiMessageiPadPro129:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPadPro129
-	case ScreenshotTypeiMessageiPadPro3Gen11:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen11
-	case ScreenshotTypeiMessageiPadPro3Gen129:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen129
-	case ScreenshotTypeiMessageiPhone40:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPhone40
-	case ScreenshotTypeiMessageiPhone47:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPhone47
-	case ScreenshotTypeiMessageiPhone55:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPhone55
-	case ScreenshotTypeiMessageiPhone58:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPhone58
-	case ScreenshotTypeiMessageiPhone65:
-		value = asc.ScreenshotDisplayTypeiMessageAppIPhone65
+	switch *d {
+	case SubscriptionDurationOneWeek:
+		value = "ONE_WEEK"
+	case SubscriptionDurationOneMonth:
+		value = "ONE_MONTH"
+	case SubscriptionDurationTwoMonths:
+		value = "TWO_MONTHS"
+	case SubscriptionDurationThreeMonths:
+		value = "THREE_MONTHS"
+	case SubscriptionDurationSixMonths:
+		value = "SIX_MONTHS"
+	case SubscriptionDurationOneYear:
+		value = "ONE_YEAR"
+	default:
+		return nil
+	}
+
+	return &value
+}
+
+func (m *offerMode) APIValue() *string {
+	if m == nil {
+		return nil
+	}
+
+	var value string
+
+	switch *m {
+	case OfferModeFreeTrial:
+		value = "FREE_TRIAL"
+	case OfferModePayAsYouGo:
+		value = "PAY_AS_YOU_GO"
+	case OfferModePayUpFront:
+		value = "PAY_UP_FRONT"
+	default:
+		return nil
+	}
+
+	return &value
+}
+
+func (d *offerDuration) APIValue() *string {
+	if d == nil {
+		return nil
+	}
+
+	var value string
+
+	switch *d {
+	case OfferDurationThreeDays:
+		value = "THREE_DAYS"
+	case OfferDurationOneWeek:
+		value = "ONE_WEEK"
+	case OfferDurationTwoWeeks:
+		value = "TWO_WEEKS"
+	case OfferDurationOneMonth:
+		value = "ONE_MONTH"
+	case OfferDurationTwoMonths:
+		value = "TWO_MONTHS"
+	case OfferDurationThreeMonths:
+		value = "THREE_MONTHS"
+	case OfferDurationSixMonths:
+		value = "SIX_MONTHS"
+	case OfferDurationOneYear:
+		value = "ONE_YEAR"
+	default:
+		return nil
+	}
+
+	return &value
+}
+
+func (b *inAppEventBadge) APIValue() *string {
+	if b == nil {
+		return nil
+	}
+
+	var value string
+
+	switch *b {
+	case InAppEventBadgeLiveEvent:
+		value = "LIVE_EVENT"
+	case InAppEventBadgePremiere:
+		value = "PREMIERE"
+	case InAppEventBadgeChallenge:
+		value = "CHALLENGE"
+	case InAppEventBadgeCompetition:
+		value = "COMPETITION"
+	case InAppEventBadgeNewSeason:
+		value = "NEW_SEASON"
+	case InAppEventBadgeMajorUpdate:
+		value = "MAJOR_UPDATE"
+	case InAppEventBadgeSpecialEvent:
+		value = "SPECIAL_EVENT"
+	default:
+		return nil
+	}
+
+	return &value
+}
+
+func (p *inAppEventPurpose) APIValue() *string {
+	if p == nil {
+		return nil
+	}
+
+	var value string
+
+	switch *p {
+	case InAppEventPurposeAppropriateForAllUsers:
+		value = "APPROPRIATE_FOR_ALL_USERS"
+	case InAppEventPurposeAttractNewUsers:
+		value = "ATTRACT_NEW_USERS"
+	case InAppEventPurposeKeepActiveUsersInformed:
+		value = "KEEP_ACTIVE_USERS_INFORMED"
+	case InAppEventPurposeBringBackLapsedUsers:
+		value = "BRING_BACK_LAPSED_USERS"
+	default:
+		return nil
+	}
+
+	return &value
+}
+
+func (p *inAppEventPriority) APIValue() *string {
+	if p == nil {
+		return nil
+	}
+
+	var value string
+
+	switch *p {
+	case InAppEventPriorityHigh:
+		value = "HIGH"
+	case InAppEventPriorityNormal:
+		value = "NORMAL"
 	default:
 		return nil
 	}
@@ -920,97 +1800,90 @@ func (t *screenshotType) APIValue() *asc.ScreenshotDisplayType {
 	return &value
 }
 
-// GetPreviews fetches the value from the map corresponding to the API value.
+func (t *previewType) APIValue() *asc.PreviewType {
+	if t == nil {
+		return nil
+	}
+
+	for _, pt := range previewTypes {
+		if pt.config == *t {
+			value := pt.api
+
+			return &value
+		}
+	}
+
+	return nil
+}
+
+func (t *screenshotType) APIValue() *asc.ScreenshotDisplayType {
+	if t == nil {
+		return nil
+	}
+
+	for _, st := range screenshotTypes {
+		if st.config == *t {
+			value := st.api
+
+			return &value
+		}
+	}
+
+	return nil
+}
+
+// GetPreviews fetches the value from the map corresponding to the API value. When more than one configured preview
+// type corresponds to the API value, such as iphone67 and iphone69, the one listed first takes precedence.
 func (s PreviewSets) GetPreviews(previewType asc.PreviewType) []Preview {
-	switch previewType {
-	case asc.PreviewTypeAppleTV:
-		return s[PreviewTypeAppleTV]
-	case asc.PreviewTypeDesktop:
-		return s[PreviewTypeDesktop]
-	case asc.PreviewTypeiPad105:
-		return s[PreviewTypeiPad105]
-	case asc.PreviewTypeiPad97:
-		return s[PreviewTypeiPad97]
-	case asc.PreviewTypeiPadPro129:
-		return s[PreviewTypeiPadPro129]
-	case asc.PreviewTypeiPadPro3Gen11:
-		return s[PreviewTypeiPadPro3Gen11]
-	case asc.PreviewTypeiPadPro3Gen129:
-		return s[PreviewTypeiPadPro3Gen129]
-	case asc.PreviewTypeiPhone35:
-		return s[PreviewTypeiPhone35]
-	case asc.PreviewTypeiPhone40:
-		return s[PreviewTypeiPhone40]
-	case asc.PreviewTypeiPhone47:
-		return s[PreviewTypeiPhone47]
-	case asc.PreviewTypeiPhone55:
-		return s[PreviewTypeiPhone55]
-	case asc.PreviewTypeiPhone58:
-		return s[PreviewTypeiPhone58]
-	case asc.PreviewTypeiPhone65:
-		return s[PreviewTypeiPhone65]
-	case asc.PreviewTypeWatchSeries3:
-		return s[PreviewTypeWatchSeries3]
-	case asc.PreviewTypeWatchSeries4:
-		return s[PreviewTypeWatchSeries4]
+	for _, t := range previewTypes {
+		if t.api != previewType {
+			continue
+		}
+
+		if previews, ok := s[t.config]; ok {
+			return previews
+		}
 	}
 
 	return []Preview{}
 }
 
-// GetScreenshots fetches the value from the map corresponding to the API value.
+// WithFallbacks returns the screenshot sets with a set added for each screenshot type in fallbacks that has no set
+// of its own, with the screenshots of the screenshot type it falls back to.
+func (s ScreenshotSets) WithFallbacks(fallbacks ScreenshotFallbacks) ScreenshotSets {
+	if len(fallbacks) == 0 {
+		return s
+	}
+
+	sets := make(ScreenshotSets, len(s)+len(fallbacks))
+	for screenshotType, screenshots := range s {
+		sets[screenshotType] = screenshots
+	}
+
+	for screenshotType, from := range fallbacks {
+		if _, ok := sets[screenshotType]; ok {
+			continue
+		}
+
+		if screenshots, ok := s[from]; ok {
+			sets[screenshotType] = screenshots
+		}
+	}
+
+	return sets
+}
+
+// GetScreenshots fetches the value from the map corresponding to the API value. When more than one configured
+// screenshot type corresponds to the API value, such as iphone67 and iphone69, the one listed first takes precedence.
 func (s ScreenshotSets) GetScreenshots(screenshotType asc.ScreenshotDisplayType) []File {
-	switch screenshotType {
-	case asc.ScreenshotDisplayTypeAppAppleTV:
-		return s[ScreenshotTypeAppleTV]
-	case asc.ScreenshotDisplayTypeAppDesktop:
-		return s[ScreenshotTypeDesktop]
-	case asc.ScreenshotDisplayTypeAppiPad105:
-		return s[ScreenshotTypeiPad105]
-	case asc.ScreenshotDisplayTypeAppiPad97:
-		return s[ScreenshotTypeiPad97]
-	case asc.ScreenshotDisplayTypeAppiPadPro129:
-		return s[ScreenshotTypeiPadPro129]
-	case asc.ScreenshotDisplayTypeAppiPadPro3Gen11:
-		return s[ScreenshotTypeiPadPro3Gen11]
-	case asc.ScreenshotDisplayTypeAppiPadPro3Gen129:
-		return s[ScreenshotTypeiPadPro3Gen129]
-	case asc.ScreenshotDisplayTypeAppiPhone35:
-		return s[ScreenshotTypeiPhone35]
-	case asc.ScreenshotDisplayTypeAppiPhone40:
-		return s[ScreenshotTypeiPhone40]
-	case asc.ScreenshotDisplayTypeAppiPhone47:
-		return s[ScreenshotTypeiPhone47]
-	case asc.ScreenshotDisplayTypeAppiPhone55:
-		return s[ScreenshotTypeiPhone55]
-	case asc.ScreenshotDisplayTypeAppiPhone58:
-		return s[ScreenshotTypeiPhone58]
-	case asc.ScreenshotDisplayTypeAppiPhone65:
-		return s[ScreenshotTypeiPhone65]
-	case asc.ScreenshotDisplayTypeAppWatchSeries3:
-		return s[ScreenshotTypeWatchSeries3]
-	case asc.ScreenshotDisplayTypeAppWatchSeries4:
-		return s[ScreenshotTypeWatchSeries4]
-	case asc.ScreenshotDisplayTypeiMessageAppIPad105:
-		return s[ScreenshotTypeiMessageiPad105]
-	case asc.ScreenshotDisplayTypeiMessageAppIPad97:
-		return s[ScreenshotTypeiMessageiPad97]
-	case asc.ScreenshotDisplayTypeiMessageAppIPadPro129:
-		return s[ScreenshotTypeiMessageiPadPro129]
-	case asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen11:
-		return s[ScreenshotTypeiMessageiPadPro3Gen11]
-	case asc.ScreenshotDisplayTypeiMessageAppIPadPro3Gen129:
-		return s[ScreenshotTypeiMessageiPadPro3Gen129]
-	case asc.ScreenshotDisplayTypeiMessageAppIPhone40:
-		return s[ScreenshotTypeiMessageiPhone40]
-	case asc.ScreenshotDisplayTypeiMessageAppIPhone47:
-		return s[ScreenshotTypeiMessageiPhone47]
-	case asc.ScreenshotDisplayTypeiMessageAppIPhone55:
-		return s[ScreenshotTypeiMessageiPhone55]
-	case asc.ScreenshotDisplayTypeiMessageAppIPhone58:
-		return s[ScreenshotTypeiMessageiPhone58]
-	case asc.ScreenshotDisplayTypeiMessageAppIPhone65:
-		return s[ScreenshotTypeiMessageiPhone65]
+	for _, t := range screenshotTypes {
+		if t.api != screenshotType {
+			continue
+		}
+
+		if screenshots, ok := s[t.config]; ok {
+			return screenshots
+		}
 	}
 
 	return []File{}
@@ -37,6 +37,8 @@ func TestPlatformAPIValue(t *testing.T) {
 	assert.Equal(t, *plat.APIValue(), asc.PlatformMACOS)
 	plat = PlatformTvOS
 	assert.Equal(t, *plat.APIValue(), asc.PlatformTVOS)
+	plat = PlatformVisionOS
+	assert.Equal(t, *plat.APIValue(), asc.Platform("VISION_OS"))
 
 	bad := Platform("watchOS")
 	assert.Empty(t, bad.APIValue())
@@ -65,6 +67,25 @@ func TestReleaseTypeAPIValue(t *testing.T) {
 	assert.Empty(t, empty.APIValue())
 }
 
+func TestInAppPurchaseTypeAPIValue(t *testing.T) {
+	t.Parallel()
+
+	var typ inAppPurchaseType
+	typ = InAppPurchaseTypeConsumable
+	assert.Equal(t, *typ.APIValue(), "CONSUMABLE")
+	typ = InAppPurchaseTypeNonConsumable
+	assert.Equal(t, *typ.APIValue(), "NON_CONSUMABLE")
+	typ = InAppPurchaseTypeNonRenewingSubscription
+	assert.Equal(t, *typ.APIValue(), "NON_RENEWING_SUBSCRIPTION")
+
+	bad := inAppPurchaseType("autoRenewable")
+	assert.Empty(t, bad.APIValue())
+
+	var empty *inAppPurchaseType
+
+	assert.Empty(t, empty.APIValue())
+}
+
 func TestContentIntensityAPIValue(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +158,18 @@ func TestPreviewTypeAPIValue(t *testing.T) {
 	assert.Equal(t, *preview.APIValue(), asc.PreviewTypeWatchSeries3)
 	preview = PreviewTypeWatchSeries4
 	assert.Equal(t, *preview.APIValue(), asc.PreviewTypeWatchSeries4)
+	preview = PreviewTypeAppleTV4K
+	assert.Equal(t, *preview.APIValue(), asc.PreviewTypeAppleTV)
+	preview = PreviewTypeAppleVisionPro
+	assert.Equal(t, *preview.APIValue(), asc.PreviewType("APPLE_VISION_PRO"))
+	preview = PreviewTypeiPadPro13
+	assert.Equal(t, *preview.APIValue(), asc.PreviewTypeiPadPro3Gen129)
+	preview = PreviewTypeiPhone61
+	assert.Equal(t, *preview.APIValue(), asc.PreviewType("IPHONE_61"))
+	preview = PreviewTypeiPhone67
+	assert.Equal(t, *preview.APIValue(), asc.PreviewType("IPHONE_67"))
+	preview = PreviewTypeiPhone69
+	assert.Equal(t, *preview.APIValue(), asc.PreviewType("IPHONE_67"))
 
 	bad := previewType("Google Pixel")
 	assert.Empty(t, bad.APIValue())
@@ -200,6 +233,28 @@ func TestScreenshotTypeAPIValue(t *testing.T) {
 	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayTypeiMessageAppIPhone58)
 	screenshot = ScreenshotTypeiMessageiPhone65
 	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayTypeiMessageAppIPhone65)
+	screenshot = ScreenshotTypeAppleTV4K
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayTypeAppAppleTV)
+	screenshot = ScreenshotTypeAppleVisionPro
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_APPLE_VISION_PRO"))
+	screenshot = ScreenshotTypeiPadPro13
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayTypeAppiPadPro3Gen129)
+	screenshot = ScreenshotTypeiPhone61
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_IPHONE_61"))
+	screenshot = ScreenshotTypeiPhone67
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_IPHONE_67"))
+	screenshot = ScreenshotTypeiPhone69
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_IPHONE_67"))
+	screenshot = ScreenshotTypeWatchSeries7
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_WATCH_SERIES_7"))
+	screenshot = ScreenshotTypeWatchSeries10
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_WATCH_SERIES_10"))
+	screenshot = ScreenshotTypeWatchUltra
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("APP_WATCH_ULTRA"))
+	screenshot = ScreenshotTypeiMessageiPhone61
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("IMESSAGE_APP_IPHONE_61"))
+	screenshot = ScreenshotTypeiMessageiPhone67
+	assert.Equal(t, *screenshot.APIValue(), asc.ScreenshotDisplayType("IMESSAGE_APP_IPHONE_67"))
 
 	bad := screenshotType("Google Pixel")
 	assert.Empty(t, bad.APIValue())
@@ -245,6 +300,13 @@ func TestPreviewSetsGetPreviews(t *testing.T) {
 	sets[PreviewTypeWatchSeries4] = []Preview{}
 	assert.Empty(t, sets.GetPreviews(asc.PreviewTypeWatchSeries4))
 	assert.Empty(t, sets.GetPreviews(""))
+
+	sets = PreviewSets{
+		PreviewTypeiPhone69: {{File: File{Path: "iphone69.mp4"}}},
+	}
+	assert.Equal(t, []Preview{{File: File{Path: "iphone69.mp4"}}}, sets.GetPreviews("IPHONE_67"))
+	sets[PreviewTypeiPhone67] = []Preview{{File: File{Path: "iphone67.mp4"}}}
+	assert.Equal(t, []Preview{{File: File{Path: "iphone67.mp4"}}}, sets.GetPreviews("IPHONE_67"))
 }
 
 func TestGetScreenshotSetsGetScreenshots(t *testing.T) {
@@ -303,4 +365,31 @@ func TestGetScreenshotSetsGetScreenshots(t *testing.T) {
 	sets[ScreenshotTypeiMessageiPhone65] = []File{}
 	assert.Empty(t, sets.GetScreenshots(asc.ScreenshotDisplayTypeiMessageAppIPhone65))
 	assert.Empty(t, sets.GetScreenshots(""))
+
+	sets = ScreenshotSets{
+		ScreenshotTypeAppleTV4K: {{Path: "appleTV4K.png"}},
+	}
+	assert.Equal(t, []File{{Path: "appleTV4K.png"}}, sets.GetScreenshots(asc.ScreenshotDisplayTypeAppAppleTV))
+	sets[ScreenshotTypeAppleTV] = []File{{Path: "appleTV.png"}}
+	assert.Equal(t, []File{{Path: "appleTV.png"}}, sets.GetScreenshots(asc.ScreenshotDisplayTypeAppAppleTV))
+}
+
+func TestScreenshotSetsWithFallbacks(t *testing.T) {
+	t.Parallel()
+
+	sets := ScreenshotSets{
+		ScreenshotTypeiPhone67: {{Path: "iphone67.png"}},
+		ScreenshotTypeiPhone55: {{Path: "iphone55.png"}},
+	}
+	assert.Equal(t, sets, sets.WithFallbacks(nil))
+	assert.Equal(t, ScreenshotSets{
+		ScreenshotTypeiPhone67: {{Path: "iphone67.png"}},
+		ScreenshotTypeiPhone65: {{Path: "iphone67.png"}},
+		ScreenshotTypeiPhone55: {{Path: "iphone55.png"}},
+	}, sets.WithFallbacks(ScreenshotFallbacks{
+		ScreenshotTypeiPhone65: ScreenshotTypeiPhone67,
+		ScreenshotTypeiPhone55: ScreenshotTypeiPhone67,
+		ScreenshotTypeiPhone47: ScreenshotTypeiPhone58,
+	}))
+	assert.Len(t, sets, 2)
 }
@@ -23,5 +23,36 @@ Package config contains types and helpers available to configure a Cider project
 
 You can customize your project using a `.cider.yml` file either created from scratch
 or using [`cider init`](./commands/cider_init.md).
+
+The configuration file can also be written in JSON or TOML, as `.cider.json` or `.cider.toml`.
+Every format is decoded with the same fields and the same strictness, and examples in this
+documentation use YAML. To start with one of these formats, use `cider init --format json`.
+
+Environment-specific differences, such as a staging build with its own bundle ID and
+beta groups, can be kept in a profile overlay next to the configuration file. Running
+with `--profile staging` patches `.cider.staging.yml` over `.cider.yml`, merging
+mappings key by key and replacing any other values outright. The active profile is
+available to templated fields as `{{ .profile }}`.
+
+The public TestFlight links of beta groups are printed at the end of a release, and listed by
+[`cider testflight links`](./commands/cider_testflight_links.md). Templates rendered during a
+release, such as the `whatToTest` template, can read them from `{{ .publicLinks }}`, a map of
+app names to maps of beta group names to links. The links are read back after beta groups are updated,
+so groups created or made public by the release are included. When `whatToTest` is set, the links
+are also read before beta groups are updated, so the notes only list groups that already existed.
+
+A [JSON Schema](https://cidertool.github.io/cider/schema.json) describing the configuration file is published alongside
+this documentation, and [`cider check`](./commands/cider_check.md) validates against it.
+Editors using the YAML language server can load it with a modeline at the top of the file:
+
+```yaml
+# yaml-language-server: $schema=https://cidertool.github.io/cider/schema.json
+```
+
+Files declare the version of the schema they are written against with a top-level
+`schemaVersion` key. Files written against an older version, or without the key, are
+still loaded, and [`cider check`](./commands/cider_check.md) warns about any deprecated
+keys they use. [`cider config migrate`](./commands/cider_config_migrate.md) upgrades such
+a file in place, keeping its comments.
 */
 package config
@@ -0,0 +1,75 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Limits App Store Connect sets on the text of in-app events, in characters.
+const (
+	inAppEventNameLimit             = 30
+	inAppEventShortDescriptionLimit = 50
+	inAppEventLongDescriptionLimit  = 120
+)
+
+// Limits App Store Connect sets on the schedule of in-app events.
+const (
+	inAppEventMaxPublishLead = 14 * 24 * time.Hour
+	inAppEventMaxDuration    = 31 * 24 * time.Hour
+)
+
+// InAppEventSchedule is the schedule of an in-app event, once templates are applied to its dates.
+type InAppEventSchedule struct {
+	PublishStart time.Time
+	EventStart   time.Time
+	EventEnd     time.Time
+}
+
+// Schedule parses the dates of the event. The event is published when it starts unless it sets a publish date.
+func (e InAppEvent) Schedule() (InAppEventSchedule, error) {
+	var schedule InAppEventSchedule
+
+	var err error
+
+	if schedule.EventStart, err = time.Parse(time.RFC3339, e.EventStart); err != nil {
+		return schedule, err
+	}
+
+	if schedule.EventEnd, err = time.Parse(time.RFC3339, e.EventEnd); err != nil {
+		return schedule, err
+	}
+
+	schedule.PublishStart = schedule.EventStart
+	if e.PublishStart != "" {
+		if schedule.PublishStart, err = time.Parse(time.RFC3339, e.PublishStart); err != nil {
+			return schedule, err
+		}
+	}
+
+	return schedule, nil
+}
+
+// isTemplated reports whether s is a template, whose value is only known once templates are applied.
+func isTemplated(s string) bool {
+	return strings.Contains(s, "{{")
+}
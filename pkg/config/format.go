@@ -0,0 +1,183 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a file format a configuration file can be written in.
+type Format string
+
+const (
+	// FormatYAML refers to YAML, the default configuration file format.
+	FormatYAML Format = "yaml"
+	// FormatJSON refers to JSON.
+	FormatJSON Format = "json"
+	// FormatTOML refers to TOML.
+	FormatTOML Format = "toml"
+)
+
+// nolint: gochecknoglobals
+var (
+	tomlLinePattern        = regexp.MustCompile(`^(\[.*\]|[A-Za-z0-9_"'.-]+\s*=.*)$`)
+	tomlErrorPrefixPattern = regexp.MustCompile(`^toml: line \d+( \(last key ".*?"\))?: `)
+)
+
+// ErrUnsupportedFormat happens when a format other than YAML, JSON or TOML is requested.
+type ErrUnsupportedFormat struct {
+	Format string
+}
+
+func (e ErrUnsupportedFormat) Error() string {
+	return fmt.Sprintf("unsupported config format %s, expected one of yaml, json, toml", e.Format)
+}
+
+// ParseFormat returns the Format for the given name, such as `json`.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatYAML, FormatJSON, FormatTOML:
+		return format, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", ErrUnsupportedFormat{Format: name}
+	}
+}
+
+// FormatForPath returns the Format implied by the extension of path, or an empty Format if the
+// extension is not recognized.
+func FormatForPath(path string) Format {
+	format, err := ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return ""
+	}
+
+	return format
+}
+
+// DetectFormat sniffs the contents of a configuration file to determine its format. JSON
+// documents begin with an object, and TOML documents begin with a table header or a key/value
+// pair. Anything else is treated as YAML.
+func DetectFormat(data []byte) Format {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "{"):
+			return FormatJSON
+		case tomlLinePattern.MatchString(line):
+			return FormatTOML
+		default:
+			return FormatYAML
+		}
+	}
+
+	return FormatYAML
+}
+
+// Marshal encodes the project in the given format, declaring the current schema version.
+func (p Project) Marshal(format Format) ([]byte, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, err
+	} else if format == FormatYAML {
+		header := []byte(fmt.Sprintf("%s: %d\n", SchemaVersionKey, SchemaVersion))
+		if len(p) == 0 {
+			return header, nil
+		}
+
+		return append(header, data...), nil
+	}
+
+	// Decoding into a generic value keeps the field names used by the YAML tags.
+	var generic map[string]interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	if generic == nil {
+		generic = make(map[string]interface{})
+	}
+
+	generic[SchemaVersionKey] = SchemaVersion
+
+	switch format {
+	case FormatJSON:
+		data, err = json.MarshalIndent(generic, "", "  ")
+
+		return append(data, '\n'), err
+	case FormatTOML:
+		var buf bytes.Buffer
+
+		enc := toml.NewEncoder(&buf)
+		enc.Indent = ""
+		err = enc.Encode(generic)
+
+		return buf.Bytes(), err
+	default:
+		return nil, ErrUnsupportedFormat{Format: string(format)}
+	}
+}
+
+// tomlToNode decodes a TOML document into a YAML node so it can be checked and decoded like any
+// other configuration file. TOML does not retain positions, so the node has none.
+func tomlToNode(data []byte) (*yaml.Node, error) {
+	var generic map[string]interface{}
+	if _, err := toml.Decode(string(data), &generic); err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			message := tomlErrorPrefixPattern.ReplaceAllString(perr.Error(), "")
+
+			return nil, ValidationError{Line: perr.Position.Line, Message: message}
+		}
+
+		return nil, err
+	}
+
+	if len(generic) == 0 {
+		return nil, nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(generic); err != nil {
+		return nil, err
+	}
+
+	walkNodes(&node, "", func(n *yaml.Node, path string) bool {
+		n.Line, n.Column = 0, 0
+
+		return false
+	})
+
+	return &node, nil
+}
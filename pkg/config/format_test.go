@@ -0,0 +1,131 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]Format{
+		"yaml": FormatYAML,
+		"yml":  FormatYAML,
+		"JSON": FormatJSON,
+		"toml": FormatTOML,
+	} {
+		format, err := ParseFormat(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := ParseFormat("xml")
+	assert.EqualError(t, err, "unsupported config format xml, expected one of yaml, json, toml")
+}
+
+func TestFormatForPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, FormatYAML, FormatForPath(".cider.yml"))
+	assert.Equal(t, FormatYAML, FormatForPath("cider.yaml"))
+	assert.Equal(t, FormatJSON, FormatForPath("config/cider.json"))
+	assert.Equal(t, FormatTOML, FormatForPath(".cider.staging.toml"))
+	assert.Equal(t, Format(""), FormatForPath("cider"))
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, FormatJSON, DetectFormat([]byte("\n  {\"My App\": {}}")))
+	assert.Equal(t, FormatTOML, DetectFormat([]byte("# comment\n[\"My App\"]\nid = \"com.app\"")))
+	assert.Equal(t, FormatTOML, DetectFormat([]byte("title = \"My App\"")))
+	assert.Equal(t, FormatYAML, DetectFormat([]byte("---\nMy App:\n  id: com.app")))
+	assert.Equal(t, FormatYAML, DetectFormat([]byte("My App:\n  id: com.app")))
+	assert.Equal(t, FormatYAML, DetectFormat(nil))
+}
+
+func TestLoadFormats(t *testing.T) {
+	t.Parallel()
+
+	expected, err := Load("testdata/valid.yml")
+	assert.NoError(t, err)
+
+	for _, file := range []string{"testdata/valid.json", "testdata/valid.toml"} {
+		f, err := Load(file)
+		assert.NoError(t, err, file)
+		assert.Equal(t, expected, f, file)
+	}
+}
+
+func TestMarshalFormats(t *testing.T) {
+	t.Parallel()
+
+	f, err := Load("testdata/valid.yml")
+	assert.NoError(t, err)
+
+	for _, format := range []Format{FormatYAML, FormatJSON, FormatTOML} {
+		data, err := f.Marshal(format)
+		assert.NoError(t, err)
+		assert.Equal(t, format, DetectFormat(data))
+
+		f2, err := LoadReader(strings.NewReader(string(data)))
+		assert.NoError(t, err)
+		assert.Equal(t, f, f2)
+	}
+
+	_, err = f.Marshal("xml")
+	assert.Error(t, err)
+}
+
+func TestMarshalFormats_SchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []Format{FormatYAML, FormatJSON, FormatTOML} {
+		data, err := Project{}.Marshal(format)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), SchemaVersionKey, format)
+
+		f, err := LoadReader(strings.NewReader(string(data)))
+		assert.NoError(t, err, format)
+		assert.Empty(t, f, format)
+	}
+}
+
+func TestLoadReader_StrictFormats(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadReader(strings.NewReader(`{"My App": {"id": "com.app", "notAField": true}}`))
+	assert.EqualError(t, err, "1 error occurred:\n\t* 1:30: My App: unknown field \"notAField\"\n\n")
+
+	_, err = LoadReader(strings.NewReader("[\"My App\"]\nid = \"com.app\"\nnotAField = true\n"))
+	assert.EqualError(t, err, "1 error occurred:\n\t* My App: unknown field \"notAField\"\n\n")
+
+	_, err = LoadReader(strings.NewReader("[\"My App\"]\nid = com.app\n"))
+
+	var verr ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, 2, verr.Line)
+	assert.Equal(t, "id = com.app", verr.Snippet)
+}
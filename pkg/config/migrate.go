@@ -0,0 +1,207 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SchemaVersionKey is the reserved top-level key that declares which version of the configuration
+	// schema a file is written against. It cannot be used as an app name.
+	SchemaVersionKey = "schemaVersion"
+	// SchemaVersion is the current version of the configuration schema. Files that declare an earlier
+	// version, or no version at all, are migrated when they are loaded.
+	SchemaVersion = 2
+)
+
+// ErrUnsupportedSchemaVersion happens when a file declares a schema version newer than the one supported.
+type ErrUnsupportedSchemaVersion struct {
+	Version int
+}
+
+func (e ErrUnsupportedSchemaVersion) Error() string {
+	return fmt.Sprintf("schema version %d is newer than the supported version %d, please upgrade Cider", e.Version, SchemaVersion)
+}
+
+// migration upgrades the apps in a document to the given schema version.
+type migration struct {
+	version int
+	apply   func(app *yaml.Node, path string, report reportFunc)
+}
+
+type reportFunc func(node *yaml.Node, path string, message string)
+
+// migrations in the order they are applied.
+// nolint: gochecknoglobals
+var migrations = []migration{
+	{version: 2, apply: migratePricingToPriceTiers},
+}
+
+// migrateNode upgrades the document in place, reporting each deprecated key that was replaced. It returns
+// the schema version the document declared, or 1 if it did not declare one.
+func migrateNode(root *yaml.Node, report reportFunc) (version int, err error) {
+	version = 1
+
+	if root == nil || root.Kind != yaml.MappingNode {
+		return version, nil
+	}
+
+	if i := mappingIndex(root, SchemaVersionKey); i >= 0 {
+		value := root.Content[i+1]
+
+		version, err = strconv.Atoi(value.Value)
+		if err != nil || value.Kind != yaml.ScalarNode {
+			return version, ValidationError{
+				Line:    value.Line,
+				Column:  value.Column,
+				Path:    SchemaVersionKey,
+				Message: "expected an integer",
+			}
+		} else if version > SchemaVersion {
+			return version, ErrUnsupportedSchemaVersion{Version: version}
+		}
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			key, app := root.Content[i], root.Content[i+1]
+			if key.Value == SchemaVersionKey || app.Kind != yaml.MappingNode {
+				continue
+			}
+
+			m.apply(app, key.Value, report)
+		}
+	}
+
+	return version, nil
+}
+
+// migratePricingToPriceTiers renames `availability.pricing` to `availability.priceTiers`.
+func migratePricingToPriceTiers(app *yaml.Node, path string, report reportFunc) {
+	availability := mappingValue(app, "availability")
+	if availability == nil || availability.Kind != yaml.MappingNode {
+		return
+	}
+
+	i := mappingIndex(availability, "pricing")
+	if i < 0 || mappingIndex(availability, "priceTiers") >= 0 {
+		return
+	}
+
+	key := availability.Content[i]
+	report(key, joinPath(path, "availability"), `"pricing" is deprecated, use "priceTiers" instead`)
+	key.Value = "priceTiers"
+}
+
+// Migrate upgrades the configuration document in data to the current schema version, and returns
+// it in the same format along with the deprecated keys that were replaced. The file name is used
+// to determine the format, and to report positions. Comments in YAML documents are preserved.
+func Migrate(file string, data []byte) ([]byte, []ValidationError, error) {
+	s := newSource()
+	format := formatOf(file, data)
+
+	doc, err := s.parseDocument(file, data, format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := documentContent(doc)
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		doc = root
+	}
+
+	if _, err := s.migrate(file, root); err != nil {
+		return nil, nil, err
+	}
+
+	setSchemaVersion(root)
+
+	out, err := encodeNode(doc, root, format)
+
+	return out, s.Deprecations(), err
+}
+
+func setSchemaVersion(root *yaml.Node) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(SchemaVersion)}
+
+	if i := mappingIndex(root, SchemaVersionKey); i >= 0 {
+		root.Content[i+1] = value
+
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: SchemaVersionKey}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+func encodeNode(doc *yaml.Node, root *yaml.Node, format Format) ([]byte, error) {
+	if format == FormatYAML {
+		var buf bytes.Buffer
+
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2) // nolint: gomnd
+
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), enc.Close()
+	}
+
+	var generic map[string]interface{}
+	if err := root.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	if format == FormatJSON {
+		data, err := json.MarshalIndent(generic, "", "  ")
+
+		return append(data, '\n'), err
+	}
+
+	var buf bytes.Buffer
+
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	err := enc.Encode(generic)
+
+	return buf.Bytes(), err
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+
+	return nil
+}
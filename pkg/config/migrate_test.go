@@ -0,0 +1,124 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/legacy.yml")
+	assert.NoError(t, err)
+
+	out, deprecations, err := Migrate("testdata/legacy.yml", data)
+	assert.NoError(t, err)
+	assert.Equal(t, `schemaVersion: 2
+# An app configured before schema versions were introduced.
+My App:
+  id: com.app
+  localizations:
+    en-US:
+      name: My App
+      # Shown on the App Store.
+      privacyPolicyText: go away
+      privacyPolicyURL: https://example.com/privacy
+  availability:
+    # Free in every territory.
+    priceTiers:
+      - tier: '0'
+    availableInNewTerritories: true
+`, string(out))
+
+	assert.Len(t, deprecations, 1)
+	assert.Equal(t, `testdata/legacy.yml:12:5: My App.availability: "pricing" is deprecated, use "priceTiers" instead`, deprecations[0].Error())
+
+	// Migrating again changes nothing.
+	again, deprecations, err := Migrate("testdata/legacy.yml", out)
+	assert.NoError(t, err)
+	assert.Empty(t, deprecations)
+	assert.Equal(t, string(out), string(again))
+}
+
+func TestMigrate_Formats(t *testing.T) {
+	t.Parallel()
+
+	out, deprecations, err := Migrate("cider.json", []byte(`{"My App": {"availability": {"pricing": [{"tier": "0"}]}}}`))
+	assert.NoError(t, err)
+	assert.Len(t, deprecations, 1)
+	assert.JSONEq(t, `{"schemaVersion": 2, "My App": {"availability": {"priceTiers": [{"tier": "0"}]}}}`, string(out))
+
+	out, deprecations, err = Migrate("cider.toml", []byte("[\"My App\".availability]\npricing = [{tier = \"0\"}]\n"))
+	assert.NoError(t, err)
+	assert.Len(t, deprecations, 1)
+	assert.Contains(t, string(out), "schemaVersion = 2\n")
+	assert.Contains(t, string(out), "priceTiers")
+	assert.NotContains(t, string(out), "pricing")
+}
+
+func TestMigrate_Empty(t *testing.T) {
+	t.Parallel()
+
+	out, deprecations, err := Migrate("cider.yml", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, deprecations)
+	assert.Equal(t, "schemaVersion: 2\n", string(out))
+}
+
+func TestMigrate_Err(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Migrate("cider.yml", []byte("schemaVersion: 3\nMy App:\n  id: com.app\n"))
+	assert.ErrorAs(t, err, &ErrUnsupportedSchemaVersion{})
+	assert.EqualError(t, err, "schema version 3 is newer than the supported version 2, please upgrade Cider")
+
+	_, _, err = Migrate("cider.yml", []byte("schemaVersion: two\n"))
+	assert.EqualError(t, err, "cider.yml:1:16: schemaVersion: expected an integer")
+
+	_, _, err = Migrate("cider.yml", []byte("My App: [\n"))
+	assert.Error(t, err)
+}
+
+func TestLoad_Legacy(t *testing.T) {
+	t.Parallel()
+
+	f, err := Load("testdata/legacy.yml")
+	assert.NoError(t, err)
+
+	app := f["My App"]
+	assert.Equal(t, "go away", app.Localizations["en-US"].PrivacyPolicyText)
+	assert.Equal(t, "https://example.com/privacy", app.Localizations["en-US"].PrivacyPolicyURL)
+	assert.Equal(t, []PriceSchedule{{Tier: "0"}}, app.Availability.Pricing)
+
+	source, err := LoadSource("testdata/legacy.yml", "")
+	assert.NoError(t, err)
+	// The replaced keys are not reported as unknown fields.
+	err = source.Validate()
+	assert.NotContains(t, err.Error(), "pricing")
+	assert.Len(t, source.Deprecations(), 1)
+
+	_, err = ParseSource("cider.yml", []byte("schemaVersion: 2\nMy App:\n  id: com.app\n"))
+	assert.NoError(t, err)
+}
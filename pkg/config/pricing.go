@@ -0,0 +1,104 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultPriceBaseTerritory is the base territory of the prices of apps whose availability does not set one.
+const DefaultPriceBaseTerritory = "USA"
+
+// PriceBaseTerritory returns the territory whose prices App Store Connect uses to set the price in territories
+// without prices of their own.
+func (a Availability) PriceBaseTerritory() string {
+	if a.BaseTerritory != "" {
+		return a.BaseTerritory
+	}
+
+	return DefaultPriceBaseTerritory
+}
+
+// PriceBaseTerritory returns the territory whose price App Store Connect uses to set the price of the in-app
+// purchase in other territories.
+func (p InAppPurchase) PriceBaseTerritory() string {
+	if p.BaseTerritory != "" {
+		return p.BaseTerritory
+	}
+
+	return DefaultPriceBaseTerritory
+}
+
+// PricesByTerritory returns the prices of the app keyed by territory, with the prices that don't set a territory
+// in the base territory. The prices of each territory are sorted by start date, with the ones that take effect
+// immediately first, and prices without an end date end when the next price in their territory takes effect.
+func (a Availability) PricesByTerritory() map[string][]AppPrice {
+	prices := make(map[string][]AppPrice)
+
+	for _, price := range a.Prices {
+		price.Territory = a.priceTerritory(price)
+		prices[price.Territory] = append(prices[price.Territory], price)
+	}
+
+	for _, territoryPrices := range prices {
+		sort.SliceStable(territoryPrices, func(i, j int) bool {
+			return territoryPrices[i].start().Before(territoryPrices[j].start())
+		})
+
+		for i := range territoryPrices[:len(territoryPrices)-1] {
+			if territoryPrices[i].EndDate == nil {
+				end := territoryPrices[i+1].start()
+				territoryPrices[i].EndDate = &end
+			}
+		}
+	}
+
+	return prices
+}
+
+func (a Availability) priceTerritory(price AppPrice) string {
+	if price.Territory != "" {
+		return price.Territory
+	}
+
+	return a.PriceBaseTerritory()
+}
+
+// start returns the date the price takes effect, or the zero time if it takes effect immediately.
+func (p AppPrice) start() time.Time {
+	if p.StartDate == nil {
+		return time.Time{}
+	}
+
+	return *p.StartDate
+}
+
+// overlaps reports whether the periods of the prices overlap. A period includes its start date, and ends just
+// before its end date, so a price can take effect the moment another one ends. Prices without an end date last
+// until the next price takes effect, so they only overlap prices that take effect at the same time.
+func (p AppPrice) overlaps(other AppPrice) bool {
+	if other.start().Before(p.start()) {
+		p, other = other, p
+	}
+
+	return p.start().Equal(other.start()) || (p.EndDate != nil && p.EndDate.After(other.start()))
+}
@@ -0,0 +1,66 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailability_PricesByTerritory(t *testing.T) {
+	t.Parallel()
+
+	jan := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	availability := Availability{
+		Prices: []AppPrice{
+			{Price: "2.99", StartDate: &mar},
+			{Price: "0.99"},
+			{Territory: "GBR", Price: "1.49", StartDate: &jan, EndDate: &jun},
+		},
+	}
+
+	assert.Equal(t, DefaultPriceBaseTerritory, availability.PriceBaseTerritory())
+	assert.Equal(t, map[string][]AppPrice{
+		"USA": {
+			{Territory: "USA", Price: "0.99", EndDate: &mar},
+			{Territory: "USA", Price: "2.99", StartDate: &mar},
+		},
+		"GBR": {
+			{Territory: "GBR", Price: "1.49", StartDate: &jan, EndDate: &jun},
+		},
+	}, availability.PricesByTerritory())
+
+	availability.BaseTerritory = "GBR"
+	assert.Equal(t, "GBR", availability.PriceBaseTerritory())
+	assert.Len(t, availability.PricesByTerritory()["GBR"], 3)
+}
+
+func TestInAppPurchase_PriceBaseTerritory(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultPriceBaseTerritory, InAppPurchase{Price: "0.99"}.PriceBaseTerritory())
+	assert.Equal(t, "GBR", InAppPurchase{Price: "0.99", BaseTerritory: "GBR"}.PriceBaseTerritory())
+}
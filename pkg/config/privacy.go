@@ -0,0 +1,129 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+// privacyDataTypeInfo is how App Store Connect presents a privacy data type.
+type privacyDataTypeInfo struct {
+	category string
+	name     string
+}
+
+// PrivacyCategories are the categories of privacy data types, in the order App Store Connect shows them.
+// nolint: gochecknoglobals
+var PrivacyCategories = []string{
+	"Contact Info",
+	"Health & Fitness",
+	"Financial Info",
+	"Location",
+	"Sensitive Info",
+	"Contacts",
+	"User Content",
+	"Browsing History",
+	"Search History",
+	"Identifiers",
+	"Purchases",
+	"Usage Data",
+	"Diagnostics",
+	"Other Data",
+}
+
+// privacyTaxonomy lists the privacy data types App Store Connect knows about.
+// nolint: gochecknoglobals
+var privacyTaxonomy = map[privacyDataType]privacyDataTypeInfo{
+	PrivacyDataTypeName:                 {"Contact Info", "Name"},
+	PrivacyDataTypeEmailAddress:         {"Contact Info", "Email Address"},
+	PrivacyDataTypePhoneNumber:          {"Contact Info", "Phone Number"},
+	PrivacyDataTypePhysicalAddress:      {"Contact Info", "Physical Address"},
+	PrivacyDataTypeOtherContactInfo:     {"Contact Info", "Other User Contact Info"},
+	PrivacyDataTypeHealth:               {"Health & Fitness", "Health"},
+	PrivacyDataTypeFitness:              {"Health & Fitness", "Fitness"},
+	PrivacyDataTypePaymentInfo:          {"Financial Info", "Payment Info"},
+	PrivacyDataTypeCreditInfo:           {"Financial Info", "Credit Info"},
+	PrivacyDataTypeOtherFinancialInfo:   {"Financial Info", "Other Financial Info"},
+	PrivacyDataTypePreciseLocation:      {"Location", "Precise Location"},
+	PrivacyDataTypeCoarseLocation:       {"Location", "Coarse Location"},
+	PrivacyDataTypeSensitiveInfo:        {"Sensitive Info", "Sensitive Info"},
+	PrivacyDataTypeContacts:             {"Contacts", "Contacts"},
+	PrivacyDataTypeEmailsOrTextMessages: {"User Content", "Emails or Text Messages"},
+	PrivacyDataTypePhotosOrVideos:       {"User Content", "Photos or Videos"},
+	PrivacyDataTypeAudioData:            {"User Content", "Audio Data"},
+	PrivacyDataTypeGameplayContent:      {"User Content", "Gameplay Content"},
+	PrivacyDataTypeCustomerSupport:      {"User Content", "Customer Support"},
+	PrivacyDataTypeOtherUserContent:     {"User Content", "Other User Content"},
+	PrivacyDataTypeBrowsingHistory:      {"Browsing History", "Browsing History"},
+	PrivacyDataTypeSearchHistory:        {"Search History", "Search History"},
+	PrivacyDataTypeUserID:               {"Identifiers", "User ID"},
+	PrivacyDataTypeDeviceID:             {"Identifiers", "Device ID"},
+	PrivacyDataTypePurchaseHistory:      {"Purchases", "Purchase History"},
+	PrivacyDataTypeProductInteraction:   {"Usage Data", "Product Interaction"},
+	PrivacyDataTypeAdvertisingData:      {"Usage Data", "Advertising Data"},
+	PrivacyDataTypeOtherUsageData:       {"Usage Data", "Other Usage Data"},
+	PrivacyDataTypeCrashData:            {"Diagnostics", "Crash Data"},
+	PrivacyDataTypePerformanceData:      {"Diagnostics", "Performance Data"},
+	PrivacyDataTypeOtherDiagnosticData:  {"Diagnostics", "Other Diagnostic Data"},
+	PrivacyDataTypeOtherDataTypes:       {"Other Data", "Other Data Types"},
+}
+
+// privacyPurposeNames lists the purposes App Store Connect knows about.
+// nolint: gochecknoglobals
+var privacyPurposeNames = map[privacyPurpose]string{
+	PrivacyPurposeThirdPartyAdvertising:  "Third-Party Advertising",
+	PrivacyPurposeDeveloperAdvertising:   "Developer's Advertising or Marketing",
+	PrivacyPurposeAnalytics:              "Analytics",
+	PrivacyPurposeProductPersonalization: "Product Personalization",
+	PrivacyPurposeAppFunctionality:       "App Functionality",
+	PrivacyPurposeOther:                  "Other Purposes",
+}
+
+// Category returns the name of the category App Store Connect shows the data type under, or an empty string if
+// App Store Connect does not know about the data type.
+func (t privacyDataType) Category() string {
+	return privacyTaxonomy[t].category
+}
+
+// DisplayName returns the name App Store Connect shows for the data type.
+func (t privacyDataType) DisplayName() string {
+	if info, ok := privacyTaxonomy[t]; ok {
+		return info.name
+	}
+
+	return string(t)
+}
+
+// DisplayName returns the name App Store Connect shows for the purpose.
+func (p privacyPurpose) DisplayName() string {
+	if name, ok := privacyPurposeNames[p]; ok {
+		return name
+	}
+
+	return string(p)
+}
+
+// Collects returns true if the declaration includes data of type t.
+func (p Privacy) Collects(t privacyDataType) bool {
+	for _, dataType := range p.DataTypes {
+		if dataType.Type == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrProfileNotFound happens when the overlay file for a requested profile does not exist.
+type ErrProfileNotFound struct {
+	Profile string
+	Path    string
+}
+
+func (e ErrProfileNotFound) Error() string {
+	return fmt.Sprintf("overlay for profile %s not found at %s", e.Profile, e.Path)
+}
+
+// ProfilePath returns the path of the overlay file for the given profile. The profile
+// name is inserted before the extension of the base file, so `.cider.yml` with the
+// profile `staging` becomes `.cider.staging.yml`.
+func ProfilePath(file string, profile string) string {
+	ext := filepath.Ext(file)
+
+	return strings.TrimSuffix(file, ext) + "." + profile + ext
+}
+
+// LoadWithProfile loads the config file and patches the overlay file for the given profile
+// over it. Mappings in the overlay are merged recursively into the base file, while all
+// other values, including sequences, replace the value in the base file outright. If
+// profile is empty, this behaves identically to Load.
+func LoadWithProfile(file string, profile string) (config Project, err error) {
+	source, err := LoadSource(file, profile)
+	if err != nil {
+		return config, err
+	}
+
+	return source.Decode()
+}
+
+// mergeNodes patches overlay over base and returns the result. Both nodes may be modified.
+func mergeNodes(base *yaml.Node, overlay *yaml.Node) *yaml.Node {
+	if overlay == nil {
+		return base
+	}
+
+	if base == nil || base.Kind != yaml.MappingNode || overlay.Kind != yaml.MappingNode {
+		return overlay
+	}
+
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+
+		if j := mappingIndex(base, key.Value); j >= 0 {
+			base.Content[j+1] = mergeNodes(base.Content[j+1], value)
+		} else {
+			base.Content = append(base.Content, key, value)
+		}
+	}
+
+	return base
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
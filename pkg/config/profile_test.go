@@ -0,0 +1,87 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfilePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ".cider.staging.yml", ProfilePath(".cider.yml", "staging"))
+	assert.Equal(t, "configs/foo.prod.yaml", ProfilePath("configs/foo.yaml", "prod"))
+	assert.Equal(t, "cider.staging", ProfilePath("cider", "staging"))
+}
+
+func TestLoadWithProfile(t *testing.T) {
+	t.Parallel()
+
+	f, err := LoadWithProfile("testdata/valid.yml", "staging")
+	assert.NoError(t, err)
+
+	app := f["Wayfair"]
+	assert.Equal(t, "com.sky.ProjectApp.beta", app.BundleID)
+	assert.Equal(t, "My App Beta", app.Localizations["en-US"].Name)
+	assert.Equal(t, "congratulations", app.Localizations["en-US"].Subtitle)
+	assert.Equal(t, "僕のアップ", app.Localizations["ja"].Name)
+	assert.Equal(t, []BetaGroup{{Name: "Staging"}}, app.Testflight.BetaGroups)
+	assert.Len(t, app.Testflight.BetaTesters, 2)
+}
+
+func TestLoadWithProfile_NoProfile(t *testing.T) {
+	t.Parallel()
+
+	expected, err := Load("testdata/valid.yml")
+	assert.NoError(t, err)
+	f, err := LoadWithProfile("testdata/valid.yml", "")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, f)
+}
+
+func TestLoadWithProfile_EmptyOverlay(t *testing.T) {
+	t.Parallel()
+
+	expected, err := Load("testdata/valid.yml")
+	assert.NoError(t, err)
+	f, err := LoadWithProfile("testdata/valid.yml", "empty")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, f)
+}
+
+func TestLoadWithProfile_Err(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadWithProfile("testdata/doesnotexist.yml", "staging")
+	assert.Error(t, err)
+
+	_, err = LoadWithProfile("testdata/valid.yml", "doesnotexist")
+	assert.ErrorAs(t, err, &ErrProfileNotFound{})
+	assert.EqualError(t, err, "overlay for profile doesnotexist not found at testdata/valid.doesnotexist.yml")
+
+	_, err = LoadWithProfile("testdata/valid.yml", "broken")
+	assert.Error(t, err)
+
+	_, err = LoadWithProfile("testdata/valid.yml", "invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,302 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	// Embeds the configuration schema generated by tools/gendoc.
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the JSON Schema describing the configuration file, generated from the types in this package.
+//
+//go:embed schema.json
+var Schema []byte // nolint: gochecknoglobals
+
+const schemaRefPrefix = "#/$defs/"
+
+// schemaNode is the subset of JSON Schema used by the configuration schema.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Enum                 []string               `json:"enum"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	PropertyNames        *schemaNode            `json:"propertyNames"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *schemaNode            `json:"items"`
+	MinItems             int                    `json:"minItems"`
+	MaxItems             int                    `json:"maxItems"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+}
+
+type schemaValidator struct {
+	source *Source
+	root   *schemaNode
+	// strict enables the checks that go beyond what is needed to decode the document, such as
+	// required fields, enumerations and the types of scalar values.
+	strict bool
+	errors []ValidationError
+}
+
+// Validate checks the YAML document in data against the configuration schema. All violations
+// are returned together, in the order they appear in the document.
+func Validate(data []byte) error {
+	source, err := ParseSource("", data)
+	if err != nil {
+		return err
+	}
+
+	return source.Validate()
+}
+
+// ValidateFile checks the config file against the configuration schema, with the overlay for the
+// given profile patched over it if profile is not empty.
+func ValidateFile(file string, profile string) error {
+	source, err := LoadSource(file, profile)
+	if err != nil {
+		return err
+	}
+
+	return source.Validate()
+}
+
+func (s *Source) validate(strict bool) error {
+	if s.root == nil {
+		return nil
+	}
+
+	var root schemaNode
+	if err := json.Unmarshal(Schema, &root); err != nil {
+		return err
+	}
+
+	v := schemaValidator{source: s, root: &root, strict: strict}
+	v.validate(s.root, &root, "")
+
+	sortValidationErrors(v.errors)
+
+	var result *multierror.Error
+	for _, err := range v.errors {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (v *schemaValidator) fail(node *yaml.Node, path string, format string, args ...interface{}) {
+	v.errors = append(v.errors, v.source.errorAt(node, path, fmt.Sprintf(format, args...)))
+}
+
+func (v *schemaValidator) validate(node *yaml.Node, schema *schemaNode, path string) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if schema.Ref != "" {
+		if def, ok := v.root.Defs[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]; ok {
+			v.validate(node, def, path)
+		}
+	}
+
+	if schema.Type != "" {
+		if actual := nodeType(node); !v.typeMatches(schema.Type, actual) {
+			v.fail(node, path, "expected %s, found %s", schema.Type, actual)
+
+			return
+		}
+	}
+
+	if !v.strict {
+		switch node.Kind {
+		case yaml.MappingNode:
+			v.validateMapping(node, schema, path)
+		case yaml.SequenceNode:
+			v.validateSequence(node, schema, path)
+		}
+
+		return
+	}
+
+	if len(schema.Enum) > 0 && !contains(schema.Enum, node.Value) {
+		v.fail(node, path, "invalid value %q, expected one of: %s", node.Value, strings.Join(schema.Enum, ", "))
+	}
+
+	if len(schema.AnyOf) > 0 {
+		v.validateAnyOf(node, schema, path)
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(node, schema, path)
+	case yaml.SequenceNode:
+		v.validateSequence(node, schema, path)
+	}
+}
+
+func (v *schemaValidator) validateAnyOf(node *yaml.Node, schema *schemaNode, path string) {
+	var branchErrors []ValidationError
+
+	for _, branch := range schema.AnyOf {
+		sub := schemaValidator{source: v.source, root: v.root, strict: v.strict}
+		sub.validate(node, branch, path)
+
+		if len(sub.errors) == 0 {
+			return
+		}
+
+		if branchErrors == nil {
+			branchErrors = sub.errors
+		}
+	}
+
+	v.errors = append(v.errors, branchErrors...)
+}
+
+func (v *schemaValidator) validateMapping(node *yaml.Node, schema *schemaNode, path string) {
+	var additional *schemaNode
+
+	var allowAdditional = true
+
+	if len(schema.AdditionalProperties) > 0 {
+		if err := json.Unmarshal(schema.AdditionalProperties, &allowAdditional); err != nil {
+			additional = new(schemaNode)
+			allowAdditional = json.Unmarshal(schema.AdditionalProperties, additional) == nil
+		}
+	}
+
+	seen := make(map[string]bool, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := joinPath(path, key.Value)
+
+		if seen[key.Value] {
+			v.fail(key, path, "duplicate field %q", key.Value)
+		}
+
+		seen[key.Value] = true
+
+		if schema.PropertyNames != nil {
+			v.validate(key, schema.PropertyNames, keyPath)
+		}
+
+		if property, ok := schema.Properties[key.Value]; ok {
+			v.validate(value, property, keyPath)
+		} else if additional != nil {
+			v.validate(value, additional, keyPath)
+		} else if !allowAdditional {
+			v.fail(key, path, "unknown field %q", key.Value)
+		}
+	}
+
+	if !v.strict {
+		return
+	}
+
+	for _, field := range schema.Required {
+		if !seen[field] {
+			v.fail(node, path, "missing required field %q", field)
+		}
+	}
+}
+
+func (v *schemaValidator) validateSequence(node *yaml.Node, schema *schemaNode, path string) {
+	if schema.MinItems > 0 && schema.MinItems == schema.MaxItems && len(node.Content) != schema.MinItems {
+		v.fail(node, path, "expected exactly %d items, found %d", schema.MinItems, len(node.Content))
+	} else if schema.MinItems > 0 && len(node.Content) < schema.MinItems {
+		v.fail(node, path, "expected at least %d items, found %d", schema.MinItems, len(node.Content))
+	} else if schema.MaxItems > 0 && len(node.Content) > schema.MaxItems {
+		v.fail(node, path, "expected at most %d items, found %d", schema.MaxItems, len(node.Content))
+	}
+
+	if schema.Items == nil {
+		return
+	}
+
+	for i, item := range node.Content {
+		v.validate(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	default:
+		// Strings, timestamps and binary values are all represented as strings in JSON.
+		return "string"
+	}
+}
+
+func (v *schemaValidator) typeMatches(expected string, actual string) bool {
+	if expected == actual || (expected == "number" && actual == "integer") {
+		return true
+	}
+
+	if v.strict {
+		return false
+	}
+
+	// The decoder is lenient about scalars, so only the shape of the value matters.
+	isScalar := func(t string) bool {
+		return t != "object" && t != "array"
+	}
+
+	return actual == "null" || (isScalar(expected) && isScalar(actual))
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaIsUpToDate(t *testing.T) {
+	t.Parallel()
+
+	published, err := os.ReadFile("../../docs/schema.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(published), string(Schema), "run `go run ./tools/gendoc schema` to update the schema")
+}
+
+func TestValidate_Valid(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("testdata/valid.yml")
+	assert.NoError(t, err)
+	assert.NoError(t, Validate(data))
+	assert.NoError(t, Validate([]byte("")))
+	assert.NoError(t, ValidateFile("testdata/valid.yml", "staging"))
+}
+
+func TestValidate_Marshalled(t *testing.T) {
+	t.Parallel()
+
+	f, err := Load("testdata/valid.yml")
+	assert.NoError(t, err)
+	str, err := f.String()
+	assert.NoError(t, err)
+	assert.NoError(t, Validate([]byte(str)))
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`My App:
+  id: com.app
+  primaryLocale: en-XX
+  localizations:
+    en-US:
+      name: My App
+      tagline: Oops
+  versions:
+    platform: windows
+    localizations: {}
+  testflight:
+    enableAutoNotify: 'yes'
+    licenseAgreement: ''
+    localizations: {}
+    betaGroups:
+      - publicLinkLimit: many
+`)
+
+	err := Validate(data)
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+
+	var messages = make([]string, len(merr.Errors))
+	for i, e := range merr.Errors {
+		messages[i] = e.Error()
+	}
+
+	assert.Equal(t, []string{
+		`3:18: My App.primaryLocale: invalid value "en-XX", expected one of: ar-SA, ca, cs, da, de-DE, el, en-AU, en-CA, en-GB, en-US, es-ES, es-MX, fi, fr-CA, fr-FR, he, hi, hr, hu, id, it, ja, ko, ms, nl-NL, no, pl, pt-BR, pt-PT, ro, ru, sk, sv, th, tr, uk, vi, zh-Hans, zh-Hant`,
+		`7:7: My App.localizations.en-US: unknown field "tagline"`,
+		`9:15: My App.versions.platform: invalid value "windows", expected one of: iOS, macOS, tvOS, visionOS`,
+		`12:23: My App.testflight.enableAutoNotify: expected boolean, found string`,
+		`16:9: My App.testflight.betaGroups[0]: missing required field "group"`,
+		`16:26: My App.testflight.betaGroups[0].publicLinkLimit: expected integer, found string`,
+	}, messages)
+}
+
+func TestValidate_Err(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, Validate([]byte("id: [")))
+	assert.Error(t, ValidateFile("testdata/doesnotexist.yml", ""))
+	assert.ErrorAs(t, ValidateFile("testdata/valid.yml", "doesnotexist"), &ErrProfileNotFound{})
+
+	var verr ValidationError
+	assert.ErrorAs(t, ValidateFile("testdata/valid.yml", "invalid"), &verr)
+	assert.Equal(t, ValidationError{
+		File:    "testdata/valid.invalid.yml",
+		Line:    3,
+		Column:  3,
+		Path:    "Wayfair",
+		Message: `unknown field "notAField"`,
+		Snippet: "  notAField: true",
+	}, verr)
+}
@@ -0,0 +1,473 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// nolint: gochecknoglobals
+var yamlLineErrorPattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// ValidationError describes a problem with a value in the configuration file, along
+// with where it can be found.
+type ValidationError struct {
+	// File the offending value was read from, if known.
+	File string
+	// Line and column of the offending value, starting at 1. A column of 0 means the column is unknown.
+	Line   int
+	Column int
+	// Path to the offending value, such as `My App.versions.platform`.
+	Path    string
+	Message string
+	// Snippet is the text of the offending line.
+	Snippet string
+}
+
+func (e ValidationError) Error() string {
+	var parts = make([]string, 0, 4)
+
+	if position := e.position(); position != "" {
+		parts = append(parts, position)
+	}
+
+	if e.Path != "" {
+		parts = append(parts, e.Path)
+	}
+
+	return strings.Join(append(parts, e.Message), ": ")
+}
+
+func (e ValidationError) position() string {
+	var position = e.File
+
+	if e.Line > 0 {
+		if position != "" {
+			position += ":"
+		}
+
+		position += strconv.Itoa(e.Line)
+
+		if e.Column > 0 {
+			position += ":" + strconv.Itoa(e.Column)
+		}
+	}
+
+	return position
+}
+
+// Excerpt renders the offending line with a marker under the offending column, suitable for
+// displaying beneath the error. It is empty if the line is unknown.
+func (e ValidationError) Excerpt() string {
+	if e.Snippet == "" {
+		return ""
+	}
+
+	gutter := strconv.Itoa(e.Line)
+	excerpt := fmt.Sprintf("%s | %s", gutter, e.Snippet)
+
+	if e.Column > 0 {
+		excerpt += fmt.Sprintf("\n%s | %s^", strings.Repeat(" ", len(gutter)), strings.Repeat(" ", e.Column-1))
+	}
+
+	return excerpt
+}
+
+// Source is a configuration document parsed from one or more files. It retains the position
+// of every value so that problems can be reported against the file, line and column they
+// originate from.
+type Source struct {
+	root         *yaml.Node
+	files        map[*yaml.Node]string
+	lines        map[string][]string
+	deprecations []ValidationError
+}
+
+func newSource() *Source {
+	return &Source{
+		files: make(map[*yaml.Node]string),
+		lines: make(map[string][]string),
+	}
+}
+
+// LoadSource parses the config file, with the overlay for the given profile patched over it
+// if profile is not empty.
+func LoadSource(file string, profile string) (*Source, error) {
+	s := newSource()
+
+	base, err := s.parseFile(file)
+	if err != nil || profile == "" {
+		s.root = base
+
+		return s, err
+	}
+
+	overlayPath := ProfilePath(file, profile)
+
+	overlay, err := s.parseFile(overlayPath)
+	if os.IsNotExist(err) {
+		return nil, ErrProfileNotFound{Profile: profile, Path: overlayPath}
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.root = mergeNodes(base, overlay)
+
+	return s, nil
+}
+
+// ParseSource parses a configuration document from data. The file name is only used for
+// reporting errors, and can be empty.
+func ParseSource(file string, data []byte) (*Source, error) {
+	s := newSource()
+
+	root, err := s.parse(file, data)
+	s.root = root
+
+	return s, err
+}
+
+func (s *Source) parseFile(file string) (*yaml.Node, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parse(file, data)
+}
+
+func (s *Source) parse(file string, data []byte) (*yaml.Node, error) {
+	doc, err := s.parseDocument(file, data, formatOf(file, data))
+	if err != nil {
+		return nil, err
+	}
+
+	root := documentContent(doc)
+
+	if _, err := s.migrate(file, root); err != nil {
+		return nil, err
+	}
+
+	// The schema version is not part of the project itself.
+	if root != nil {
+		if i := mappingIndex(root, SchemaVersionKey); i >= 0 {
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		}
+	}
+
+	return root, nil
+}
+
+// parseDocument parses data into a document node, recording the file each node was read from.
+func (s *Source) parseDocument(file string, data []byte, format Format) (*yaml.Node, error) {
+	s.lines[file] = strings.Split(string(data), "\n")
+
+	var doc *yaml.Node
+
+	var err error
+
+	if format == FormatTOML {
+		doc, err = tomlToNode(data)
+	} else {
+		// JSON is a subset of YAML, so both are parsed the same way.
+		doc, err = parseYAML(data)
+	}
+
+	if err != nil {
+		return nil, s.syntaxError(file, err)
+	}
+
+	walkNodes(doc, "", func(node *yaml.Node, path string) bool {
+		s.files[node] = file
+
+		return false
+	})
+
+	return doc, nil
+}
+
+// migrate upgrades the document to the current schema version, recording the deprecated keys it replaces.
+func (s *Source) migrate(file string, root *yaml.Node) (int, error) {
+	version, err := migrateNode(root, func(node *yaml.Node, path string, message string) {
+		s.deprecations = append(s.deprecations, s.errorAt(node, path, message))
+	})
+
+	var verr ValidationError
+	if errors.As(err, &verr) {
+		verr.File = file
+		verr.Snippet = s.line(file, verr.Line)
+
+		return version, verr
+	}
+
+	return version, err
+}
+
+// Deprecations returns the keys from earlier versions of the configuration schema that were
+// migrated while loading the document.
+func (s *Source) Deprecations() []ValidationError {
+	sortValidationErrors(s.deprecations)
+
+	return s.deprecations
+}
+
+// sortValidationErrors orders errs by their position in the configuration files.
+func sortValidationErrors(errs []ValidationError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		} else if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+}
+
+func parseYAML(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+func formatOf(file string, data []byte) Format {
+	if format := FormatForPath(file); format != "" {
+		return format
+	}
+
+	return DetectFormat(data)
+}
+
+// Decode decodes the document into a Project. The structure of the document is checked first, so
+// that unknown fields and values of the wrong shape are reported with their positions.
+func (s *Source) Decode() (config Project, err error) {
+	if s.root == nil {
+		return config, nil
+	}
+
+	if err := s.validate(false); err != nil {
+		return config, err
+	}
+
+	if err := s.root.Decode(&config); err != nil {
+		return config, s.decodeError(err)
+	}
+
+	return config, nil
+}
+
+// Validate checks the document against the configuration schema. All violations are returned
+// together, in the order they appear in the document.
+func (s *Source) Validate() error {
+	return s.validate(true)
+}
+
+// Locate returns the position of the value at path in the document, such as `My App.versions.copyright`.
+func (s *Source) Locate(path string) (ValidationError, bool) {
+	var found ValidationError
+
+	var ok bool
+
+	walkNodes(s.root, "", func(node *yaml.Node, nodePath string) bool {
+		if nodePath != path || node.Kind == yaml.DocumentNode {
+			return false
+		}
+
+		found, ok = s.errorAt(node, nodePath, ""), true
+
+		return true
+	})
+
+	return found, ok
+}
+
+// WrapError attributes err to the position of the value at path it was produced from, such as a templated
+// field. If the path cannot be found in the document, err is returned unchanged.
+func (s *Source) WrapError(path string, err error) error {
+	if s == nil || err == nil {
+		return err
+	}
+
+	verr, ok := s.Locate(path)
+	if !ok {
+		return err
+	}
+
+	verr.Message = err.Error()
+
+	return verr
+}
+
+func (s *Source) errorAt(node *yaml.Node, path string, message string) ValidationError {
+	file := s.files[node]
+
+	return ValidationError{
+		File:    file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: message,
+		Snippet: s.line(file, node.Line),
+	}
+}
+
+func (s *Source) line(file string, line int) string {
+	lines := s.lines[file]
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	return strings.TrimRight(lines[line-1], "\r")
+}
+
+// syntaxError converts errors from the parser, which only carry a line number.
+func (s *Source) syntaxError(file string, err error) error {
+	var verr ValidationError
+	if errors.As(err, &verr) {
+		verr.File = file
+		verr.Snippet = s.line(file, verr.Line)
+
+		return verr
+	}
+
+	match := yamlLineErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	return ValidationError{
+		File:    file,
+		Line:    line,
+		Message: match[2],
+		Snippet: s.line(file, line),
+	}
+}
+
+// decodeError converts errors from decoding the document, which only carry a line number, by
+// finding the value on that line.
+func (s *Source) decodeError(err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	var result *multierror.Error
+
+	for _, message := range typeErr.Errors {
+		match := yamlLineErrorPattern.FindStringSubmatch(message)
+		if match == nil {
+			result = multierror.Append(result, errors.New(message)) // nolint: goerr113
+
+			continue
+		}
+
+		line, _ := strconv.Atoi(match[1])
+
+		var verr = ValidationError{Line: line, Message: match[2]}
+
+		walkNodes(s.root, "", func(node *yaml.Node, path string) bool {
+			if node.Line != line || node.Kind != yaml.ScalarNode {
+				return false
+			}
+
+			verr = s.errorAt(node, path, match[2])
+
+			return false
+		})
+
+		result = multierror.Append(result, verr)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// walkNodes visits node and its descendants depth-first, along with their paths, until fn returns true.
+func walkNodes(node *yaml.Node, path string, fn func(node *yaml.Node, path string) bool) bool {
+	if node == nil {
+		return false
+	}
+
+	if fn(node, path) {
+		return true
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if walkNodes(child, path, fn) {
+				return true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if walkNodes(key, path, fn) || walkNodes(value, joinPath(path, key.Value), fn) {
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if walkNodes(item, fmt.Sprintf("%s[%d]", path, i), fn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+
+	if node.Kind == 0 {
+		return nil
+	}
+
+	return node
+}
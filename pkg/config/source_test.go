@@ -0,0 +1,159 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+
+	err := ValidationError{
+		File:    ".cider.yml",
+		Line:    12,
+		Column:  5,
+		Path:    "My App.versions.platform",
+		Message: "oops",
+		Snippet: "    platform: windows",
+	}
+	assert.EqualError(t, err, ".cider.yml:12:5: My App.versions.platform: oops")
+	assert.Equal(t, "12 |     platform: windows\n   |     ^", err.Excerpt())
+
+	err = ValidationError{Line: 3, Message: "oops"}
+	assert.EqualError(t, err, "3: oops")
+	assert.Empty(t, err.Excerpt())
+}
+
+func TestLoadSource_SyntaxError(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadWithProfile("testdata/valid.yml", "broken")
+
+	var verr ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, "testdata/valid.broken.yml", verr.File)
+	assert.Equal(t, 3, verr.Line)
+	assert.Equal(t, "  id: [", verr.Snippet)
+}
+
+func TestSource_Decode(t *testing.T) {
+	t.Parallel()
+
+	source, err := ParseSource("cider.yml", []byte(`My App:
+  id: com.app
+  primaryLocale: en-US
+  notAField: true
+  categories:
+    - GAMES
+  testflight:
+    betaGroups:
+      - group: Friends
+        publicLinkLimit: many
+`))
+	assert.NoError(t, err)
+
+	_, err = source.Decode()
+
+	var merr *multierror.Error
+	assert.True(t, errors.As(err, &merr))
+	assert.Len(t, merr.Errors, 2)
+	assert.EqualError(t, merr.Errors[0], `cider.yml:4:3: My App: unknown field "notAField"`)
+	assert.EqualError(t, merr.Errors[1], `cider.yml:6:5: My App.categories: expected object, found array`)
+
+	source, err = ParseSource("cider.yml", []byte(`My App:
+  id: com.app
+  testflight:
+    betaGroups:
+      - group: Friends
+        publicLinkLimit: many
+`))
+	assert.NoError(t, err)
+
+	_, err = source.Decode()
+
+	var verr ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, "cider.yml", verr.File)
+	assert.Equal(t, 6, verr.Line)
+	assert.Equal(t, 26, verr.Column)
+	assert.Equal(t, "My App.testflight.betaGroups[0].publicLinkLimit", verr.Path)
+	assert.Contains(t, verr.Message, "cannot unmarshal !!str `many` into int")
+}
+
+func TestSource_Decode_Lenient(t *testing.T) {
+	t.Parallel()
+
+	source, err := ParseSource("", []byte(`My App:
+  id: com.app
+  availability:
+    priceTiers:
+      - tier: 0
+  versions:
+    platform: iOS
+    enablePhasedRelease: yes
+`))
+	assert.NoError(t, err)
+
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+	assert.Equal(t, "0", proj["My App"].Availability.Pricing[0].Tier)
+	assert.True(t, proj["My App"].Versions.PhasedReleaseEnabled)
+}
+
+func TestSource_Empty(t *testing.T) {
+	t.Parallel()
+
+	source, err := ParseSource("", nil)
+	assert.NoError(t, err)
+
+	proj, err := source.Decode()
+	assert.NoError(t, err)
+	assert.Empty(t, proj)
+	assert.NoError(t, source.Validate())
+}
+
+func TestSource_WrapError(t *testing.T) {
+	t.Parallel()
+
+	source, err := LoadSource("testdata/valid.yml", "staging")
+	assert.NoError(t, err)
+
+	err = source.WrapError("Wayfair.localizations.en-US.name", errTestError)
+	assert.EqualError(t, err, "testdata/valid.staging.yml:6:13: Wayfair.localizations.en-US.name: test error")
+
+	err = source.WrapError("Wayfair.localizations.en-US.subtitle", errTestError)
+	assert.EqualError(t, err, "testdata/valid.yml:7:17: Wayfair.localizations.en-US.subtitle: test error")
+
+	err = source.WrapError("Wayfair.localizations.ja.name", errTestError)
+	assert.EqualError(t, err, "testdata/valid.yml:11:13: Wayfair.localizations.ja.name: test error")
+
+	err = source.WrapError("Wayfair.localizations.fr-FR.name", errTestError)
+	assert.Equal(t, errTestError, err)
+
+	var nilSource *Source
+	assert.Equal(t, errTestError, nilSource.WrapError("Wayfair.localizations.en-US.name", errTestError))
+	assert.NoError(t, source.WrapError("Wayfair.localizations.en-US.name", nil))
+}
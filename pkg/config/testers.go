@@ -0,0 +1,286 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// testersGroupSeparator separates the names of beta groups in the groups column of a roster.
+const testersGroupSeparator = ";"
+
+// TestersColumns are the columns of a beta tester roster, in the order they are read when the roster has no header.
+// nolint: gochecknoglobals
+var TestersColumns = []string{"email", "firstName", "lastName", "groups"}
+
+// TesterRecord is a beta tester in a roster, along with the names of the beta groups they belong to.
+type TesterRecord struct {
+	BetaTester
+	Groups []string
+}
+
+// InGroup returns true if the record belongs to the named beta group. Records that do not name any groups
+// belong to no group.
+func (r TesterRecord) InGroup(name string) bool {
+	for _, group := range r.Groups {
+		if group == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadTesters reads a roster of beta testers in CSV format, one tester per record. Each record has an email, a first
+// name, a last name, and the names of the beta groups the tester belongs to, separated by semicolons. If the first
+// record names the columns, they can appear in any order and unrecognized columns are ignored. Blank lines and lines
+// beginning with # are skipped.
+func ReadTesters(r io.Reader) ([]TesterRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns = make(map[string]int, len(TestersColumns))
+
+	for i, name := range TestersColumns {
+		columns[strings.ToLower(name)] = i
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	lines := csvRecordLines(string(data))
+
+	var records []TesterRecord
+
+	first := true
+
+	for i := 0; ; i++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				return nil, ValidationError{Line: perr.Line, Column: perr.Column, Message: perr.Err.Error()}
+			}
+
+			return nil, err
+		}
+
+		if isBlankRow(row) {
+			continue
+		}
+
+		if first {
+			first = false
+
+			if isTestersHeader(row) {
+				columns = make(map[string]int, len(row))
+				for i, name := range row {
+					columns[strings.ToLower(strings.TrimSpace(name))] = i
+				}
+
+				continue
+			}
+		}
+
+		cell := func(name string) string {
+			if i, ok := columns[strings.ToLower(name)]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+
+			return ""
+		}
+
+		record := TesterRecord{
+			BetaTester: BetaTester{
+				Email:     cell("email"),
+				FirstName: cell("firstName"),
+				LastName:  cell("lastName"),
+			},
+		}
+
+		if record.Email == "" {
+			var line int
+			if i < len(lines) {
+				line = lines[i]
+			}
+
+			return nil, ValidationError{Line: line, Message: "missing email"}
+		}
+
+		for _, group := range strings.Split(cell("groups"), testersGroupSeparator) {
+			if group = strings.TrimSpace(group); group != "" {
+				record.Groups = append(record.Groups, group)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// csvRecordLines returns the line each record of a CSV file starts on, skipping blank lines and comments as
+// csv.Reader does. Newlines within a quoted field are preceded by an odd number of quotes, since quotes within
+// a quoted field are escaped by doubling them.
+func csvRecordLines(data string) []int {
+	var lines []int
+
+	var quoted bool
+
+	for i, text := range strings.Split(data, "\n") {
+		if !quoted {
+			if text = strings.TrimSuffix(text, "\r"); text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+
+			lines = append(lines, i+1)
+		}
+
+		if strings.Count(text, `"`)%2 == 1 {
+			quoted = !quoted
+		}
+	}
+
+	return lines
+}
+
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isTestersHeader(row []string) bool {
+	for _, cell := range row {
+		if strings.EqualFold(strings.TrimSpace(cell), "email") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadTesters reads a roster of beta testers from a CSV file. See ReadTesters for the format.
+func LoadTesters(file string) ([]TesterRecord, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := ReadTesters(bytes.NewReader(data))
+
+	var verr ValidationError
+	if errors.As(err, &verr) {
+		verr.File = file
+
+		return nil, verr
+	}
+
+	return records, err
+}
+
+// WriteTesters writes a roster of beta testers in CSV format, with a header row. The records are sorted by email.
+func WriteTesters(w io.Writer, records []TesterRecord) error {
+	sorted := make([]TesterRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Email) < strings.ToLower(sorted[j].Email)
+	})
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(TestersColumns); err != nil {
+		return err
+	}
+
+	for _, record := range sorted {
+		if err := writer.Write([]string{
+			record.Email,
+			record.FirstName,
+			record.LastName,
+			strings.Join(record.Groups, testersGroupSeparator),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// ResolveTesters returns the testers listed in the beta group, merged with the testers in its testersFile that
+// belong to the group. A relative testersFile is resolved against dir, the directory of the project. Testers listed
+// in the group take precedence over testers in the file with the same email.
+func (g BetaGroup) ResolveTesters(dir string) ([]BetaTester, error) {
+	if g.TestersFile == "" {
+		return g.Testers, nil
+	}
+
+	file := g.TestersFile
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+
+	records, err := LoadTesters(file)
+	if err != nil {
+		return nil, err
+	}
+
+	testers := make([]BetaTester, 0, len(g.Testers)+len(records))
+	testers = append(testers, g.Testers...)
+
+	var found = make(map[string]bool, len(testers))
+
+	for _, tester := range testers {
+		found[strings.ToLower(tester.Email)] = true
+	}
+
+	for _, record := range records {
+		email := strings.ToLower(record.Email)
+		if found[email] || !record.InGroup(g.Name) {
+			continue
+		}
+
+		found[email] = true
+
+		testers = append(testers, record.BetaTester)
+	}
+
+	return testers, nil
+}
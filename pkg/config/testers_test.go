@@ -0,0 +1,139 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTesters(t *testing.T) {
+	t.Parallel()
+
+	records, err := ReadTesters(strings.NewReader(`groups, Email ,lastName
+"QA;Staging",qa@example.com,Lovelace
+,everyone@example.com,
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []TesterRecord{
+		{BetaTester: BetaTester{Email: "qa@example.com", LastName: "Lovelace"}, Groups: []string{"QA", "Staging"}},
+		{BetaTester: BetaTester{Email: "everyone@example.com"}},
+	}, records)
+}
+
+func TestReadTesters_MultilineFields(t *testing.T) {
+	t.Parallel()
+
+	records, err := ReadTesters(strings.NewReader("# Roster\r\nemail,lastName,groups\r\n\r\n" +
+		"qa@example.com,\"Lovelace,\r\nCountess\",QA\r\n# \"Unbalanced\r\nother@example.com,,\r\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []TesterRecord{
+		{BetaTester: BetaTester{Email: "qa@example.com", LastName: "Lovelace,\nCountess"}, Groups: []string{"QA"}},
+		{BetaTester: BetaTester{Email: "other@example.com"}},
+	}, records)
+
+	_, err = ReadTesters(strings.NewReader("email,lastName\nqa@example.com,\"Lovelace\nCountess\"\n,\"Hopper\"\"s\"\n"))
+	assert.EqualError(t, err, "4: missing email")
+}
+
+func TestReadTesters_NoHeader(t *testing.T) {
+	t.Parallel()
+
+	records, err := ReadTesters(strings.NewReader("qa@example.com,Ada,Lovelace,QA\nother@example.com\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []TesterRecord{
+		{BetaTester: BetaTester{Email: "qa@example.com", FirstName: "Ada", LastName: "Lovelace"}, Groups: []string{"QA"}},
+		{BetaTester: BetaTester{Email: "other@example.com"}},
+	}, records)
+}
+
+func TestReadTesters_Err(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadTesters(strings.NewReader("email,firstName\n\n,Ada\n"))
+	assert.EqualError(t, err, "3: missing email")
+
+	_, err = ReadTesters(strings.NewReader("email,firstName\nqa@example.com,\"Ada\n"))
+	assert.Error(t, err)
+
+	var verr ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, 2, verr.Line)
+
+	_, err = LoadTesters("testdata/doesnotexist.csv")
+	assert.Error(t, err)
+}
+
+func TestWriteTesters(t *testing.T) {
+	t.Parallel()
+
+	records := []TesterRecord{
+		{BetaTester: BetaTester{Email: "z@example.com"}},
+		{BetaTester: BetaTester{Email: "a@example.com", FirstName: "Ada", LastName: "Lovelace, Countess"}, Groups: []string{"QA", "Staging"}},
+	}
+
+	var buf bytes.Buffer
+
+	err := WriteTesters(&buf, records)
+	assert.NoError(t, err)
+	assert.Equal(t, `email,firstName,lastName,groups
+a@example.com,Ada,"Lovelace, Countess",QA;Staging
+z@example.com,,,
+`, buf.String())
+
+	read, err := ReadTesters(&buf)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, records, read)
+}
+
+func TestResolveTesters(t *testing.T) {
+	t.Parallel()
+
+	group := BetaGroup{
+		Name:        "QA",
+		Testers:     []BetaTester{{Email: "QA1@example.com", FirstName: "Inline"}},
+		TestersFile: "testdata/testers.csv",
+	}
+
+	testers, err := group.ResolveTesters("")
+	assert.NoError(t, err)
+	assert.Equal(t, []BetaTester{
+		{Email: "QA1@example.com", FirstName: "Inline"},
+		{Email: "qa2@example.com", FirstName: "Grace", LastName: "Hopper"},
+	}, testers)
+
+	group.TestersFile = "testers.csv"
+	testers, err = group.ResolveTesters("testdata")
+	assert.NoError(t, err)
+	assert.Len(t, testers, 2)
+
+	group = BetaGroup{Name: "QA", Testers: []BetaTester{{Email: "qa@example.com"}}}
+	testers, err = group.ResolveTesters("")
+	assert.NoError(t, err)
+	assert.Equal(t, group.Testers, testers)
+
+	group.TestersFile = "testdata/doesnotexist.csv"
+	_, err = group.ResolveTesters("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import "sort"
+
+// PlatformVersions returns the version to release on each platform, sorted by platform. A version that does not
+// list platforms is only released on its own platform.
+func (v Version) PlatformVersions() []Version {
+	if len(v.Platforms) == 0 {
+		return []Version{v}
+	}
+
+	platforms := make([]string, 0, len(v.Platforms))
+	for platform := range v.Platforms {
+		platforms = append(platforms, string(platform))
+	}
+
+	sort.Strings(platforms)
+
+	versions := make([]Version, len(platforms))
+	for i, platform := range platforms {
+		versions[i] = v.forPlatform(Platform(platform))
+	}
+
+	return versions
+}
+
+func (v Version) forPlatform(platform Platform) Version {
+	override := v.Platforms[platform]
+
+	version := v
+	version.Platform = platform
+	version.Platforms = nil
+
+	version.Localizations = make(VersionLocalizations, len(v.Localizations))
+	for locale, loc := range v.Localizations {
+		version.Localizations[locale] = loc
+	}
+
+	for locale, loc := range override.Localizations {
+		version.Localizations[locale] = version.Localizations[locale].merge(loc)
+	}
+
+	if override.Copyright != "" {
+		version.Copyright = override.Copyright
+	}
+
+	if override.EarliestReleaseDate != nil {
+		version.EarliestReleaseDate = override.EarliestReleaseDate
+	}
+
+	if override.ReleaseType != "" {
+		version.ReleaseType = override.ReleaseType
+	}
+
+	if override.PhasedReleaseEnabled != nil {
+		version.PhasedReleaseEnabled = *override.PhasedReleaseEnabled
+	}
+
+	if override.IDFADeclaration != nil {
+		version.IDFADeclaration = override.IDFADeclaration
+	}
+
+	if override.RoutingCoverage != nil {
+		version.RoutingCoverage = override.RoutingCoverage
+	}
+
+	if override.ReviewDetails != nil {
+		version.ReviewDetails = override.ReviewDetails
+	}
+
+	return version
+}
+
+// merge returns the localization with the fields set by override replaced, and the previews, screenshots and
+// screenshot fallbacks of the types override sets replaced.
+func (l VersionLocalization) merge(override VersionLocalization) VersionLocalization {
+	for _, field := range []struct {
+		value    *string
+		override string
+	}{
+		{&l.Description, override.Description},
+		{&l.Keywords, override.Keywords},
+		{&l.MarketingURL, override.MarketingURL},
+		{&l.PromotionalText, override.PromotionalText},
+		{&l.SupportURL, override.SupportURL},
+		{&l.WhatsNewText, override.WhatsNewText},
+	} {
+		if field.override != "" {
+			*field.value = field.override
+		}
+	}
+
+	previewSets := make(PreviewSets, len(l.PreviewSets)+len(override.PreviewSets))
+	for previewType, previews := range l.PreviewSets {
+		previewSets[previewType] = previews
+	}
+
+	for previewType, previews := range override.PreviewSets {
+		previewSets[previewType] = previews
+	}
+
+	screenshotSets := make(ScreenshotSets, len(l.ScreenshotSets)+len(override.ScreenshotSets))
+	for screenshotType, screenshots := range l.ScreenshotSets {
+		screenshotSets[screenshotType] = screenshots
+	}
+
+	for screenshotType, screenshots := range override.ScreenshotSets {
+		screenshotSets[screenshotType] = screenshots
+	}
+
+	if len(previewSets) > 0 {
+		l.PreviewSets = previewSets
+	}
+
+	if len(screenshotSets) > 0 {
+		l.ScreenshotSets = screenshotSets
+	}
+
+	screenshotFallbacks := make(ScreenshotFallbacks, len(l.ScreenshotFallbacks)+len(override.ScreenshotFallbacks))
+	for screenshotType, from := range l.ScreenshotFallbacks {
+		screenshotFallbacks[screenshotType] = from
+	}
+
+	for screenshotType, from := range override.ScreenshotFallbacks {
+		screenshotFallbacks[screenshotType] = from
+	}
+
+	if len(screenshotFallbacks) > 0 {
+		l.ScreenshotFallbacks = screenshotFallbacks
+	}
+
+	return l
+}
@@ -0,0 +1,106 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion_PlatformVersions(t *testing.T) {
+	t.Parallel()
+
+	version := Version{Platform: PlatformiOS, Copyright: "2020 App"}
+	assert.Equal(t, []Version{version}, version.PlatformVersions())
+
+	enabled := true
+	version = Version{
+		Copyright:   "2020 App",
+		ReleaseType: ReleaseTypeManual,
+		Localizations: VersionLocalizations{
+			"en-US": {
+				Description: "My App",
+				Keywords:    "Apps",
+				ScreenshotSets: ScreenshotSets{
+					ScreenshotTypeiPhone65: {{Path: "iphone65.png"}},
+				},
+				ScreenshotFallbacks: ScreenshotFallbacks{
+					ScreenshotTypeiPhone55: ScreenshotTypeiPhone65,
+				},
+			},
+			"ja": {Description: "私のアプリ"},
+		},
+		Platforms: VersionPlatforms{
+			PlatformMacOS: {
+				Copyright:            "2020 App for Mac",
+				PhasedReleaseEnabled: &enabled,
+				Localizations: VersionLocalizations{
+					"en-US": {
+						Keywords: "Apps, Mac",
+						ScreenshotSets: ScreenshotSets{
+							ScreenshotTypeDesktop: {{Path: "desktop.png"}},
+						},
+						ScreenshotFallbacks: ScreenshotFallbacks{
+							ScreenshotTypeiPhone58: ScreenshotTypeiPhone65,
+						},
+					},
+				},
+			},
+			PlatformiOS: {},
+		},
+	}
+
+	versions := version.PlatformVersions()
+	assert.Len(t, versions, 2)
+
+	iOS := versions[0]
+	assert.Equal(t, PlatformiOS, iOS.Platform)
+	assert.Nil(t, iOS.Platforms)
+	assert.Equal(t, "2020 App", iOS.Copyright)
+	assert.False(t, iOS.PhasedReleaseEnabled)
+	assert.Equal(t, version.Localizations, iOS.Localizations)
+
+	macOS := versions[1]
+	assert.Equal(t, PlatformMacOS, macOS.Platform)
+	assert.Equal(t, "2020 App for Mac", macOS.Copyright)
+	assert.Equal(t, ReleaseTypeManual, macOS.ReleaseType)
+	assert.True(t, macOS.PhasedReleaseEnabled)
+	assert.Equal(t, VersionLocalizations{
+		"en-US": {
+			Description: "My App",
+			Keywords:    "Apps, Mac",
+			ScreenshotSets: ScreenshotSets{
+				ScreenshotTypeiPhone65: {{Path: "iphone65.png"}},
+				ScreenshotTypeDesktop:  {{Path: "desktop.png"}},
+			},
+			ScreenshotFallbacks: ScreenshotFallbacks{
+				ScreenshotTypeiPhone55: ScreenshotTypeiPhone65,
+				ScreenshotTypeiPhone58: ScreenshotTypeiPhone65,
+			},
+		},
+		"ja": {Description: "私のアプリ"},
+	}, macOS.Localizations)
+
+	// Overrides do not leak into the shared localizations
+	assert.Len(t, version.Localizations["en-US"].ScreenshotSets, 1)
+	assert.Len(t, version.Localizations["en-US"].ScreenshotFallbacks, 1)
+}
@@ -32,6 +32,10 @@ import (
 	"github.com/cidertool/cider/pkg/config"
 )
 
+// DefaultMaxTesterRemovals is the number of beta testers that can be removed in a single run unless
+// configured otherwise.
+const DefaultMaxTesterRemovals = 10
+
 // PublishMode describes which review destination to publish to.
 type PublishMode string
 
@@ -55,6 +59,8 @@ type Context struct {
 	ctx.Context
 	Config                  config.Project
 	RawConfig               config.Project
+	ConfigSource            *config.Source
+	Profile                 string
 	Env                     Env
 	Date                    time.Time
 	Git                     GitInfo
@@ -64,12 +70,15 @@ type Context struct {
 	PublishMode             PublishMode
 	Log                     log.Interface
 	MaxProcesses            int
+	MaxTesterRemovals       int
 	SkipGit                 bool
 	SkipUpdatePricing       bool
 	SkipUpdateMetadata      bool
 	SkipSubmit              bool
 	OverrideBetaGroups      bool
 	OverrideBetaTesters     bool
+	PruneDryRun             bool
+	PublicLinks             map[string]map[string]string
 	VersionIsInitialRelease bool
 	Version                 string
 	Build                   string
@@ -113,13 +122,15 @@ func NewWithTimeout(config config.Project, timeout time.Duration) (*Context, ctx
 // Wrap wraps an existing context.
 func Wrap(ctx ctx.Context, config config.Project) *Context {
 	return &Context{
-		Context:      ctx,
-		Config:       config,
-		RawConfig:    config,
-		Env:          splitEnv(os.Environ()),
-		Date:         time.Now(),
-		Log:          log.New(),
-		MaxProcesses: 1,
+		Context:           ctx,
+		Config:            config,
+		RawConfig:         config,
+		Env:               splitEnv(os.Environ()),
+		Date:              time.Now(),
+		Log:               log.New(),
+		MaxProcesses:      1,
+		MaxTesterRemovals: DefaultMaxTesterRemovals,
+		PublicLinks:       map[string]map[string]string{},
 	}
 }
 
@@ -64,6 +64,7 @@ func main() {
 		cmdConfig(),
 		cmdMan(),
 		cmdMarkdown(),
+		cmdSchema(),
 	)
 
 	root.cmd = cmd
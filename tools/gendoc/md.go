@@ -48,14 +48,16 @@ type pageNavField struct {
 }
 
 func runDocsMdCmd(cmd *cobra.Command, args []string) error {
-	var orderRoot, orderInit, orderRelease, orderCheck, orderCompletions = 0, 1, 2, 3, 4
+	var orderRoot, orderInit, orderRelease, orderCheck, orderConfig, orderConfigMigrate, orderCompletions = 0, 1, 2, 3, 4, 5, 6
 
 	var pageNavFields = map[string]pageNavField{
-		"cider.md":             {order: orderRoot},
-		"cider_init.md":        {order: orderInit},
-		"cider_release.md":     {order: orderRelease},
-		"cider_check.md":       {order: orderCheck},
-		"cider_completions.md": {order: orderCompletions},
+		"cider.md":                {order: orderRoot},
+		"cider_init.md":           {order: orderInit},
+		"cider_release.md":        {order: orderRelease},
+		"cider_check.md":          {order: orderCheck},
+		"cider_config.md":         {order: orderConfig},
+		"cider_config_migrate.md": {order: orderConfigMigrate},
+		"cider_completions.md":    {order: orderCompletions},
 	}
 
 	var dir string
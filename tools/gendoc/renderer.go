@@ -139,8 +139,9 @@ func (r *docRenderer) gatherTypes() error {
 	}
 
 	for _, cons := range r.Package.Consts {
-		name, values := r.gatherConsts(cons)
-		r.Values[name] = values
+		if name, values := r.gatherConsts(cons); name != "" {
+			r.Values[name] = values
+		}
 	}
 
 	root, ok := r.Types["Project"]
@@ -210,10 +211,19 @@ func (*docRenderer) gatherConsts(cons *doc.Value) (name string, values []string)
 	values = make([]string, len(cons.Decl.Specs))
 
 	for i, s := range cons.Decl.Specs {
-		if spec, ok := s.(*ast.ValueSpec); ok {
-			name = spec.Type.(*ast.Ident).Name
-			values[i] = spec.Values[0].(*ast.BasicLit).Value
+		spec, ok := s.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		// Untyped constants do not enumerate the values of a config type.
+		ident, ok := spec.Type.(*ast.Ident)
+		if !ok {
+			return "", nil
 		}
+
+		name = ident.Name
+		values[i] = spec.Values[0].(*ast.BasicLit).Value
 	}
 
 	return name, values
@@ -294,12 +304,19 @@ func (r *docRenderer) renderStruct(typ *ast.StructType) {
 		var options []string
 
 		if values, ok := r.Values[typeName]; ok {
+			isArray := strings.HasPrefix(typeNameFormatted, "[")
+
 			if identName := r.asIdentName(typeName); identName != "" {
 				typeNameFormatted = identName
 			} else {
 				typeNameFormatted = "string"
 			}
 
+			// Arrays of enumerated values are still arrays
+			if isArray {
+				typeNameFormatted = "[" + typeNameFormatted + "]"
+			}
+
 			options = values
 		}
 
@@ -0,0 +1,441 @@
+/**
+Copyright (C) 2020 Aaron Sky.
+
+This file is part of Cider, a tool for automating submission
+of apps to Apple's App Stores.
+
+Cider is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+Cider is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with Cider.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/cidertool/cider/internal/closer"
+	"github.com/cidertool/cider/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// ErrCategoriesNotFound indicates that no category IDs could be read from the configuration footer.
+var ErrCategoriesNotFound = errors.New("no category IDs found")
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	schemaID      = "https://cidertool.github.io/cider/schema.json"
+	// schemaEmbedPath is the copy of the schema embedded into the config package for validation.
+	schemaEmbedPath = "pkg/config/schema.json"
+	schemaRefPrefix = "#/$defs/"
+
+	schemaLocaleDef      = "Locale"
+	schemaCategoryDef    = "Category"
+	schemaSubcategoryDef = "Subcategory"
+)
+
+// schemaLocales are the locale codes supported by App Store Connect.
+// nolint: gochecknoglobals
+var schemaLocales = []string{
+	"ar-SA", "ca", "cs", "da", "de-DE", "el", "en-AU", "en-CA", "en-GB", "en-US",
+	"es-ES", "es-MX", "fi", "fr-CA", "fr-FR", "he", "hi", "hr", "hu", "id",
+	"it", "ja", "ko", "ms", "nl-NL", "no", "pl", "pt-BR", "pt-PT", "ro",
+	"ru", "sk", "sv", "th", "tr", "uk", "vi", "zh-Hans", "zh-Hant",
+}
+
+// schemaFieldDefs maps fields typed as plain strings in the config package to the
+// definitions that describe their values more precisely.
+// nolint: gochecknoglobals
+var schemaFieldDefs = map[string]string{
+	"App.primaryLocale":                 schemaLocaleDef,
+	"Categories.primary":                schemaCategoryDef,
+	"Categories.secondary":              schemaCategoryDef,
+	"Categories.primarySubcategories":   schemaSubcategoryDef,
+	"Categories.secondarySubcategories": schemaSubcategoryDef,
+}
+
+// nolint: gochecknoglobals
+var schemaCategoryPattern = regexp.MustCompile("^( *)- `\"([A-Z_]+)\"`$")
+
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	PropertyNames        *jsonSchema            `json:"propertyNames,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	MinItems             int                    `json:"minItems,omitempty"`
+	MaxItems             int                    `json:"maxItems,omitempty"`
+	Minimum              int                    `json:"minimum,omitempty"`
+	Maximum              int                    `json:"maximum,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// CmdSchema returns the cobra.Command for the schema subcommand.
+func cmdSchema() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Generate a JSON Schema for the Cider configuration file.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runDocsSchemaCmd,
+	}
+}
+
+func runDocsSchemaCmd(cmd *cobra.Command, args []string) error {
+	var path string
+	if len(args) == 0 {
+		path = defaultDocsPath
+	} else {
+		path = args[0]
+	}
+
+	log.WithField("path", path).Info("generating configuration schema")
+
+	err := genConfigSchema(path)
+	if err != nil {
+		log.Error("generation failed")
+	} else {
+		log.Info("generation completed successfully")
+	}
+
+	return err
+}
+
+func genConfigSchema(dir string) error {
+	r, err := newRenderer()
+	if err != nil {
+		return err
+	}
+
+	schema, err := r.Schema(filepath.Join(dir, "configuration-footer.md"))
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	data = append(data, '\n')
+
+	for _, path := range []string{filepath.Join(dir, "schema.json"), schemaEmbedPath} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Schema builds a JSON Schema describing the configuration file from the types gathered by the renderer.
+// Known category IDs are read from the list in the configuration footer at footerPath.
+func (r *docRenderer) Schema(footerPath string) (*jsonSchema, error) {
+	categories, subcategories, err := readCategories(footerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := map[string]*jsonSchema{
+		schemaLocaleDef: {
+			Description: "Locale code supported by App Store Connect.",
+			Type:        "string",
+			Enum:        schemaLocales,
+		},
+		schemaCategoryDef:    knownStringsSchema("App category ID.", categories),
+		schemaSubcategoryDef: knownStringsSchema("App subcategory ID.", subcategories),
+	}
+
+	for name, values := range r.Values {
+		enum := make([]string, len(values))
+
+		for i, value := range values {
+			if enum[i], err = strconv.Unquote(value); err != nil {
+				return nil, err
+			}
+		}
+
+		defs[name] = &jsonSchema{
+			Description: schemaDescription(r.typeDoc(name)),
+			Type:        "string",
+			Enum:        enum,
+		}
+	}
+
+	for _, opt := range r.TypesToRender {
+		switch typ := opt.Type.(type) {
+		case *ast.StructType:
+			defs[opt.Name] = r.structSchema(opt.Name, typ)
+		case *ast.MapType:
+			defs[opt.Name] = r.mapSchema(opt.Name, typ)
+		default:
+			continue
+		}
+
+		defs[opt.Name].Description = schemaDescription(opt.Doc)
+	}
+
+	// The schema version sits alongside the apps at the top of the file.
+	if project, ok := defs["Project"]; ok {
+		project.Properties = map[string]*jsonSchema{
+			config.SchemaVersionKey: {
+				Description: "Version of the configuration schema the file is written against. " +
+					"Older files can be upgraded with `cider config migrate`.",
+				Type:    "integer",
+				Minimum: 1,
+				Maximum: config.SchemaVersion,
+			},
+		}
+	}
+
+	return &jsonSchema{
+		Schema:      schemaDialect,
+		ID:          schemaID,
+		Title:       "Cider configuration",
+		Description: schemaDescription(r.Package.Doc),
+		Ref:         schemaRefPrefix + "Project",
+		Defs:        defs,
+	}, nil
+}
+
+func (r *docRenderer) structSchema(name string, typ *ast.StructType) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+
+	for _, field := range typ.Fields.List {
+		if len(field.Names) == 0 {
+			// embedded struct field
+			embedded, ok := r.Types[getTypeName(field.Type)]
+			if !ok {
+				continue
+			}
+
+			for _, s := range embedded.Decl.Specs {
+				if spec, ok := s.(*ast.TypeSpec); ok {
+					if f, ok := spec.Type.(*ast.StructType); ok {
+						inner := r.structSchema(spec.Name.Name, f)
+						for key, value := range inner.Properties {
+							schema.Properties[key] = value
+						}
+
+						schema.Required = append(schema.Required, inner.Required...)
+					}
+				}
+			}
+
+			continue
+		}
+
+		tag, required := getTagValue(field.Tag)
+
+		property := r.typeSchema(field.Type)
+		if def, ok := schemaFieldDefs[name+"."+tag]; ok {
+			if property.Items != nil {
+				property.Items = &jsonSchema{Ref: schemaRefPrefix + def}
+			} else {
+				property = &jsonSchema{Ref: schemaRefPrefix + def}
+			}
+		}
+
+		property.Description = schemaDescription(field.Doc.Text())
+		schema.Properties[tag] = property
+
+		if required && !strings.HasPrefix(formatTypeName(field.Type), "[") {
+			schema.Required = append(schema.Required, tag)
+		}
+	}
+
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+func (r *docRenderer) mapSchema(name string, typ *ast.MapType) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		AdditionalProperties: r.typeSchema(typ.Value),
+	}
+
+	if _, ok := r.Values[getTypeName(typ.Key)]; ok {
+		schema.PropertyNames = &jsonSchema{Ref: schemaRefPrefix + getTypeName(typ.Key)}
+	} else if strings.HasSuffix(name, "Localizations") {
+		schema.PropertyNames = &jsonSchema{Ref: schemaRefPrefix + schemaLocaleDef}
+	}
+
+	return schema
+}
+
+func (r *docRenderer) typeSchema(expr ast.Expr) *jsonSchema {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return r.typeSchema(t.X)
+	case *ast.ArrayType:
+		schema := &jsonSchema{
+			Type:  "array",
+			Items: r.typeSchema(t.Elt),
+		}
+
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			// Fixed-length arrays must be given in full.
+			schema.MaxItems, _ = strconv.Atoi(lit.Value)
+			schema.MinItems = schema.MaxItems
+		}
+
+		return schema
+	case *ast.MapType:
+		return r.mapSchema("", t)
+	case *ast.SelectorExpr:
+		if t.Sel.Name == "Time" {
+			return &jsonSchema{Type: "string", Format: "date-time"}
+		}
+	case *ast.Ident:
+		if _, ok := r.Values[t.Name]; ok {
+			return &jsonSchema{Ref: schemaRefPrefix + t.Name}
+		} else if _, ok := r.Types[t.Name]; ok {
+			return &jsonSchema{Ref: schemaRefPrefix + t.Name}
+		}
+
+		switch t.Name {
+		case "string":
+			return &jsonSchema{Type: "string"}
+		case "bool":
+			return &jsonSchema{Type: "boolean"}
+		case "int", "int64":
+			return &jsonSchema{Type: "integer"}
+		case "float64":
+			return &jsonSchema{Type: "number"}
+		}
+	}
+
+	log.Warnf("no schema for %s", formatTypeName(expr))
+
+	return &jsonSchema{}
+}
+
+func (r *docRenderer) typeDoc(name string) string {
+	typ, ok := r.Types[name]
+	if !ok {
+		return ""
+	}
+
+	return typ.Doc
+}
+
+// knownStringsSchema accepts any string, while still offering the known values for completion.
+func knownStringsSchema(description string, values []string) *jsonSchema {
+	return &jsonSchema{
+		Description: description,
+		AnyOf: []*jsonSchema{
+			{Type: "string", Enum: values},
+			{Type: "string"},
+		},
+	}
+}
+
+// schemaDescription condenses a Go doc comment into plain text, dropping YAML examples.
+func schemaDescription(s string) string {
+	var paragraphs []string
+
+	var current []string
+
+	var inYamlBlock bool
+
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "```"):
+			inYamlBlock = !inYamlBlock
+		case inYamlBlock, line == ".":
+			continue
+		case line == "":
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, " "))
+				current = nil
+			}
+		default:
+			current = append(current, line)
+		}
+	}
+
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, " "))
+	}
+
+	var kept = make([]string, 0, len(paragraphs))
+
+	for _, p := range paragraphs {
+		// Introductions to the YAML examples dropped above.
+		if strings.HasPrefix(p, "For example") && strings.HasSuffix(p, ":") {
+			continue
+		}
+
+		kept = append(kept, p)
+	}
+
+	return strings.Join(kept, "\n\n")
+}
+
+func readCategories(path string) (categories []string, subcategories []string, err error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer closer.Close(f)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		match := schemaCategoryPattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		if match[1] == "" {
+			categories = append(categories, match[2])
+		} else {
+			subcategories = append(subcategories, match[2])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrCategoriesNotFound, path)
+	}
+
+	return categories, subcategories, nil
+}